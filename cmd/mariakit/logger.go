@@ -0,0 +1,61 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"log"
+)
+
+// logLevel controls which messages a logger prints
+type logLevel int
+
+const (
+	levelQuiet   logLevel = iota // errors only
+	levelNormal                  // progress and warnings
+	levelVerbose                 // per-table detail as well
+)
+
+// levelLogger prints progress to out and warnings to errOut, depending on its level.
+// Fatal errors bypass it and are always printed by the log package.
+type levelLogger struct {
+	level  logLevel
+	out    io.Writer
+	errOut io.Writer
+}
+
+// newLogger returns a levelLogger for the -quiet and -verbose flags, which are
+// mutually exclusive
+func newLogger(out, errOut io.Writer, quiet, verbose bool) (*levelLogger, error) {
+	switch {
+	case quiet && verbose:
+		return nil, errors.New("-quiet and -verbose cannot be combined")
+	case quiet:
+		return &levelLogger{level: levelQuiet, out: out, errOut: errOut}, nil
+	case verbose:
+		return &levelLogger{level: levelVerbose, out: out, errOut: errOut}, nil
+	default:
+		return &levelLogger{level: levelNormal, out: out, errOut: errOut}, nil
+	}
+}
+
+// Infof prints a progress message unless the logger is quiet
+func (l *levelLogger) Infof(format string, args ...any) {
+	if l.level >= levelNormal {
+		fmt.Fprintf(l.out, format+"\n", args...)
+	}
+}
+
+// Debugf prints a detail message if the logger is verbose
+func (l *levelLogger) Debugf(format string, args ...any) {
+	if l.level >= levelVerbose {
+		fmt.Fprintf(l.out, format+"\n", args...)
+	}
+}
+
+// Warnf prints a warning in the log package's format unless the logger is quiet
+func (l *levelLogger) Warnf(format string, args ...any) {
+	if l.level >= levelNormal {
+		log.New(l.errOut, "", log.LstdFlags).Printf("Warning: "+format, args...)
+	}
+}
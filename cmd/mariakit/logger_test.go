@@ -0,0 +1,44 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestLevelLogger(t *testing.T) {
+	tests := []struct {
+		name          string
+		quiet         bool
+		verbose       bool
+		expectedOut   string
+		expectWarning bool
+	}{
+		{"quiet", true, false, "", false},
+		{"normal", false, false, "progress\n", true},
+		{"verbose", false, true, "progress\ndetail users\n", true},
+	}
+
+	for _, test := range tests {
+		var out, errOut bytes.Buffer
+		logger, err := newLogger(&out, &errOut, test.quiet, test.verbose)
+		if err != nil {
+			t.Fatalf("newLogger(%s) error: %v", test.name, err)
+		}
+
+		logger.Infof("progress")
+		logger.Debugf("detail %s", "users")
+		logger.Warnf("slow %s", "orders")
+
+		if out.String() != test.expectedOut {
+			t.Errorf("%s output = %q, expected %q", test.name, out.String(), test.expectedOut)
+		}
+		if hasWarning := strings.Contains(errOut.String(), "Warning: slow orders"); hasWarning != test.expectWarning {
+			t.Errorf("%s warning output = %q, expected warning: %t", test.name, errOut.String(), test.expectWarning)
+		}
+	}
+
+	if _, err := newLogger(&bytes.Buffer{}, &bytes.Buffer{}, true, true); err == nil {
+		t.Error("newLogger(quiet, verbose) expected error, got nil")
+	}
+}
@@ -1,24 +1,54 @@
 package main
 
 import (
+	"bufio"
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"go/format"
+	"go/token"
+	"io"
 	"log"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"time"
+	"unicode"
 
 	"github.com/louis77/mariakit/schema"
 )
 
 func main() {
 	var (
-		connectionString = flag.String("conn", "", "MariaDB connection string (required)")
+		connectionString = flag.String("conn", "", "MariaDB connection string (required unless -sql-file is set)")
+		sqlFile          = flag.String("sql-file", "", "Schema dump with CREATE TABLE statements to generate from instead of a database")
 		outputDir        = flag.String("output", "./generated", "Output directory for generated files")
-		generateType     = flag.String("type", "all", "Type of code to generate: all, constants, structs, enums")
+		generateType     = flag.String("type", "all", "Type of code to generate: all, constants, structs, types, columntypes (alias columnnames), queries, enums, enumtypes, metadata, schemainfo, repositories, schemajson, typemap")
+		schemaName       = flag.String("schema", "", "Database schema to inspect, overriding the one in the connection string")
+		packageFlag      = flag.String("package", "", "Package name for generated files (default: derived from output directory)")
 		configPath       = flag.String("config", "mariakit.yaml", "Path to configuration file")
+		include          = flag.String("include", "", "Comma-separated glob patterns of tables to generate")
+		exclude          = flag.String("exclude", "", "Comma-separated glob patterns of tables to skip")
+		tablesFile       = flag.String("tables-file", "", "File with table names to generate, one per line (# starts a comment)")
+		continueOnError  = flag.Bool("continue-on-error", false, "Skip tables that fail inspection and exit non-zero at the end")
+		ormPreset        = flag.String("orm", "", "Struct tag preset: sqlx, bun or gorm (default: sqlx)")
+		singleFile       = flag.Bool("single-file", false, "Write all generated code to a single models.go (requires -type=all)")
+		filePerTable     = flag.Bool("file-per-table", false, "Write each table's struct and methods to <table>.go instead of structs.go")
+		structsOutput    = flag.String("structs-output", "", "Output directory for structs.go (default: -output)")
+		constantsOutput  = flag.String("constants-output", "", "Output directory for column_constants.go (default: -output)")
+		enumsOutput      = flag.String("enums-output", "", "Output directory for enum_constants.go (default: -output)")
+		goGenerate       = flag.Bool("go-generate", false, "Emit a //go:generate directive rerunning mariakit into metadata.go")
+		stateFile        = flag.String("state", "", "State file recording table and input signatures; skips generation when nothing changed since the last complete run")
+		planOnly         = flag.Bool("plan", false, "Print the tables, enums and files a run would generate, then exit without writing anything")
+		extStubs         = flag.Bool("ext-stubs", false, "Create a <table>_ext.go stub for hand-written methods next to the structs if it does not exist yet; existing files are never touched")
+		overwrite        = flag.Bool("overwrite", true, "Overwrite existing files; with -overwrite=false, files without the generated-code banner are never replaced")
+		goGenerateConn   = flag.String("go-generate-conn", "", "Connection string for the //go:generate directive, e.g. '$DATABASE_URL' (default: -conn with the password redacted)")
+		quiet            = flag.Bool("quiet", false, "Only print errors")
+		verbose          = flag.Bool("verbose", false, "Also print per-table inspection timing")
 		help             = flag.Bool("help", false, "Show help message")
 	)
 
@@ -29,17 +59,47 @@ func main() {
 		return
 	}
 
-	if *connectionString == "" {
-		log.Fatal("Connection string is required. Use -conn flag.")
+	logger, err := newLogger(os.Stdout, os.Stderr, *quiet, *verbose)
+	if err != nil {
+		log.Fatal(err)
 	}
 
-	// Create output directory if it doesn't exist
-	if err := os.MkdirAll(*outputDir, 0755); err != nil {
-		log.Fatalf("Failed to create output directory: %v", err)
+	if *connectionString == "" && *sqlFile == "" {
+		log.Fatal("Connection string is required. Use -conn flag, or -sql-file to read a schema dump.")
+	}
+	if *connectionString != "" && *sqlFile != "" {
+		log.Fatal("-conn and -sql-file cannot be combined")
 	}
 
-	// Extract package name from output directory
-	packageName := filepath.Base(*outputDir)
+	// Use the explicit package name or derive one from the output directory
+	packageName := *packageFlag
+	if packageName == "" {
+		packageName = packageNameFromDir(*outputDir)
+	} else if !token.IsIdentifier(packageName) {
+		log.Fatalf("Invalid package name: %s", packageName)
+	}
+
+	defaultTarget := outputTarget{dir: *outputDir, packageName: packageName}
+	targets := outputTargets(defaultTarget, map[string]string{
+		"structs":   *structsOutput,
+		"constants": *constantsOutput,
+		"enums":     *enumsOutput,
+	})
+
+	// Create output directories if they don't exist; a plan or type map
+	// preview writes nothing
+	typeMapOnly := strings.ToLower(*generateType) == "typemap"
+	outputDirs := []string{*outputDir}
+	for _, target := range targets {
+		outputDirs = append(outputDirs, target.dir)
+	}
+	if !*planOnly && !typeMapOnly {
+		for _, dir := range outputDirs {
+			if err := os.MkdirAll(dir, 0755); err != nil {
+				log.Fatalf("Failed to create output directory: %v", err)
+			}
+		}
+	}
 
 	// Load configuration
 	config, err := schema.LoadConfig(*configPath)
@@ -47,113 +107,839 @@ func main() {
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
 
+	if *continueOnError {
+		config.ContinueOnError = true
+	}
+	if *singleFile {
+		if strings.ToLower(*generateType) != "all" {
+			log.Fatal("-single-file can only be used with -type=all")
+		}
+		if len(targets) > 0 {
+			log.Fatal("-single-file cannot be combined with per-type output directories")
+		}
+		config.SingleFile = true
+	}
+	if *filePerTable {
+		config.FilePerTable = true
+		if err := config.Validate(); err != nil {
+			log.Fatalf("Invalid -file-per-table flag: %v", err)
+		}
+	}
+	if *ormPreset != "" {
+		config.ORMPreset = *ormPreset
+		if err := config.Validate(); err != nil {
+			log.Fatalf("Invalid -orm flag: %v", err)
+		}
+	}
+	if *schemaName != "" {
+		config.Schema = *schemaName
+	}
+	if *goGenerate {
+		sourceFlag := "-sql-file=" + *sqlFile
+		if *sqlFile == "" {
+			conn := *goGenerateConn
+			if conn == "" {
+				conn = schema.RedactDSN(*connectionString)
+			}
+			sourceFlag = "-conn=" + conn
+		}
+		config.GoGenerate = goGenerateCommand(sourceFlag, packageName)
+	}
+	if *include != "" {
+		config.Include = append(config.Include, splitList(*include)...)
+	}
+	if *exclude != "" {
+		config.Exclude = append(config.Exclude, splitList(*exclude)...)
+	}
+	if *tablesFile != "" {
+		tables, err := readTablesFile(*tablesFile)
+		if err != nil {
+			log.Fatalf("Failed to read tables file: %v", err)
+		}
+		config.Include = append(config.Include, tables...)
+	}
+
 	// Check if config file exists and report
 	if _, err := os.Stat(*configPath); err == nil {
-		fmt.Printf("📄 Using configuration file: %s\n", *configPath)
+		logger.Infof("📄 Using configuration file: %s", *configPath)
 	} else {
-		fmt.Printf("📄 No configuration file found at %s, using defaults\n", *configPath)
+		logger.Infof("📄 No configuration file found at %s, using defaults", *configPath)
 	}
 
 	// Create schema generator with config
-	generator, err := schema.NewSchemaGeneratorWithConfig(*connectionString, config)
-	if err != nil {
-		log.Fatalf("Failed to create schema generator: %v", err)
+	var generator *schema.SchemaGenerator
+	if *sqlFile != "" {
+		source, err := schema.LoadSQLDump(*sqlFile)
+		if err != nil {
+			log.Fatalf("Failed to load schema dump: %v", err)
+		}
+		generator = schema.NewSchemaGeneratorFromSource(source, config)
+	} else {
+		generator, err = schema.NewSchemaGeneratorWithConfig(*connectionString, config)
+		if err != nil {
+			log.Fatalf("Failed to create schema generator for %s: %v", schema.RedactDSN(*connectionString), err)
+		}
+		if version, err := generator.ServerVersion(context.Background()); err == nil && !version.IsZero() {
+			logger.Debugf("   server version %s", version)
+		}
 	}
 	defer generator.Close()
 
+	if logger.level >= levelVerbose {
+		generator.SetInspectHook(func(tableName string, duration time.Duration, err error) {
+			if err != nil {
+				logger.Debugf("   inspected %s in %s: %v", tableName, duration.Round(time.Microsecond), err)
+				return
+			}
+			logger.Debugf("   inspected %s in %s", tableName, duration.Round(time.Microsecond))
+		})
+	}
+
 	ctx := context.Background()
 
-	fmt.Println("🔍 Inspecting MariaDB schema...")
+	logger.Infof("🔍 Inspecting MariaDB schema...")
+
+	if *planOnly {
+		var types []string
+		if strings.ToLower(*generateType) != "all" {
+			types = []string{strings.ToLower(*generateType)}
+		}
+		plan, err := generator.Plan(ctx, types)
+		if err != nil {
+			log.Fatalf("Failed to plan generation: %v", err)
+		}
+		structFiles := map[string]bool{"repositories.go": true}
+		if config.FilePerTable {
+			structFiles["structs.go"] = true
+			for _, table := range plan.Tables {
+				structFiles[schema.TableFileName(table.Name)] = true
+			}
+		}
+		writePlan(os.Stdout, plan, func(filename string) string {
+			if structFiles[filename] {
+				return filepath.Join(targetFor(targets, defaultTarget, "structs").dir, filename)
+			}
+			for generateType, target := range targets {
+				if schema.SeparableTypes[generateType] == filename {
+					return filepath.Join(target.dir, filename)
+				}
+			}
+			return filepath.Join(*outputDir, filename)
+		})
+		return
+	}
+
+	if typeMapOnly {
+		mappings, err := generator.TypeMap(ctx)
+		if err != nil {
+			log.Fatalf("Failed to map column types: %v", err)
+		}
+		writeTypeMap(os.Stdout, mappings)
+		return
+	}
+
+	// With a state file, only regenerate when a table or an input changed
+	// since the last complete run. The tables inspected for the signatures
+	// are reused by the generators.
+	defer generator.CacheInspection()()
+	var (
+		signatures      map[string]string
+		inputs          string
+		unchangedTables = make(map[string]bool)
+		removedTables   []string
+	)
+	if *stateFile != "" {
+		state, err := schema.LoadState(*stateFile)
+		if err != nil {
+			log.Fatalf("Failed to load state: %v", err)
+		}
+		signatures, err = generator.TableSignatures(ctx)
+		if err != nil {
+			log.Fatalf("Failed to compute table signatures: %v", err)
+		}
+		inputs, err = schema.InputsSignature(config, flagInputs()...)
+		if err != nil {
+			log.Fatalf("Failed to compute the inputs signature: %v", err)
+		}
+
+		changed := state.ChangedTables(inputs, signatures)
+		if len(changed) == 0 {
+			logger.Infof("✨ No table changed since the last run, keeping the generated files")
+			return
+		}
+		if state.Inputs != inputs {
+			logger.Infof("🔄 Configuration or flags changed since the last run, regenerating all tables")
+		} else {
+			logger.Infof("🔄 Changed tables: %s", strings.Join(changed, ", "))
+			for table := range signatures {
+				unchangedTables[table] = true
+			}
+			for _, table := range changed {
+				delete(unchangedTables, table)
+			}
+		}
+		for table := range state.Tables {
+			if _, exists := signatures[table]; !exists {
+				removedTables = append(removedTables, table)
+			}
+		}
+	}
+
+	// With -file-per-table, the <table>.go files of unchanged tables are kept
+	// as they are and those of removed tables are deleted. Tables sharing
+	// an embedded mixin depend on each other, so they are always regenerated.
+	keptFiles := make(map[string]bool)
+	keepUnchangedTables := func(outputs map[string]string, dir string) {
+		if !config.FilePerTable {
+			return
+		}
+		if len(config.EmbedMixin) == 0 {
+			for table := range unchangedTables {
+				outputPath := filepath.Join(dir, schema.TableFileName(table))
+				if _, err := os.Stat(outputPath); err == nil {
+					delete(outputs, outputPath)
+					keptFiles[outputPath] = true
+				}
+			}
+		}
+		for _, table := range removedTables {
+			outputPath := filepath.Join(dir, schema.TableFileName(table))
+			if _, generated := outputs[outputPath]; generated {
+				continue
+			}
+			handWritten, err := clobberedFiles([]string{outputPath})
+			if err != nil {
+				log.Fatalf("Failed to check existing files: %v", err)
+			}
+			if len(handWritten) > 0 {
+				continue
+			}
+			if err := os.Remove(outputPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+				log.Fatalf("Failed to remove file %s: %v", outputPath, err)
+			} else if err == nil {
+				logger.Infof("🗑️ Removed %s", outputPath)
+			}
+		}
+	}
+
+	// With -overwrite=false, never replace files that were not generated
+	checkOverwrite := func(outputPaths ...string) {
+		if *overwrite {
+			return
+		}
+		clobbered, err := clobberedFiles(outputPaths)
+		if err != nil {
+			log.Fatalf("Failed to check existing files: %v", err)
+		}
+		if len(clobbered) > 0 {
+			log.Fatalf("Refusing to overwrite files without the generated-code banner (-overwrite=false):\n  %s", strings.Join(clobbered, "\n  "))
+		}
+	}
 
 	// Generate code based on type
 	switch strings.ToLower(*generateType) {
 	case "all":
-		fmt.Println("📝 Generating all code types...")
-		files, err := generator.GenerateAll(ctx, packageName)
+		logger.Infof("📝 Generating all code types...")
+		root, separate := splitPackages(defaultTarget, targets)
+		packages, err := generator.GenerateAllSplit(ctx, root, separate)
 		if err != nil {
 			log.Fatalf("Failed to generate code: %v", err)
 		}
 
-		for filename, content := range files {
-			outputPath := filepath.Join(*outputDir, filename)
-			if err := os.WriteFile(outputPath, []byte(content), 0644); err != nil {
+		// Types with their own directory are written to their package
+		dirs := map[string]string{root.ImportPath: *outputDir}
+		for generateType, target := range targets {
+			dirs[separate[generateType].ImportPath] = target.dir
+		}
+		outputs := make(map[string]string)
+		for importPath, files := range packages {
+			for filename, content := range files {
+				outputs[filepath.Join(dirs[importPath], filename)] = content
+			}
+		}
+		keepUnchangedTables(outputs, targetFor(targets, defaultTarget, "structs").dir)
+
+		outputPaths := make([]string, 0, len(outputs))
+		for outputPath := range outputs {
+			outputPaths = append(outputPaths, outputPath)
+		}
+		sort.Strings(outputPaths)
+		checkOverwrite(outputPaths...)
+
+		for _, outputPath := range outputPaths {
+			if err := os.WriteFile(outputPath, []byte(outputs[outputPath]), 0644); err != nil {
 				log.Fatalf("Failed to write file %s: %v", outputPath, err)
 			}
-			fmt.Printf("✅ Generated %s\n", outputPath)
+			logger.Infof("✅ Generated %s", outputPath)
 		}
 
 	case "constants":
-		fmt.Println("📝 Generating column constants...")
-		content, err := generator.GenerateColumnConstants(ctx, packageName)
+		logger.Infof("📝 Generating column constants...")
+		target := targetFor(targets, defaultTarget, "constants")
+		content, err := generator.GenerateColumnConstants(ctx, target.packageName)
 		if err != nil {
 			log.Fatalf("Failed to generate column constants: %v", err)
 		}
 
-		outputPath := filepath.Join(*outputDir, "column_constants.go")
+		outputPath := filepath.Join(target.dir, "column_constants.go")
+		checkOverwrite(outputPath)
 		if err := os.WriteFile(outputPath, []byte(content), 0644); err != nil {
 			log.Fatalf("Failed to write file %s: %v", outputPath, err)
 		}
-		fmt.Printf("✅ Generated %s\n", outputPath)
+		logger.Infof("✅ Generated %s", outputPath)
 
 	case "structs":
-		fmt.Println("📝 Generating table structs...")
-		content, err := generator.GenerateStructs(ctx, packageName)
+		logger.Infof("📝 Generating table structs...")
+		target := targetFor(targets, defaultTarget, "structs")
+		root, separate := splitPackages(defaultTarget, targets)
+		files := make(map[string]string)
+		if config.FilePerTable {
+			files, err = generator.GenerateStructFilesSplit(ctx, root, separate)
+		} else {
+			files["structs.go"], err = generator.GenerateTypeSplit(ctx, "structs", root, separate)
+		}
 		if err != nil {
 			log.Fatalf("Failed to generate structs: %v", err)
 		}
 
-		outputPath := filepath.Join(*outputDir, "structs.go")
+		outputs := make(map[string]string)
+		for filename, content := range files {
+			outputs[filepath.Join(target.dir, filename)] = content
+		}
+		keepUnchangedTables(outputs, target.dir)
+
+		outputPaths := make([]string, 0, len(outputs))
+		for outputPath := range outputs {
+			outputPaths = append(outputPaths, outputPath)
+		}
+		sort.Strings(outputPaths)
+		checkOverwrite(outputPaths...)
+		for _, outputPath := range outputPaths {
+			if err := os.WriteFile(outputPath, []byte(outputs[outputPath]), 0644); err != nil {
+				log.Fatalf("Failed to write file %s: %v", outputPath, err)
+			}
+			logger.Infof("✅ Generated %s", outputPath)
+		}
+
+	case "types":
+		logger.Infof("📝 Generating column type aliases...")
+		content, err := generator.GenerateColumnTypes(ctx, packageName)
+		if err != nil {
+			log.Fatalf("Failed to generate column type aliases: %v", err)
+		}
+
+		outputPath := filepath.Join(*outputDir, "column_types.go")
+		checkOverwrite(outputPath)
 		if err := os.WriteFile(outputPath, []byte(content), 0644); err != nil {
 			log.Fatalf("Failed to write file %s: %v", outputPath, err)
 		}
-		fmt.Printf("✅ Generated %s\n", outputPath)
+		logger.Infof("✅ Generated %s", outputPath)
+
+	case "columntypes", "columnnames":
+		logger.Infof("📝 Generating typed column names...")
+		content, err := generator.GenerateColumnNameTypes(ctx, packageName)
+		if err != nil {
+			log.Fatalf("Failed to generate typed column names: %v", err)
+		}
+
+		outputPath := filepath.Join(*outputDir, "column_names.go")
+		checkOverwrite(outputPath)
+		if err := os.WriteFile(outputPath, []byte(content), 0644); err != nil {
+			log.Fatalf("Failed to write file %s: %v", outputPath, err)
+		}
+		logger.Infof("✅ Generated %s", outputPath)
+
+	case "queries":
+		logger.Infof("📝 Generating SQL queries...")
+		content, err := generator.GenerateQueries(ctx, packageName)
+		if err != nil {
+			log.Fatalf("Failed to generate queries: %v", err)
+		}
+
+		outputPath := filepath.Join(*outputDir, "queries.go")
+		checkOverwrite(outputPath)
+		if err := os.WriteFile(outputPath, []byte(content), 0644); err != nil {
+			log.Fatalf("Failed to write file %s: %v", outputPath, err)
+		}
+		logger.Infof("✅ Generated %s", outputPath)
 
 	case "enums":
-		fmt.Println("📝 Generating enum constants...")
-		content, err := generator.GenerateEnumConstants(ctx, packageName)
+		logger.Infof("📝 Generating enum constants...")
+		target := targetFor(targets, defaultTarget, "enums")
+		content, err := generator.GenerateEnumConstants(ctx, target.packageName)
 		if err != nil {
 			log.Fatalf("Failed to generate enum constants: %v", err)
 		}
 
-		outputPath := filepath.Join(*outputDir, "enum_constants.go")
+		outputPath := filepath.Join(target.dir, "enum_constants.go")
+		checkOverwrite(outputPath)
+		if err := os.WriteFile(outputPath, []byte(content), 0644); err != nil {
+			log.Fatalf("Failed to write file %s: %v", outputPath, err)
+		}
+		logger.Infof("✅ Generated %s", outputPath)
+
+	case "enumtypes":
+		logger.Infof("📝 Generating enum types...")
+		content, err := generator.GenerateEnumTypes(ctx, packageName)
+		if err != nil {
+			log.Fatalf("Failed to generate enum types: %v", err)
+		}
+
+		outputPath := filepath.Join(*outputDir, "enum_types.go")
+		checkOverwrite(outputPath)
+		if err := os.WriteFile(outputPath, []byte(content), 0644); err != nil {
+			log.Fatalf("Failed to write file %s: %v", outputPath, err)
+		}
+		logger.Infof("✅ Generated %s", outputPath)
+
+	case "metadata":
+		logger.Infof("📝 Generating schema metadata...")
+		content, err := generator.GenerateMetadata(ctx, packageName)
+		if err != nil {
+			log.Fatalf("Failed to generate metadata: %v", err)
+		}
+
+		outputPath := filepath.Join(*outputDir, "metadata.go")
+		checkOverwrite(outputPath)
 		if err := os.WriteFile(outputPath, []byte(content), 0644); err != nil {
 			log.Fatalf("Failed to write file %s: %v", outputPath, err)
 		}
-		fmt.Printf("✅ Generated %s\n", outputPath)
+		logger.Infof("✅ Generated %s", outputPath)
+
+	case "schemainfo":
+		logger.Infof("📝 Generating schema info...")
+		content, err := generator.GenerateSchemaInfo(ctx, packageName)
+		if err != nil {
+			log.Fatalf("Failed to generate schema info: %v", err)
+		}
+
+		outputPath := filepath.Join(*outputDir, "schema_info.go")
+		checkOverwrite(outputPath)
+		if err := os.WriteFile(outputPath, []byte(content), 0644); err != nil {
+			log.Fatalf("Failed to write file %s: %v", outputPath, err)
+		}
+		logger.Infof("✅ Generated %s", outputPath)
+
+	case "repositories":
+		logger.Infof("📝 Generating repository interfaces...")
+		// The interfaces refer to the structs, so they live in their package
+		target := targetFor(targets, defaultTarget, "structs")
+		root, separate := splitPackages(defaultTarget, targets)
+		content, err := generator.GenerateTypeSplit(ctx, "repositories", root, separate)
+		if err != nil {
+			log.Fatalf("Failed to generate repository interfaces: %v", err)
+		}
+
+		outputPath := filepath.Join(target.dir, "repositories.go")
+		checkOverwrite(outputPath)
+		if err := os.WriteFile(outputPath, []byte(content), 0644); err != nil {
+			log.Fatalf("Failed to write file %s: %v", outputPath, err)
+		}
+		logger.Infof("✅ Generated %s", outputPath)
+
+	case "schemajson":
+		logger.Infof("📝 Generating schema descriptor...")
+		content, err := generator.GenerateSchemaJSON(ctx)
+		if err != nil {
+			log.Fatalf("Failed to generate schema descriptor: %v", err)
+		}
+
+		outputPath := filepath.Join(*outputDir, "schema.json")
+		checkOverwrite(outputPath)
+		if err := os.WriteFile(outputPath, []byte(content), 0644); err != nil {
+			log.Fatalf("Failed to write file %s: %v", outputPath, err)
+		}
+		logger.Infof("✅ Generated %s", outputPath)
 
 	default:
-		log.Fatalf("Invalid generate type: %s. Use 'all', 'constants', 'structs', or 'enums'", *generateType)
+		log.Fatalf("Invalid generate type: %s. Use 'all', 'constants', 'structs', 'types', 'columntypes', 'columnnames', 'queries', 'enums', 'enumtypes', 'metadata', 'schemainfo', 'repositories', 'schemajson', or 'typemap'", *generateType)
+	}
+
+	if *extStubs {
+		generateType := strings.ToLower(*generateType)
+		if generateType != "all" && generateType != "structs" {
+			log.Fatal("-ext-stubs can only be used with -type=all or -type=structs")
+		}
+
+		target := targetFor(targets, defaultTarget, "structs")
+		stubs, err := generator.GenerateExtensionStubs(ctx, target.packageName)
+		if err != nil {
+			log.Fatalf("Failed to generate extension stubs: %v", err)
+		}
+
+		filenames := make([]string, 0, len(stubs))
+		for filename := range stubs {
+			filenames = append(filenames, filename)
+		}
+		sort.Strings(filenames)
+		for _, filename := range filenames {
+			outputPath := filepath.Join(target.dir, filename)
+			created, err := writeIfAbsent(outputPath, stubs[filename])
+			if err != nil {
+				log.Fatalf("Failed to write file %s: %v", outputPath, err)
+			}
+			if created {
+				logger.Infof("✅ Created %s", outputPath)
+			}
+		}
 	}
 
 	// Format generated Go files
-	fmt.Println("🔧 Formatting generated Go files...")
-	if err := formatGeneratedFiles(*outputDir); err != nil {
-		log.Printf("Warning: Failed to format generated files: %v", err)
+	logger.Infof("🔧 Formatting generated Go files...")
+	complete := true
+	for _, dir := range outputDirs {
+		if err := formatGeneratedFiles(dir, keptFiles, logger); err != nil {
+			logger.Warnf("Failed to format generated files: %v", err)
+			complete = false
+		}
+	}
+
+	for _, warning := range generator.Warnings() {
+		logger.Warnf("%s", warning)
+	}
+
+	if tableErrors := generator.TableErrors(); len(tableErrors) > 0 {
+		for _, tableErr := range tableErrors {
+			log.Printf("Error: skipped table %s: %v", tableErr.Table, tableErr.Err)
+		}
+		log.Fatalf("Schema code generation completed with %d failed table(s)", len(tableErrors))
+	}
+
+	// Only a complete run may be recorded, or the next run would skip
+	// what this one left unfinished
+	if *stateFile != "" && complete {
+		state := &schema.GenerationState{Inputs: inputs, Tables: signatures}
+		if err := state.Save(*stateFile); err != nil {
+			log.Fatalf("Failed to save state: %v", err)
+		}
+	} else if *stateFile != "" {
+		logger.Warnf("Not updating %s after an incomplete run", *stateFile)
+	}
+
+	logger.Infof("🎉 Schema code generation completed successfully!")
+}
+
+// outputTarget is the directory generated files are written to and their package name
+type outputTarget struct {
+	dir         string
+	packageName string
+}
+
+// outputTargets returns the targets of the generation types given their own
+// directory in dirs, each deriving its package name from its directory. Types
+// without a directory or with the default one are left out.
+func outputTargets(defaultTarget outputTarget, dirs map[string]string) map[string]outputTarget {
+	targets := make(map[string]outputTarget)
+	for generateType, dir := range dirs {
+		if dir == "" || filepath.Clean(dir) == filepath.Clean(defaultTarget.dir) {
+			continue
+		}
+		targets[generateType] = outputTarget{dir: dir, packageName: packageNameFromDir(dir)}
+	}
+	return targets
+}
+
+// targetFor returns the target of a generation type, falling back to the default target
+func targetFor(targets map[string]outputTarget, defaultTarget outputTarget, generateType string) outputTarget {
+	if target, exists := targets[generateType]; exists {
+		return target
+	}
+	return defaultTarget
+}
+
+// flagInputs returns every flag with its value, set or default, for the
+// inputs signature of a -state run. The state file itself is left out so the
+// signature does not depend on where it is kept.
+func flagInputs() []string {
+	var inputs []string
+	flag.VisitAll(func(f *flag.Flag) {
+		if f.Name != "state" {
+			inputs = append(inputs, f.Name+"="+f.Value.String())
+		}
+	})
+	return inputs
+}
+
+// splitPackages returns the packages of the default target and of the
+// generation types with their own directory. Import paths are only needed to
+// qualify references between the packages, so without separate directories
+// the default target gets none.
+func splitPackages(defaultTarget outputTarget, targets map[string]outputTarget) (schema.GeneratedPackage, map[string]schema.GeneratedPackage) {
+	root := schema.GeneratedPackage{Name: defaultTarget.packageName}
+	if len(targets) == 0 {
+		return root, nil
+	}
+
+	var err error
+	if root.ImportPath, err = importPathForDir(defaultTarget.dir); err != nil {
+		log.Fatalf("Failed to resolve the import path of %s: %v", defaultTarget.dir, err)
+	}
+	separate := make(map[string]schema.GeneratedPackage)
+	for generateType, target := range targets {
+		importPath, err := importPathForDir(target.dir)
+		if err != nil {
+			log.Fatalf("Failed to resolve the import path of %s: %v", target.dir, err)
+		}
+		separate[generateType] = schema.GeneratedPackage{Name: target.packageName, ImportPath: importPath}
+	}
+	return root, separate
+}
+
+// importPathForDir derives the import path of a directory from the module
+// path in the nearest go.mod above it
+func importPathForDir(dir string) (string, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	for moduleDir := absDir; ; moduleDir = filepath.Dir(moduleDir) {
+		data, err := os.ReadFile(filepath.Join(moduleDir, "go.mod"))
+		if err == nil {
+			modulePath := modulePathFromGoMod(data)
+			if modulePath == "" {
+				return "", fmt.Errorf("no module directive in %s", filepath.Join(moduleDir, "go.mod"))
+			}
+			rel, err := filepath.Rel(moduleDir, absDir)
+			if err != nil {
+				return "", err
+			}
+			if rel == "." {
+				return modulePath, nil
+			}
+			return modulePath + "/" + filepath.ToSlash(rel), nil
+		}
+		if !errors.Is(err, os.ErrNotExist) {
+			return "", err
+		}
+		if filepath.Dir(moduleDir) == moduleDir {
+			return "", fmt.Errorf("no go.mod found above %s; separate output directories must be inside a Go module", absDir)
+		}
+	}
+}
+
+// modulePathFromGoMod returns the module path of a go.mod file, or ""
+func modulePathFromGoMod(data []byte) string {
+	for _, line := range strings.Split(string(data), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 2 && fields[0] == "module" {
+			return strings.Trim(fields[1], `"`)
+		}
+	}
+	return ""
+}
+
+// goGenerateCommand builds the mariakit command of a //go:generate directive
+// reading the schema with sourceFlag, either -conn or -sql-file. go generate
+// runs it in the package directory, so it writes to the current directory.
+// Arguments are quoted when go generate would split them.
+func goGenerateCommand(sourceFlag, packageName string) string {
+	args := []string{"mariakit", sourceFlag, "-output=.", "-package=" + packageName}
+	for i, arg := range args {
+		if strings.ContainsAny(arg, " \t\r\n\"") {
+			args[i] = strconv.Quote(arg)
+		}
+	}
+	return strings.Join(args, " ")
+}
+
+// splitList splits a comma-separated flag value, dropping empty entries
+func splitList(value string) []string {
+	var result []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// readTablesFile reads newline-separated table names, ignoring blank lines
+// and lines starting with #
+func readTablesFile(path string) ([]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var tables []string
+	for _, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		tables = append(tables, line)
+	}
+
+	if len(tables) == 0 {
+		return nil, fmt.Errorf("no tables listed in %s", path)
 	}
 
-	fmt.Println("🎉 Schema code generation completed successfully!")
+	return tables, nil
+}
+
+// packageNameFromDir derives a valid Go package name from an output directory.
+// Major version directories such as v2 resolve to their parent directory, the
+// name is lowercased and stripped of non-identifier characters, and names that
+// start with a digit or collide with a Go keyword are prefixed with "pkg".
+func packageNameFromDir(dir string) string {
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+
+	base := filepath.Base(dir)
+	if isMajorVersion(base) {
+		if parent := filepath.Base(filepath.Dir(dir)); parent != string(filepath.Separator) && parent != "." {
+			base = parent
+		}
+	}
+
+	var builder strings.Builder
+	for _, r := range strings.ToLower(base) {
+		if r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) {
+			builder.WriteRune(r)
+		}
+	}
+
+	name := builder.String()
+	if name == "" {
+		return "generated"
+	}
+	if unicode.IsDigit([]rune(name)[0]) || token.IsKeyword(name) {
+		name = "pkg" + name
+	}
+	return name
+}
+
+// isMajorVersion reports whether a directory name is a module major version suffix like v2
+func isMajorVersion(name string) bool {
+	if len(name) < 2 || name[0] != 'v' {
+		return false
+	}
+	for _, r := range name[1:] {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
 }
 
-// formatGeneratedFiles formats all .go files in the specified directory using go/format
-func formatGeneratedFiles(outputDir string) error {
+// writePlan prints a generation plan with the output path of each file
+func writePlan(w io.Writer, plan *schema.GenerationPlan, outputPath func(filename string) string) {
+	fmt.Fprintf(w, "📋 %d table(s), %d enum(s)\n", len(plan.Tables), plan.Enums)
+	for _, table := range plan.Tables {
+		fmt.Fprintf(w, "   %s: %d column(s)\n", table.Name, table.Columns)
+	}
+	fmt.Fprintln(w, "📄 Files:")
+	for _, filename := range plan.Files {
+		fmt.Fprintf(w, "   %s\n", outputPath(filename))
+	}
+}
+
+// writeTypeMap prints one "table.column | mysql_type | go_type" line per column
+func writeTypeMap(w io.Writer, mappings []schema.ColumnTypeMapping) {
+	for _, mapping := range mappings {
+		fmt.Fprintf(w, "%s.%s | %s | %s\n", mapping.Table, mapping.Column, mapping.MySQLType, mapping.GoType)
+	}
+}
+
+// generatedBanner matches the first line of generated files, following the
+// convention from https://go.dev/s/generatedcode
+var generatedBanner = regexp.MustCompile(`^// Code generated .* DO NOT EDIT\.$`)
+
+// generatedJSONPrefix starts the schema descriptor written by -type=schemajson,
+// which cannot carry a banner comment
+const generatedJSONPrefix = `{"tables":`
+
+// clobberedFiles returns the paths of existing files whose first line is not
+// a generated-code banner, or JSON files that are not a schema descriptor,
+// which -overwrite=false refuses to replace
+func clobberedFiles(paths []string) ([]string, error) {
+	var clobbered []string
+	for _, path := range paths {
+		file, err := os.Open(path)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		line, err := bufio.NewReader(file).ReadString('\n')
+		file.Close()
+		if err != nil && err != io.EOF {
+			return nil, fmt.Errorf("failed to read %s: %w", path, err)
+		}
+
+		if filepath.Ext(path) == ".json" {
+			if !strings.HasPrefix(line, generatedJSONPrefix) {
+				clobbered = append(clobbered, path)
+			}
+			continue
+		}
+		if !generatedBanner.MatchString(strings.TrimRight(line, "\r\n")) {
+			clobbered = append(clobbered, path)
+		}
+	}
+	return clobbered, nil
+}
+
+// writeIfAbsent writes content to path unless the file already exists, and
+// reports whether it was created
+func writeIfAbsent(path, content string) (bool, error) {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if errors.Is(err, os.ErrExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	if _, err := file.WriteString(content); err != nil {
+		file.Close()
+		return false, err
+	}
+	return true, file.Close()
+}
+
+// formatGeneratedFiles formats the generated .go files in the specified
+// directory using go/format. Files without the generated-code banner, such as
+// hand-written extension files, and the kept files of unchanged tables are
+// left alone.
+func formatGeneratedFiles(outputDir string, keep map[string]bool, logger *levelLogger) error {
 	// Find all .go files in the output directory
 	goFiles, err := filepath.Glob(filepath.Join(outputDir, "*.go"))
 	if err != nil {
 		return fmt.Errorf("failed to find Go files: %w", err)
 	}
 
-	if len(goFiles) == 0 {
-		return nil // No Go files to format
+	handWritten, err := clobberedFiles(goFiles)
+	if err != nil {
+		return err
+	}
+	skip := make(map[string]bool)
+	for file := range keep {
+		skip[file] = true
+	}
+	for _, file := range handWritten {
+		skip[file] = true
 	}
 
-	// Format each file using go/format
+	// Format each generated file using go/format
+	formatted := 0
 	for _, file := range goFiles {
+		if skip[file] {
+			continue
+		}
 		if err := formatFile(file); err != nil {
 			return fmt.Errorf("failed to format %s: %w", file, err)
 		}
+		formatted++
 	}
 
-	fmt.Printf("✅ Formatted %d Go files\n", len(goFiles))
+	if formatted > 0 {
+		logger.Infof("✅ Formatted %d Go files", formatted)
+	}
 	return nil
 }
 
@@ -185,7 +971,13 @@ func showHelp() {
 	fmt.Println("This tool generates Go code from MariaDB database schema including:")
 	fmt.Println("  - Column name constants for all tables")
 	fmt.Println("  - Go structs for all tables with proper types")
+	fmt.Println("  - Column type aliases for all tables")
+	fmt.Println("  - Typed column name constants for all tables")
+	fmt.Println("  - SELECT, INSERT and UPDATE statements for all tables")
 	fmt.Println("  - Enum value constants for all enum columns")
+	fmt.Println("  - A schema checksum for drift detection")
+	fmt.Println("  - A Schema() function describing the generated tables")
+	fmt.Println("  - Repository interfaces per table (-type=repositories)")
 	fmt.Println()
 	fmt.Println("Usage:")
 	fmt.Printf("  %s [flags]\n", os.Args[0])
@@ -197,6 +989,15 @@ func showHelp() {
 	fmt.Println("  # Generate all code types")
 	fmt.Printf("  %s -conn='user:password@tcp(localhost:3306)/database' -output='./generated'\n", os.Args[0])
 	fmt.Println()
+	fmt.Println("  # Generate into a versioned directory with an explicit package name")
+	fmt.Printf("  %s -conn='user:password@tcp(localhost:3306)/database' -output='./internal/db/v2' -package=db\n", os.Args[0])
+	fmt.Println()
+	fmt.Println("  # Generate all code into a single models.go")
+	fmt.Printf("  %s -conn='user:password@tcp(localhost:3306)/database' -single-file\n", os.Args[0])
+	fmt.Println()
+	fmt.Println("  # Generate from a schema dump instead of a database")
+	fmt.Printf("  %s -sql-file=schema.sql -output='./generated'\n", os.Args[0])
+	fmt.Println()
 	fmt.Println("  # Generate only column constants")
 	fmt.Printf("  %s -conn='user:password@tcp(localhost:3306)/database' -type=constants\n", os.Args[0])
 	fmt.Println()
@@ -0,0 +1,234 @@
+package main
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/louis77/mariakit/schema"
+)
+
+func TestPackageNameFromDir(t *testing.T) {
+	tests := []struct {
+		dir      string
+		expected string
+	}{
+		{"./generated", "generated"},
+		{"./internal/models", "models"},
+		{"./internal/db/v2", "db"},
+		{"./My-Models", "mymodels"},
+		{"./db.models", "dbmodels"},
+		{"./2024_models", "pkg2024_models"},
+		{"./type", "pkgtype"},
+		{"./---", "generated"},
+		{"/v2", "v2"},
+	}
+
+	for _, test := range tests {
+		result := packageNameFromDir(test.dir)
+		if result != test.expected {
+			t.Errorf("packageNameFromDir(%q) = %q, expected %q", test.dir, result, test.expected)
+		}
+	}
+}
+
+func TestReadTablesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tables.txt")
+	content := "# curated tables\nusers\n\n  orders  \n# legacy\r\ninvoices\r\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write tables file: %v", err)
+	}
+
+	tables, err := readTablesFile(path)
+	if err != nil {
+		t.Fatalf("readTablesFile() error: %v", err)
+	}
+
+	if got := strings.Join(tables, ","); got != "users,orders,invoices" {
+		t.Errorf("readTablesFile() = %q, expected %q", got, "users,orders,invoices")
+	}
+
+	empty := filepath.Join(t.TempDir(), "empty.txt")
+	if err := os.WriteFile(empty, []byte("# nothing here\n\n"), 0644); err != nil {
+		t.Fatalf("failed to write tables file: %v", err)
+	}
+	if _, err := readTablesFile(empty); err == nil {
+		t.Error("readTablesFile() with no tables expected error, got nil")
+	}
+
+	if _, err := readTablesFile(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Error("readTablesFile() with missing file expected error, got nil")
+	}
+}
+
+func TestOutputTargets(t *testing.T) {
+	defaultTarget := outputTarget{dir: "./generated", packageName: "db"}
+
+	targets := outputTargets(defaultTarget, map[string]string{
+		"structs":   "./internal/models",
+		"constants": "./internal/cols/v2",
+		"enums":     "",
+	})
+
+	expected := map[string]outputTarget{
+		"structs":   {dir: "./internal/models", packageName: "models"},
+		"constants": {dir: "./internal/cols/v2", packageName: "cols"},
+	}
+	if len(targets) != len(expected) {
+		t.Fatalf("outputTargets() = %v, expected %v", targets, expected)
+	}
+	for generateType, target := range expected {
+		if targets[generateType] != target {
+			t.Errorf("outputTargets()[%s] = %+v, expected %+v", generateType, targets[generateType], target)
+		}
+	}
+
+	if target := targetFor(targets, defaultTarget, "enums"); target != defaultTarget {
+		t.Errorf("targetFor(enums) = %+v, expected the default target %+v", target, defaultTarget)
+	}
+
+	// The default directory keeps the explicit package name
+	same := outputTargets(defaultTarget, map[string]string{"structs": "generated/"})
+	if target := targetFor(same, defaultTarget, "structs"); target != defaultTarget {
+		t.Errorf("targetFor(structs) in the default directory = %+v, expected %+v", target, defaultTarget)
+	}
+}
+
+func TestImportPathForDir(t *testing.T) {
+	moduleDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(moduleDir, "go.mod"), []byte("// app\nmodule example.com/app\n\ngo 1.24\n"), 0644); err != nil {
+		t.Fatalf("failed to write go.mod: %v", err)
+	}
+
+	for dir, expected := range map[string]string{
+		moduleDir: "example.com/app",
+		filepath.Join(moduleDir, "internal", "models"): "example.com/app/internal/models",
+	} {
+		if importPath, err := importPathForDir(dir); err != nil || importPath != expected {
+			t.Errorf("importPathForDir(%s) = %q, %v, expected %q", dir, importPath, err, expected)
+		}
+	}
+
+	if _, err := importPathForDir(filepath.Join(t.TempDir(), "models")); err == nil || !strings.Contains(err.Error(), "go.mod") {
+		t.Errorf("importPathForDir() outside a module error = %v, expected a missing go.mod error", err)
+	}
+}
+
+func TestGoGenerateCommand(t *testing.T) {
+	tests := []struct {
+		source   string
+		expected string
+	}{
+		{"-conn=$DATABASE_URL", "mariakit -conn=$DATABASE_URL -output=. -package=models"},
+		{
+			"-conn=" + schema.RedactDSN("root:secret@tcp(localhost:3306)/app"),
+			"mariakit -conn=root:xxxxx@tcp(localhost:3306)/app -output=. -package=models",
+		},
+		{"-conn=user:pw@unix(/tmp/my sock)/app", `mariakit "-conn=user:pw@unix(/tmp/my sock)/app" -output=. -package=models`},
+		{"-sql-file=../../schema.sql", "mariakit -sql-file=../../schema.sql -output=. -package=models"},
+	}
+
+	for _, test := range tests {
+		if result := goGenerateCommand(test.source, "models"); result != test.expected {
+			t.Errorf("goGenerateCommand(%q) = %q, expected %q", test.source, result, test.expected)
+		}
+	}
+}
+
+func TestClobberedFiles(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"structs.go":     "// Code generated by mariakit; DO NOT EDIT.\n// Generated on: 2024-01-01T00:00:00Z\n\npackage models\n",
+		"custom_tool.go": "// Code generated by acme-gen; DO NOT EDIT.\r\npackage models\n",
+		"helpers.go":     "package models\n\n// Code generated by mariakit; DO NOT EDIT.\n",
+		"queries.go":     "// Hand-written queries\npackage models\n",
+		"empty.go":       "",
+		"schema.json":    `{"tables":[]}`,
+		"config.json":    "{\n  \"tables\": []\n}\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	paths := []string{
+		filepath.Join(dir, "structs.go"),
+		filepath.Join(dir, "custom_tool.go"),
+		filepath.Join(dir, "helpers.go"),
+		filepath.Join(dir, "queries.go"),
+		filepath.Join(dir, "empty.go"),
+		filepath.Join(dir, "missing.go"),
+		filepath.Join(dir, "schema.json"),
+		filepath.Join(dir, "config.json"),
+	}
+	clobbered, err := clobberedFiles(paths)
+	if err != nil {
+		t.Fatalf("clobberedFiles() error: %v", err)
+	}
+
+	expected := []string{filepath.Join(dir, "helpers.go"), filepath.Join(dir, "queries.go"), filepath.Join(dir, "empty.go"), filepath.Join(dir, "config.json")}
+	if strings.Join(clobbered, ",") != strings.Join(expected, ",") {
+		t.Errorf("clobberedFiles() = %v, expected %v", clobbered, expected)
+	}
+}
+
+func TestWriteIfAbsent(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "users_ext.go")
+
+	created, err := writeIfAbsent(path, "package models\n")
+	if err != nil || !created {
+		t.Fatalf("writeIfAbsent() on a missing file = %t, %v, expected it to be created", created, err)
+	}
+
+	handWritten := "package models\n\nfunc (u Users) Greeting() string { return \"hi \" + u.Name }\n"
+	if err := os.WriteFile(path, []byte(handWritten), 0644); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+
+	created, err = writeIfAbsent(path, "package models\n")
+	if err != nil || created {
+		t.Errorf("writeIfAbsent() on an existing file = %t, %v, expected it to be left alone", created, err)
+	}
+
+	// Formatting the output directory skips files without the generated-code banner
+	logger, _ := newLogger(io.Discard, io.Discard, false, false)
+	if err := formatGeneratedFiles(filepath.Dir(path), nil, logger); err != nil {
+		t.Fatalf("formatGeneratedFiles() error: %v", err)
+	}
+
+	if content, _ := os.ReadFile(path); string(content) != handWritten {
+		t.Errorf("extension file = %q, expected it unchanged", content)
+	}
+}
+
+func TestWriteTypeMap(t *testing.T) {
+	dump := filepath.Join(t.TempDir(), "schema.sql")
+	ddl := "CREATE TABLE `users` (\n  `id` int(11) NOT NULL,\n  `settings` longtext DEFAULT NULL CHECK (json_valid(`settings`)),\n  `bio` text DEFAULT NULL,\n  PRIMARY KEY (`id`)\n);\n"
+	if err := os.WriteFile(dump, []byte(ddl), 0644); err != nil {
+		t.Fatalf("failed to write %s: %v", dump, err)
+	}
+
+	source, err := schema.LoadSQLDump(dump)
+	if err != nil {
+		t.Fatalf("LoadSQLDump() error: %v", err)
+	}
+	config := &schema.Config{JSONMappings: map[string]schema.JSONMapping{"users.settings": {Type: "types.JSON[Settings]"}}}
+	generator := schema.NewSchemaGeneratorFromSource(source, config)
+
+	mappings, err := generator.TypeMap(context.Background())
+	if err != nil {
+		t.Fatalf("TypeMap() error: %v", err)
+	}
+
+	var output strings.Builder
+	writeTypeMap(&output, mappings)
+
+	expected := "users.id | int(11) | int32\nusers.settings | longtext | types.JSON[Settings]\nusers.bio | text | sql.NullString\n"
+	if output.String() != expected {
+		t.Errorf("writeTypeMap() =\n%s\nexpected\n%s", output.String(), expected)
+	}
+}
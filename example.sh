@@ -40,4 +40,7 @@ echo "Generated files:"
 echo "  - ${OUTPUT_DIR}/column_constants.go"
 echo "  - ${OUTPUT_DIR}/structs.go"
 echo "  - ${OUTPUT_DIR}/column_types.go"
-echo "  - ${OUTPUT_DIR}/enum_constants.go"
\ No newline at end of file
+echo "  - ${OUTPUT_DIR}/column_names.go"
+echo "  - ${OUTPUT_DIR}/queries.go"
+echo "  - ${OUTPUT_DIR}/enum_constants.go"
+echo "  - ${OUTPUT_DIR}/metadata.go"
\ No newline at end of file
@@ -0,0 +1,130 @@
+package schema
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// schemaCache holds inspection results while several generators run, so
+// GenerateAll inspects the table list and every table only once
+type schemaCache struct {
+	tables     []string
+	tableInfos map[string]*TableInfo
+	tableErrs  map[string]error
+}
+
+// enableCache turns on the schema cache and returns a function turning it off
+// again. Nested calls share the outer cache.
+func (sg *SchemaGenerator) enableCache() func() {
+	if sg.cache != nil {
+		return func() {}
+	}
+	sg.cache = &schemaCache{
+		tableInfos: make(map[string]*TableInfo),
+		tableErrs:  make(map[string]error),
+	}
+	return func() { sg.cache = nil }
+}
+
+// CacheInspection keeps the inspected tables and enums across generator
+// calls until the returned function is called, so a run computing
+// TableSignatures before GenerateAll inspects the schema only once
+func (sg *SchemaGenerator) CacheInspection() func() {
+	return sg.enableCache()
+}
+
+// cachedTables returns the table names, from the cache when it is enabled
+func (sg *SchemaGenerator) cachedTables(ctx context.Context) ([]string, error) {
+	if sg.cache == nil {
+		return sg.GetTables(ctx)
+	}
+	if sg.cache.tables == nil {
+		tables, err := sg.GetTables(ctx)
+		if err != nil {
+			return nil, err
+		}
+		sg.cache.tables = tables
+	}
+	return sg.cache.tables, nil
+}
+
+// cachedTableInfo returns a table's info, from the cache when it is enabled.
+// Failed inspections are cached as well.
+func (sg *SchemaGenerator) cachedTableInfo(ctx context.Context, tableName string) (*TableInfo, error) {
+	if sg.cache == nil {
+		return sg.inspectTable(ctx, tableName)
+	}
+	if err, exists := sg.cache.tableErrs[tableName]; exists {
+		return nil, err
+	}
+	if tableInfo, exists := sg.cache.tableInfos[tableName]; exists {
+		return tableInfo, nil
+	}
+
+	tableInfo, err := sg.inspectTable(ctx, tableName)
+	if err != nil {
+		sg.cache.tableErrs[tableName] = err
+		return nil, err
+	}
+	sg.cache.tableInfos[tableName] = tableInfo
+	return tableInfo, nil
+}
+
+// InspectHook is called after a table was inspected with the time the
+// inspection took and its error, if any
+type InspectHook func(tableName string, duration time.Duration, err error)
+
+// SetInspectHook sets a hook called for every table inspection, for example
+// to log slow tables. Cached tables are not inspected again. A nil hook
+// removes it.
+func (sg *SchemaGenerator) SetInspectHook(hook InspectHook) {
+	sg.inspectHook = hook
+}
+
+// inspectTable retrieves a table's info and reports it to the inspect hook
+func (sg *SchemaGenerator) inspectTable(ctx context.Context, tableName string) (*TableInfo, error) {
+	start := time.Now()
+	tableInfo, err := sg.GetTableInfo(ctx, tableName)
+	if sg.inspectHook != nil {
+		sg.inspectHook(tableName, time.Since(start), err)
+	}
+	return tableInfo, err
+}
+
+// enums returns the enum columns to generate, followed by the lookup-table
+// enums. With the cache enabled the enum columns are derived from the cached
+// tables instead of being queried again.
+func (sg *SchemaGenerator) enums(ctx context.Context) ([]EnumInfo, error) {
+	var enums []EnumInfo
+	if sg.cache == nil {
+		columnEnums, err := sg.GetAllEnums(ctx)
+		if err != nil {
+			return nil, err
+		}
+		enums = columnEnums
+	} else {
+		tableInfos, err := sg.loadTables(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, tableInfo := range tableInfos {
+			for _, col := range tableInfo.Columns {
+				if col.IsEnum {
+					enums = append(enums, EnumInfo{TableName: tableInfo.Name, ColumnName: col.Name, Values: col.EnumValues})
+				}
+			}
+		}
+	}
+
+	// Same order as GetAllEnums
+	sort.SliceStable(enums, func(i, j int) bool {
+		if enums[i].TableName != enums[j].TableName {
+			return enums[i].TableName < enums[j].TableName
+		}
+		return enums[i].ColumnName < enums[j].ColumnName
+	})
+
+	return enums, nil
+}
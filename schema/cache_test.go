@@ -0,0 +1,99 @@
+package schema
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// countingSource counts the inspection calls made against a memorySource
+type countingSource struct {
+	*memorySource
+	tablesCalls    int
+	tableInfoCalls map[string]int
+	enumsCalls     int
+}
+
+func (c *countingSource) GetTables(ctx context.Context) ([]string, error) {
+	c.tablesCalls++
+	return c.memorySource.GetTables(ctx)
+}
+
+func (c *countingSource) GetTableInfo(ctx context.Context, tableName string) (*TableInfo, error) {
+	c.tableInfoCalls[tableName]++
+	return c.memorySource.GetTableInfo(ctx, tableName)
+}
+
+func (c *countingSource) GetAllEnums(ctx context.Context) ([]EnumInfo, error) {
+	c.enumsCalls++
+	return c.memorySource.GetAllEnums(ctx)
+}
+
+func TestGenerateAll_InspectsOnce(t *testing.T) {
+	source := &countingSource{
+		memorySource:   newMemorySource(enumsTestTable(), &TableInfo{Name: "orders", Columns: []ColumnInfo{{Name: "id", Type: "int(11)"}}}),
+		tableInfoCalls: make(map[string]int),
+	}
+	sg := NewSchemaGeneratorFromSource(source, nil)
+
+	files, err := sg.GenerateAll(context.Background(), "models")
+	if err != nil {
+		t.Fatalf("GenerateAll() error: %v", err)
+	}
+	if !strings.Contains(files["enum_constants.go"], `Users_Status_Active = "active"`) {
+		t.Errorf("GenerateAll() enum_constants.go lacks the cached enum:\n%s", files["enum_constants.go"])
+	}
+
+	if source.tablesCalls != 1 {
+		t.Errorf("GetTables called %d times, expected 1", source.tablesCalls)
+	}
+	for _, table := range []string{"orders", "users"} {
+		if calls := source.tableInfoCalls[table]; calls != 1 {
+			t.Errorf("GetTableInfo(%s) called %d times, expected 1", table, calls)
+		}
+	}
+	if source.enumsCalls != 0 {
+		t.Errorf("GetAllEnums called %d times, expected enums to come from the cached tables", source.enumsCalls)
+	}
+
+	// The cache only lives for a single GenerateAll call
+	if sg.cache != nil {
+		t.Error("GenerateAll() left the schema cache enabled")
+	}
+	if _, err := sg.GenerateStructs(context.Background(), "models"); err != nil {
+		t.Fatalf("GenerateStructs() error: %v", err)
+	}
+	if calls := source.tableInfoCalls["users"]; calls != 2 {
+		t.Errorf("GetTableInfo(users) called %d times after a standalone generator, expected 2", calls)
+	}
+}
+
+func TestSetInspectHook(t *testing.T) {
+	source := newMemorySource(enumsTestTable(), &TableInfo{Name: "orders", Columns: []ColumnInfo{{Name: "id", Type: "int(11)"}}})
+	source.errors["broken"] = errors.New("access denied")
+	sg := NewSchemaGeneratorFromSource(source, &Config{ContinueOnError: true})
+
+	inspected := make(map[string]int)
+	var failed []string
+	sg.SetInspectHook(func(tableName string, duration time.Duration, err error) {
+		inspected[tableName]++
+		if err != nil {
+			failed = append(failed, tableName)
+		}
+	})
+
+	if _, err := sg.GenerateAll(context.Background(), "models"); err != nil {
+		t.Fatalf("GenerateAll() error: %v", err)
+	}
+
+	for _, table := range []string{"users", "orders", "broken"} {
+		if inspected[table] != 1 {
+			t.Errorf("inspect hook called %d times for %s, expected 1", inspected[table], table)
+		}
+	}
+	if len(failed) != 1 || failed[0] != "broken" {
+		t.Errorf("inspect hook reported failures %v, expected [broken]", failed)
+	}
+}
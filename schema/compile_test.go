@@ -0,0 +1,62 @@
+package schema
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// runGenerated compiles the generated files, which must use package main,
+// together with mainSrc and returns the combined output of running them.
+// Generated code may import github.com/louis77/mariakit/types, which
+// resolves to this checkout.
+func runGenerated(t *testing.T, files map[string]string, mainSrc string) string {
+	t.Helper()
+
+	if testing.Short() {
+		t.Skip("skipping compilation of generated code in short mode")
+	}
+	goBin, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go toolchain not available")
+	}
+
+	root, err := filepath.Abs("..")
+	if err != nil {
+		t.Fatalf("failed to resolve module root: %v", err)
+	}
+
+	dir := t.TempDir()
+	goMod := "module generatedtest\n\ngo 1.24\n\n" +
+		"require github.com/louis77/mariakit v0.0.0\n\n" +
+		"replace github.com/louis77/mariakit => " + root + "\n"
+
+	goSum, err := os.ReadFile(filepath.Join(root, "go.sum"))
+	if err != nil {
+		t.Fatalf("failed to read go.sum: %v", err)
+	}
+
+	sources := map[string]string{"go.mod": goMod, "go.sum": string(goSum), "main.go": mainSrc}
+	for name, content := range files {
+		sources[name] = content
+	}
+	// Names may include a directory for code split into several packages
+	for name, content := range sources {
+		if err := os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0755); err != nil {
+			t.Fatalf("failed to create directory of %s: %v", name, err)
+		}
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+
+	cmd := exec.Command(goBin, "run", "-mod=mod", ".")
+	cmd.Dir = dir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("running generated code failed: %v\n%s", err, output)
+	}
+
+	return string(output)
+}
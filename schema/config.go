@@ -2,7 +2,14 @@ package schema
 
 import (
 	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
 	"os"
+	"path"
+	"sort"
+	"strings"
 
 	"gopkg.in/yaml.v3"
 )
@@ -16,8 +23,231 @@ type JSONMapping struct {
 // Config represents the configuration file structure
 type Config struct {
 	JSONMappings map[string]JSONMapping `yaml:"json_mappings"`
+
+	// Schema overrides the database name of the connection string
+	Schema string `yaml:"schema"`
+
+	// ReadOnly makes every connection of the pool a read-only session, so
+	// generation cannot modify the database even by accident. It has no
+	// effect on pools passed to NewSchemaGeneratorFromDB.
+	ReadOnly bool `yaml:"read_only"`
+
+	// Include restricts generation to tables matching these glob patterns.
+	// Entries without glob characters must name an existing table.
+	Include []string `yaml:"include"`
+
+	// Exclude skips tables matching these glob patterns
+	Exclude []string `yaml:"exclude"`
+
+	// TableTypes lists the information_schema.TABLES TABLE_TYPE values to
+	// generate code for, such as VIEW or SEQUENCE. Defaults to BASE TABLE,
+	// which also selects system-versioned tables.
+	TableTypes []string `yaml:"table_types"`
+
+	// SkipInvisible excludes INVISIBLE and system-versioning period columns
+	// from generated code. Defaults to true when unset.
+	SkipInvisible *bool `yaml:"skip_invisible"`
+
+	// ExportStructs controls whether generated struct types and their
+	// constructors are exported. Defaults to true when unset. Fields always
+	// stay exported because database/sql cannot scan into unexported fields.
+	ExportStructs *bool `yaml:"export_structs"`
+
+	// ExportConstants controls whether generated column, enum, SQL and typed
+	// column name constants and their types are exported. Defaults to true
+	// when unset.
+	ExportConstants *bool `yaml:"export_constants"`
+
+	// ContinueOnError skips tables that fail inspection instead of aborting generation
+	ContinueOnError bool `yaml:"continue_on_error"`
+
+	// ColumnPrefixStrip maps table names to a column name prefix that is
+	// removed before naming struct fields. The "*" entry applies to all
+	// tables without their own entry. db tags keep the full column name.
+	ColumnPrefixStrip map[string]string `yaml:"column_prefix_strip"`
+
+	// FieldRenames maps "table.column" keys to hand-picked struct field
+	// names, overriding the derived name and column_prefix_strip. db tags
+	// keep the column name.
+	FieldRenames map[string]string `yaml:"field_renames"`
+
+	// NullableOverrides maps "table.column" keys to the nullability to use
+	// instead of the one the schema reports, for generated columns and views
+	// with wrong metadata or columns a trigger keeps non-null
+	NullableOverrides map[string]bool `yaml:"nullable_overrides"`
+
+	// SingleFile makes GenerateAll merge all generated code into a single
+	// models.go with one package clause and import block
+	SingleFile bool `yaml:"single_file"`
+
+	// FilePerTable writes the struct of each table, with its functions and
+	// methods, to <table>.go instead of structs.go. structs.go then only
+	// holds the declarations the tables share, if any.
+	FilePerTable bool `yaml:"file_per_table"`
+
+	// GroupColumns splits each table's column constants into primary key,
+	// generated and regular column groups
+	GroupColumns bool `yaml:"group_columns"`
+
+	// ExactDecimals maps DECIMAL and NUMERIC columns to types.Decimal instead of float64
+	ExactDecimals bool `yaml:"exact_decimals"`
+
+	// UnsignedIntegers maps UNSIGNED and ZEROFILL integer columns to uint32
+	// and uint64 instead of int32 and int64, and TINYINT(1) UNSIGNED to
+	// uint32 instead of bool
+	UnsignedIntegers bool `yaml:"unsigned_integers"`
+
+	// EnumMode controls enum generation: constants (default) emits untyped
+	// string constants, typed emits a string type per enum column and int
+	// emits an iota-backed int type that is stored as its string
+	EnumMode string `yaml:"enum_mode"`
+
+	// UUIDMode controls the Go type of native UUID columns: uuid (default)
+	// maps them to types.UUID, string to string and sql.NullString
+	UUIDMode string `yaml:"uuid_mode"`
+
+	// TimestampColumns maps DATETIME and TIMESTAMP columns whose names match
+	// any of these glob patterns, such as "*_at", to types.Timestamp, which
+	// normalizes values to UTC
+	TimestampColumns []string `yaml:"timestamp_columns"`
+
+	// TolerantDateTimes maps DATE, DATETIME and TIMESTAMP columns to
+	// types.DateTime, which also scans the text values the driver returns
+	// without parseTime=true. timestamp_columns takes precedence.
+	TolerantDateTimes bool `yaml:"tolerant_datetimes"`
+
+	// BitMode controls the Go type of BIT(n) columns with n > 1: uint
+	// (default) maps them to types.Bits, bytes to the raw []byte. BIT(1)
+	// columns always map to bool.
+	BitMode string `yaml:"bit_mode"`
+
+	// NullableMode controls the Go type of nullable columns: sql (default)
+	// maps them to sql.Null* types, pointer to a pointer to the type of the
+	// NOT NULL column, such as *bool for a nullable TINYINT(1). Types that
+	// hold NULL themselves, like []byte, JSON and typed enums, are kept.
+	NullableMode string `yaml:"nullable_mode"`
+
+	// PreciseJSON maps detected JSON columns without a json_mappings entry
+	// to types.PreciseJSON[any], which decodes numbers as json.Number
+	// instead of float64 so large integers keep their precision
+	PreciseJSON bool `yaml:"precise_json"`
+
+	// BlobColumns maps BLOB, TINYBLOB, MEDIUMBLOB and LONGBLOB columns to
+	// types.Blob, which exposes its content as an io.Reader. BINARY and
+	// VARBINARY columns stay []byte.
+	BlobColumns bool `yaml:"blob_columns"`
+
+	// PlaceholderStyle controls bind parameters in generated SQL: question (default), dollar or named
+	PlaceholderStyle string `yaml:"placeholder_style"`
+
+	// ORMPreset selects the struct tags for an ORM: sqlx (default) emits db
+	// tags, bun emits bun tags and gorm emits gorm tags, with primary key and
+	// auto-increment markers where the ORM supports them
+	ORMPreset string `yaml:"orm"`
+
+	// GenerateValidatorTags adds go-playground/validator tags to NOT NULL
+	// columns: required for required columns, oneof for enums and max for
+	// the declared length of char and varchar columns
+	GenerateValidatorTags bool `yaml:"generate_validator_tags"`
+
+	// GenerateSchemaInfo adds schema_info.go with its Schema function to
+	// GenerateAll. It is off by default because the Schema, TableDescriptor
+	// and ColumnDescriptor declarations would collide with the structs of
+	// tables of the same names.
+	GenerateSchemaInfo bool `yaml:"generate_schema_info"`
+
+	// GenerateStringers adds a String method to each generated struct that
+	// prints the row as Users{id=1, name=alice} for debugging and logging
+	GenerateStringers bool `yaml:"generate_stringers"`
+
+	// SensitiveColumns lists "table.column" glob patterns, such as
+	// "*.password_hash", of columns whose values String prints as ***
+	SensitiveColumns []string `yaml:"sensitive_columns"`
+
+	// EmbedMixin lists columns, such as created_at, updated_at and
+	// deleted_at, that are generated once as fields of a Timestamps struct.
+	// Structs of tables having all of them embed Timestamps instead of
+	// repeating the fields.
+	EmbedMixin []string `yaml:"embed_mixin"`
+
+	// StructTags lists the struct tag names to emit, replacing the preset's.
+	// db, bun and gorm tags use their ORM format, other tags hold the column name.
+	StructTags []string `yaml:"struct_tags"`
+
+	// ToolName is the generator named in the "// Code generated by <tool>;
+	// DO NOT EDIT." banner of generated files. Defaults to mariakit.
+	ToolName string `yaml:"tool_name"`
+
+	// LintDirective adds a file-level directive above the package clause of
+	// generated files so linters skip them: nolint emits //nolint:all for
+	// golangci-lint, staticcheck emits //lint:file-ignore for staticcheck
+	LintDirective string `yaml:"lint_directive"`
+
+	// GoGenerate is a command, such as "mariakit -conn=$DATABASE_URL -output=.",
+	// emitted as a //go:generate directive in metadata.go so the package can
+	// be regenerated with go generate
+	GoGenerate string `yaml:"go_generate"`
+
+	// LookupEnums maps "table.column" foreign key columns to the lookup table
+	// holding their values. A constant is generated per row of the lookup
+	// table, named after its label column and holding its key.
+	LookupEnums map[string]LookupEnum `yaml:"lookup_enums"`
 }
 
+// LookupEnum names the reference table of a lookup-table enum, the column
+// holding the labels and the key column the foreign key references, which
+// defaults to the table's single-column primary key
+type LookupEnum struct {
+	Table  string `yaml:"table"`
+	Column string `yaml:"column"`
+	Key    string `yaml:"key"`
+}
+
+// Enum generation modes
+const (
+	EnumModeConstants = "constants"
+	EnumModeTyped     = "typed"
+	EnumModeInt       = "int"
+)
+
+// UUID column mapping modes
+const (
+	UUIDModeUUID   = "uuid"
+	UUIDModeString = "string"
+)
+
+// BIT(n) column mapping modes
+const (
+	BitModeUint  = "uint"
+	BitModeBytes = "bytes"
+)
+
+// Nullable column mapping modes
+const (
+	NullableModeSQL     = "sql"
+	NullableModePointer = "pointer"
+)
+
+// Placeholder styles for generated SQL
+const (
+	PlaceholderQuestion = "question" // ?
+	PlaceholderDollar   = "dollar"   // $1, $2, ...
+	PlaceholderNamed    = "named"    // :column
+)
+
+// ORM presets for struct tags
+const (
+	ORMSqlx = "sqlx"
+	ORMBun  = "bun"
+	ORMGorm = "gorm"
+)
+
+// Lint directives for generated files
+const (
+	LintDirectiveNolint      = "nolint"      // //nolint:all
+	LintDirectiveStaticcheck = "staticcheck" // //lint:file-ignore
+)
+
 // LoadConfig loads configuration from a YAML file
 func LoadConfig(configPath string) (*Config, error) {
 	// Return empty config if file doesn't exist
@@ -40,9 +270,266 @@ func LoadConfig(configPath string) (*Config, error) {
 		config.JSONMappings = make(map[string]JSONMapping)
 	}
 
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid config %s: %w", configPath, err)
+	}
+
 	return &config, nil
 }
 
+// Validate checks that enumerated settings hold known values, that lookup
+// enums name their reference table and column, that every JSON mapping has a
+// parseable Go type and that mappings referring to non-builtin types declare
+// the import they need
+func (c *Config) Validate() error {
+	if c.SingleFile && c.FilePerTable {
+		return fmt.Errorf("single_file and file_per_table cannot be combined")
+	}
+
+	switch c.EnumMode {
+	case "", EnumModeConstants, EnumModeTyped, EnumModeInt:
+	default:
+		return fmt.Errorf("unknown enum mode %q, use %s, %s or %s", c.EnumMode, EnumModeConstants, EnumModeTyped, EnumModeInt)
+	}
+
+	switch c.UUIDMode {
+	case "", UUIDModeUUID, UUIDModeString:
+	default:
+		return fmt.Errorf("unknown UUID mode %q, use %s or %s", c.UUIDMode, UUIDModeUUID, UUIDModeString)
+	}
+
+	switch c.BitMode {
+	case "", BitModeUint, BitModeBytes:
+	default:
+		return fmt.Errorf("unknown bit mode %q, use %s or %s", c.BitMode, BitModeUint, BitModeBytes)
+	}
+
+	switch c.NullableMode {
+	case "", NullableModeSQL, NullableModePointer:
+	default:
+		return fmt.Errorf("unknown nullable mode %q, use %s or %s", c.NullableMode, NullableModeSQL, NullableModePointer)
+	}
+
+	switch c.PlaceholderStyle {
+	case "", PlaceholderQuestion, PlaceholderDollar, PlaceholderNamed:
+	default:
+		return fmt.Errorf("unknown placeholder style %q, use %s, %s or %s",
+			c.PlaceholderStyle, PlaceholderQuestion, PlaceholderDollar, PlaceholderNamed)
+	}
+
+	switch c.ORMPreset {
+	case "", ORMSqlx, ORMBun, ORMGorm:
+	default:
+		return fmt.Errorf("unknown ORM preset %q, use %s, %s or %s", c.ORMPreset, ORMSqlx, ORMBun, ORMGorm)
+	}
+
+	switch c.LintDirective {
+	case "", LintDirectiveNolint, LintDirectiveStaticcheck:
+	default:
+		return fmt.Errorf("unknown lint directive %q, use %s or %s", c.LintDirective, LintDirectiveNolint, LintDirectiveStaticcheck)
+	}
+
+	for _, pattern := range c.TimestampColumns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid timestamp column pattern %q: %w", pattern, err)
+		}
+	}
+
+	for _, pattern := range c.SensitiveColumns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid sensitive column pattern %q: %w", pattern, err)
+		}
+	}
+
+	if strings.ContainsAny(c.GoGenerate, "\r\n") {
+		return fmt.Errorf("go_generate command must be a single line")
+	}
+
+	if strings.ContainsAny(c.ToolName, "\r\n") {
+		return fmt.Errorf("tool name %q must be a single line", c.ToolName)
+	}
+
+	mixinColumns := make(map[string]bool)
+	for _, column := range c.EmbedMixin {
+		if strings.TrimSpace(column) == "" {
+			return fmt.Errorf("embed_mixin must not contain empty entries")
+		}
+		if mixinColumns[column] {
+			return fmt.Errorf("embed_mixin lists column %q twice", column)
+		}
+		mixinColumns[column] = true
+	}
+
+	for _, tableType := range c.TableTypes {
+		if strings.TrimSpace(tableType) == "" {
+			return fmt.Errorf("table_types must not contain empty entries")
+		}
+	}
+
+	for _, tag := range c.StructTags {
+		if !token.IsIdentifier(tag) {
+			return fmt.Errorf("invalid struct tag name %q", tag)
+		}
+	}
+
+	renameKeys := make([]string, 0, len(c.FieldRenames))
+	for key := range c.FieldRenames {
+		renameKeys = append(renameKeys, key)
+	}
+	sort.Strings(renameKeys)
+
+	for _, key := range renameKeys {
+		if table, column, ok := strings.Cut(key, "."); !ok || table == "" || column == "" {
+			return fmt.Errorf("field rename %s is not of the form table.column", key)
+		}
+		if name := c.FieldRenames[key]; !token.IsIdentifier(name) || !token.IsExported(name) {
+			return fmt.Errorf("field rename %s: %q is not an exported Go identifier", key, name)
+		}
+	}
+
+	overrideKeys := make([]string, 0, len(c.NullableOverrides))
+	for key := range c.NullableOverrides {
+		overrideKeys = append(overrideKeys, key)
+	}
+	sort.Strings(overrideKeys)
+
+	for _, key := range overrideKeys {
+		if table, column, ok := strings.Cut(key, "."); !ok || table == "" || column == "" {
+			return fmt.Errorf("nullable override %s is not of the form table.column", key)
+		}
+	}
+
+	lookupKeys := make([]string, 0, len(c.LookupEnums))
+	for key := range c.LookupEnums {
+		lookupKeys = append(lookupKeys, key)
+	}
+	sort.Strings(lookupKeys)
+
+	for _, key := range lookupKeys {
+		if table, column, ok := strings.Cut(key, "."); !ok || table == "" || column == "" {
+			return fmt.Errorf("lookup enum %s is not of the form table.column", key)
+		}
+		if lookup := c.LookupEnums[key]; lookup.Table == "" || lookup.Column == "" {
+			return fmt.Errorf("lookup enum %s needs a table and a column", key)
+		}
+	}
+
+	keys := make([]string, 0, len(c.JSONMappings))
+	for key := range c.JSONMappings {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		mapping := c.JSONMappings[key]
+		if mapping.Type == "" {
+			return fmt.Errorf("JSON mapping %s has no type", key)
+		}
+
+		expr, err := parser.ParseExpr(mapping.Type)
+		if err != nil {
+			return fmt.Errorf("JSON mapping %s has invalid type %q: %w", key, mapping.Type, err)
+		}
+
+		if mapping.Import == "" && needsImport(expr) {
+			return fmt.Errorf("JSON mapping %s uses non-builtin type %q but has no import", key, mapping.Type)
+		}
+	}
+
+	return nil
+}
+
+// needsImport reports whether a type expression references identifiers that
+// are neither Go builtins nor part of the mariakit types package, which is
+// always imported by the generated code
+func needsImport(expr ast.Expr) bool {
+	found := false
+	ast.Inspect(expr, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.SelectorExpr:
+			if pkg, ok := node.X.(*ast.Ident); !ok || pkg.Name != "types" {
+				found = true
+			}
+			return false
+		case *ast.Ident:
+			if _, ok := types.Universe.Lookup(node.Name).(*types.TypeName); !ok {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// skipInvisible reports whether invisible columns are excluded, which is the default
+func (c *Config) skipInvisible() bool {
+	if c == nil || c.SkipInvisible == nil {
+		return true
+	}
+	return *c.SkipInvisible
+}
+
+// tableTypes returns the TABLE_TYPE values GetTables selects. BASE TABLE
+// brings SYSTEM VERSIONED along, the type MariaDB reports for versioned tables.
+func (c *Config) tableTypes() []string {
+	configured := []string{"BASE TABLE"}
+	if c != nil && len(c.TableTypes) > 0 {
+		configured = c.TableTypes
+	}
+
+	var tableTypes []string
+	seen := make(map[string]bool)
+	for _, tableType := range configured {
+		tableType = strings.ToUpper(strings.TrimSpace(tableType))
+		expanded := []string{tableType}
+		if tableType == "BASE TABLE" {
+			expanded = append(expanded, "SYSTEM VERSIONED")
+		}
+		for _, t := range expanded {
+			if !seen[t] {
+				seen[t] = true
+				tableTypes = append(tableTypes, t)
+			}
+		}
+	}
+	return tableTypes
+}
+
+// toolName returns the generator named in the banner of generated files
+func (c *Config) toolName() string {
+	if c == nil || c.ToolName == "" {
+		return "mariakit"
+	}
+	return c.ToolName
+}
+
+func (c *Config) exportStructs() bool {
+	if c == nil || c.ExportStructs == nil {
+		return true
+	}
+	return *c.ExportStructs
+}
+
+// enumMode returns the configured enum generation mode
+func (c *Config) enumMode() string {
+	if c == nil || c.EnumMode == "" {
+		return EnumModeConstants
+	}
+	return c.EnumMode
+}
+
+func (c *Config) exportConstants() bool {
+	if c == nil || c.ExportConstants == nil {
+		return true
+	}
+	return *c.ExportConstants
+}
+
+// isSensitive reports whether table.column matches a sensitive_columns pattern
+func (c *Config) isSensitive(tableName, columnName string) bool {
+	return c != nil && matchTable(c.SensitiveColumns, tableName+"."+columnName)
+}
+
 // GetJSONMapping returns the custom JSON mapping for a table.column combination
 func (c *Config) GetJSONMapping(tableName, columnName string) (JSONMapping, bool) {
 	key := fmt.Sprintf("%s.%s", tableName, columnName)
@@ -0,0 +1,333 @@
+package schema
+
+import (
+	"context"
+	"database/sql/driver"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMysqlTypeToGoType_GenericJSONMapping(t *testing.T) {
+	sg := &SchemaGenerator{config: &Config{
+		JSONMappings: map[string]JSONMapping{
+			"users.settings": {Type: "types.JSON[Settings]", Import: "github.com/example/models"},
+		},
+	}}
+
+	result := sg.mysqlTypeToGoType("longtext", false, true, "users", "settings")
+	if result != "types.JSON[Settings]" {
+		t.Errorf("mysqlTypeToGoType for mapped JSON column = %q, expected %q", result, "types.JSON[Settings]")
+	}
+
+	result = sg.mysqlTypeToGoType("longtext", false, true, "users", "other")
+	if result != "types.JSON[any]" {
+		t.Errorf("mysqlTypeToGoType for unmapped JSON column = %q, expected %q", result, "types.JSON[any]")
+	}
+}
+
+func TestGenerateStructs_JSONMappingImports(t *testing.T) {
+	table := &TableInfo{
+		Name: "users",
+		Columns: []ColumnInfo{
+			{Name: "id", Type: "int(11)"},
+			{Name: "settings", Type: "longtext", IsJSON: true},
+		},
+	}
+	settings := "package settings\n\ntype Settings struct {\n\tTheme string `json:\"theme\"`\n}\n"
+
+	tests := []struct {
+		mapping JSONMapping
+		imports string
+	}{
+		// Builtin type arguments need no import besides the mariakit types package
+		{JSONMapping{Type: "types.JSON[map[string]any]"}, "import (\n" +
+			"\t\"database/sql\"\n" +
+			"\n" +
+			"\t\"github.com/louis77/mariakit/types\"\n" +
+			")\n"},
+		{JSONMapping{Type: "types.JSON[settings.Settings]", Import: "generatedtest/settings"}, "import (\n" +
+			"\t\"database/sql\"\n" +
+			"\t\"generatedtest/settings\"\n" +
+			"\n" +
+			"\t\"github.com/louis77/mariakit/types\"\n" +
+			")\n"},
+	}
+
+	for _, test := range tests {
+		config := &Config{JSONMappings: map[string]JSONMapping{"users.settings": test.mapping}}
+		if err := config.Validate(); err != nil {
+			t.Fatalf("Validate(%+v) error: %v", test.mapping, err)
+		}
+		sg := NewSchemaGeneratorFromSource(newMemorySource(table), config)
+
+		result, err := sg.GenerateStructs(context.Background(), "main")
+		if err != nil {
+			t.Fatalf("GenerateStructs() error: %v", err)
+		}
+		if !strings.Contains(result, "package main\n\n"+test.imports+"\n") {
+			t.Errorf("GenerateStructs() with %+v imports, expected exactly:\n%s\nin:\n%s", test.mapping, test.imports, result)
+		}
+
+		runGenerated(t, map[string]string{"structs.go": result, "settings/settings.go": settings}, `package main
+
+func main() {
+	var u Users
+	_ = u.SettingsValue()
+}
+`)
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	tests := []struct {
+		mapping JSONMapping
+		wantErr string
+	}{
+		{JSONMapping{Type: "types.JSON[Settings]", Import: "github.com/example/models"}, ""},
+		{JSONMapping{Type: "models.Settings", Import: "github.com/example/models"}, ""},
+		{JSONMapping{Type: "map[string]interface{}"}, ""},
+		{JSONMapping{Type: "types.JSON[map[string]any]"}, ""},
+		{JSONMapping{Type: "[]string"}, ""},
+		{JSONMapping{Type: "types.JSON[Settings]"}, "has no import"},
+		{JSONMapping{Type: "models.Settings"}, "has no import"},
+		{JSONMapping{Type: "types.JSON[", Import: "github.com/example/models"}, "invalid type"},
+		{JSONMapping{}, "has no type"},
+	}
+
+	for _, test := range tests {
+		config := &Config{JSONMappings: map[string]JSONMapping{"users.settings": test.mapping}}
+		err := config.Validate()
+		if test.wantErr == "" {
+			if err != nil {
+				t.Errorf("Validate(%+v) unexpected error: %v", test.mapping, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+			t.Errorf("Validate(%+v) error = %v, expected it to contain %q", test.mapping, err, test.wantErr)
+		}
+	}
+}
+
+func TestMysqlTypeToGoType_ExactDecimals(t *testing.T) {
+	tests := []struct {
+		exact    bool
+		nullable bool
+		expected string
+	}{
+		{false, false, "float64"},
+		{false, true, "sql.NullFloat64"},
+		{true, false, "types.Decimal"},
+		{true, true, "types.Decimal"},
+	}
+
+	for _, test := range tests {
+		sg := &SchemaGenerator{config: &Config{ExactDecimals: test.exact}}
+		result := sg.mysqlTypeToGoType("decimal(10,2)", test.nullable, false, "test_table", "test_column")
+		if result != test.expected {
+			t.Errorf("mysqlTypeToGoType(decimal, exact=%t, nullable=%t) = %q, expected %q",
+				test.exact, test.nullable, result, test.expected)
+		}
+	}
+}
+
+func TestConfigValidate_ORMPreset(t *testing.T) {
+	if err := (&Config{ORMPreset: ORMBun}).Validate(); err != nil {
+		t.Errorf("Validate() with bun preset unexpected error: %v", err)
+	}
+	if err := (&Config{ORMPreset: "hibernate"}).Validate(); err == nil {
+		t.Error("Validate() with unknown preset expected error, got nil")
+	}
+	if err := (&Config{StructTags: []string{"db", "json:x"}}).Validate(); err == nil {
+		t.Error("Validate() with invalid tag name expected error, got nil")
+	}
+}
+
+func TestConfigValidate_UUIDMode(t *testing.T) {
+	if err := (&Config{UUIDMode: "binary"}).Validate(); err == nil {
+		t.Error("Validate() with unknown UUID mode expected error, got nil")
+	}
+}
+
+func TestMysqlTypeToGoType_TimestampColumns(t *testing.T) {
+	sg := &SchemaGenerator{config: &Config{TimestampColumns: []string{"*_at"}}}
+
+	tests := []struct {
+		mysqlType  string
+		columnName string
+		nullable   bool
+		expected   string
+	}{
+		{"datetime", "created_at", false, "types.Timestamp"},
+		{"timestamp", "deleted_at", true, "types.Timestamp"},
+		{"datetime(6)", "updated_at", false, "types.Timestamp"},
+		{"date", "born_at", false, "time.Time"},
+		{"datetime", "published", false, "time.Time"},
+		{"datetime", "published", true, "sql.NullTime"},
+		// Column names are case-insensitive, unlike table names
+		{"datetime", "Created_AT", false, "types.Timestamp"},
+	}
+
+	for _, test := range tests {
+		result := sg.mysqlTypeToGoType(test.mysqlType, test.nullable, false, "posts", test.columnName)
+		if result != test.expected {
+			t.Errorf("mysqlTypeToGoType(%q, %q, nullable=%t) = %q, expected %q",
+				test.mysqlType, test.columnName, test.nullable, result, test.expected)
+		}
+	}
+
+	if err := (&Config{TimestampColumns: []string{"[_at"}}).Validate(); err == nil {
+		t.Error("Validate() with an invalid timestamp column pattern expected error, got nil")
+	}
+}
+
+func TestMysqlTypeToGoType_TolerantDateTimes(t *testing.T) {
+	sg := &SchemaGenerator{config: &Config{TolerantDateTimes: true, TimestampColumns: []string{"*_at"}}}
+
+	tests := []struct {
+		mysqlType  string
+		columnName string
+		nullable   bool
+		expected   string
+	}{
+		{"datetime", "published", false, "types.DateTime"},
+		{"timestamp", "published", true, "types.DateTime"},
+		{"date", "birthday", false, "types.DateTime"},
+		{"datetime", "created_at", false, "types.Timestamp"},
+		{"time", "opens", false, "string"},
+	}
+
+	for _, test := range tests {
+		result := sg.mysqlTypeToGoType(test.mysqlType, test.nullable, false, "posts", test.columnName)
+		if result != test.expected {
+			t.Errorf("mysqlTypeToGoType(%q, %q, nullable=%t) = %q, expected %q",
+				test.mysqlType, test.columnName, test.nullable, result, test.expected)
+		}
+	}
+}
+
+func TestMysqlTypeToGoType_BlobColumns(t *testing.T) {
+	tests := []struct {
+		mysqlType   string
+		blobColumns bool
+		expected    string
+	}{
+		{"longblob", true, "types.Blob"},
+		{"blob", true, "types.Blob"},
+		{"varbinary(16)", true, "[]byte"},
+		{"longblob", false, "[]byte"},
+	}
+
+	for _, test := range tests {
+		sg := &SchemaGenerator{config: &Config{BlobColumns: test.blobColumns}}
+		result := sg.mysqlTypeToGoType(test.mysqlType, true, false, "files", "content")
+		if result != test.expected {
+			t.Errorf("mysqlTypeToGoType(%q, blob_columns=%t) = %q, expected %q",
+				test.mysqlType, test.blobColumns, result, test.expected)
+		}
+	}
+}
+
+func TestMysqlTypeToGoType_PreciseJSON(t *testing.T) {
+	config := &Config{
+		PreciseJSON:  true,
+		JSONMappings: map[string]JSONMapping{"users.settings": {Type: "types.JSON[Settings]"}},
+	}
+	sg := &SchemaGenerator{config: config}
+
+	if result := sg.mysqlTypeToGoType("longtext", true, true, "users", "attributes"); result != "types.PreciseJSON[any]" {
+		t.Errorf("mysqlTypeToGoType(attributes) = %q, expected %q", result, "types.PreciseJSON[any]")
+	}
+	if result := sg.mysqlTypeToGoType("longtext", true, true, "users", "settings"); result != "types.JSON[Settings]" {
+		t.Errorf("mysqlTypeToGoType(settings) = %q, expected the json_mappings type", result)
+	}
+}
+
+func TestConfig_TableTypes(t *testing.T) {
+	tests := []struct {
+		tableTypes []string
+		expected   []string
+	}{
+		{nil, []string{"BASE TABLE", "SYSTEM VERSIONED"}},
+		{[]string{"BASE TABLE", "view"}, []string{"BASE TABLE", "SYSTEM VERSIONED", "VIEW"}},
+		{[]string{"SEQUENCE", "SYSTEM VERSIONED", "BASE TABLE"}, []string{"SEQUENCE", "SYSTEM VERSIONED", "BASE TABLE"}},
+	}
+
+	for _, test := range tests {
+		if result := (&Config{TableTypes: test.tableTypes}).tableTypes(); !reflect.DeepEqual(result, test.expected) {
+			t.Errorf("tableTypes() with %v = %v, expected %v", test.tableTypes, result, test.expected)
+		}
+	}
+
+	// One placeholder per table type
+	db := newFakeDB(map[string]fakeResult{
+		"TABLE_TYPE IN (?, ?, ?)": {columns: []string{"TABLE_NAME"}, rows: [][]driver.Value{{"orders"}, {"paid_orders"}}},
+	})
+	defer db.Close()
+
+	sg := NewSchemaGeneratorFromDB(db, &Config{TableTypes: []string{"BASE TABLE", "VIEW"}})
+	tables, err := sg.GetTables(context.Background())
+	if err != nil {
+		t.Fatalf("GetTables() error: %v", err)
+	}
+	if !reflect.DeepEqual(tables, []string{"orders", "paid_orders"}) {
+		t.Errorf("GetTables() = %v, expected [orders paid_orders]", tables)
+	}
+
+	if err := (&Config{TableTypes: []string{"VIEW", " "}}).Validate(); err == nil {
+		t.Error("Validate() with an empty table type expected error, got nil")
+	}
+}
+
+func TestNullableOverrides(t *testing.T) {
+	table := &TableInfo{
+		Name: "orders",
+		Columns: []ColumnInfo{
+			{Name: "id", Type: "int(11)"},
+			{Name: "total", Type: "decimal(10,2)", Nullable: true, IsGenerated: true},
+			{Name: "note", Type: "varchar(255)"},
+		},
+		PrimaryKeys: []string{"id"},
+	}
+	config := &Config{NullableOverrides: map[string]bool{
+		"orders.total": false,
+		"orders.note":  true,
+		"users.note":   false,
+	}}
+	if err := config.Validate(); err != nil {
+		t.Fatalf("Validate() unexpected error: %v", err)
+	}
+
+	result, err := NewSchemaGeneratorFromSource(newMemorySource(table), config).GenerateStructs(context.Background(), "models")
+	if err != nil {
+		t.Fatalf("GenerateStructs() error: %v", err)
+	}
+	for _, expected := range []string{"Total float64 `db:\"total\"`", "Note sql.NullString `db:\"note\"`"} {
+		if !strings.Contains(result, expected) {
+			t.Errorf("GenerateStructs() missing %q in:\n%s", expected, result)
+		}
+	}
+
+	// The override is applied to a copy of the inspected table
+	if !table.Columns[1].Nullable || table.Columns[2].Nullable {
+		t.Errorf("nullable overrides modified the source table: %+v", table.Columns)
+	}
+
+	if err := (&Config{NullableOverrides: map[string]bool{"total": false}}).Validate(); err == nil || !strings.Contains(err.Error(), "table.column") {
+		t.Errorf("Validate() error = %v, expected a table.column error", err)
+	}
+}
+
+func TestConfig_GetRequiredImports(t *testing.T) {
+	config := &Config{JSONMappings: map[string]JSONMapping{
+		"users.settings": {Type: "types.JSON[models.Settings]", Import: "github.com/example/models"},
+		"users.profile":  {Type: "models.Profile", Import: "github.com/example/models"},
+		"users.tags":     {Type: "[]string"},
+	}}
+
+	imports := config.GetRequiredImports()
+	if len(imports) != 1 || imports[0] != "github.com/example/models" {
+		t.Errorf("GetRequiredImports() = %v, expected [github.com/example/models]", imports)
+	}
+}
@@ -0,0 +1,145 @@
+package schema
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// DiffResult describes the differences between two schema snapshots
+type DiffResult struct {
+	AddedTables   []string
+	RemovedTables []string
+	ChangedTables []TableDiff
+}
+
+// TableDiff describes column changes of a table present in both snapshots
+type TableDiff struct {
+	Table          string
+	AddedColumns   []ColumnInfo
+	RemovedColumns []ColumnInfo
+	ChangedColumns []ColumnChange
+}
+
+// ColumnChange describes a column whose type or nullability changed
+type ColumnChange struct {
+	Column string
+	Old    ColumnInfo
+	New    ColumnInfo
+}
+
+// Diff compares two schema snapshots and reports added and removed tables
+// as well as added, removed and changed columns. Columns are considered
+// changed when their type or nullability differs.
+func Diff(old, new []TableInfo) DiffResult {
+	oldTables := make(map[string]TableInfo)
+	for _, table := range old {
+		oldTables[table.Name] = table
+	}
+	newTables := make(map[string]TableInfo)
+	for _, table := range new {
+		newTables[table.Name] = table
+	}
+
+	var result DiffResult
+	for name := range newTables {
+		if _, exists := oldTables[name]; !exists {
+			result.AddedTables = append(result.AddedTables, name)
+		}
+	}
+	for name, oldTable := range oldTables {
+		newTable, exists := newTables[name]
+		if !exists {
+			result.RemovedTables = append(result.RemovedTables, name)
+			continue
+		}
+		if tableDiff := diffTable(oldTable, newTable); !tableDiff.empty() {
+			result.ChangedTables = append(result.ChangedTables, tableDiff)
+		}
+	}
+
+	sort.Strings(result.AddedTables)
+	sort.Strings(result.RemovedTables)
+	sort.Slice(result.ChangedTables, func(i, j int) bool {
+		return result.ChangedTables[i].Table < result.ChangedTables[j].Table
+	})
+
+	return result
+}
+
+// diffTable compares the columns of two versions of a table, keeping column order
+func diffTable(old, new TableInfo) TableDiff {
+	tableDiff := TableDiff{Table: new.Name}
+
+	oldColumns := make(map[string]ColumnInfo)
+	for _, col := range old.Columns {
+		oldColumns[col.Name] = col
+	}
+	newColumns := make(map[string]bool)
+
+	for _, col := range new.Columns {
+		newColumns[col.Name] = true
+		oldCol, exists := oldColumns[col.Name]
+		if !exists {
+			tableDiff.AddedColumns = append(tableDiff.AddedColumns, col)
+			continue
+		}
+		if oldCol.Type != col.Type || oldCol.Nullable != col.Nullable {
+			tableDiff.ChangedColumns = append(tableDiff.ChangedColumns, ColumnChange{Column: col.Name, Old: oldCol, New: col})
+		}
+	}
+
+	for _, col := range old.Columns {
+		if !newColumns[col.Name] {
+			tableDiff.RemovedColumns = append(tableDiff.RemovedColumns, col)
+		}
+	}
+
+	return tableDiff
+}
+
+func (d TableDiff) empty() bool {
+	return len(d.AddedColumns) == 0 && len(d.RemovedColumns) == 0 && len(d.ChangedColumns) == 0
+}
+
+// Empty returns true if both snapshots describe the same schema
+func (d DiffResult) Empty() bool {
+	return len(d.AddedTables) == 0 && len(d.RemovedTables) == 0 && len(d.ChangedTables) == 0
+}
+
+// String returns a human-readable summary of the differences, one change per line
+func (d DiffResult) String() string {
+	if d.Empty() {
+		return "no schema changes\n"
+	}
+
+	var builder strings.Builder
+	for _, table := range d.AddedTables {
+		builder.WriteString(fmt.Sprintf("+ table %s\n", table))
+	}
+	for _, table := range d.RemovedTables {
+		builder.WriteString(fmt.Sprintf("- table %s\n", table))
+	}
+	for _, table := range d.ChangedTables {
+		builder.WriteString(fmt.Sprintf("~ table %s\n", table.Table))
+		for _, col := range table.AddedColumns {
+			builder.WriteString(fmt.Sprintf("  + column %s %s %s\n", col.Name, col.Type, nullability(col.Nullable)))
+		}
+		for _, col := range table.RemovedColumns {
+			builder.WriteString(fmt.Sprintf("  - column %s\n", col.Name))
+		}
+		for _, change := range table.ChangedColumns {
+			builder.WriteString(fmt.Sprintf("  ~ column %s: %s %s -> %s %s\n", change.Column,
+				change.Old.Type, nullability(change.Old.Nullable), change.New.Type, nullability(change.New.Nullable)))
+		}
+	}
+
+	return builder.String()
+}
+
+func nullability(nullable bool) string {
+	if nullable {
+		return "NULL"
+	}
+	return "NOT NULL"
+}
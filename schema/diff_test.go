@@ -0,0 +1,69 @@
+package schema
+
+import (
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	old := []TableInfo{
+		{Name: "legacy", Columns: []ColumnInfo{{Name: "id", Type: "int(11)"}}},
+		{Name: "users", Columns: []ColumnInfo{
+			{Name: "id", Type: "int(11)"},
+			{Name: "email", Type: "varchar(100)", Nullable: true},
+			{Name: "age", Type: "int(11)"},
+		}},
+	}
+	new := []TableInfo{
+		{Name: "orders", Columns: []ColumnInfo{{Name: "id", Type: "int(11)"}}},
+		{Name: "users", Columns: []ColumnInfo{
+			{Name: "id", Type: "int(11)"},
+			{Name: "email", Type: "varchar(255)", Nullable: false},
+			{Name: "nickname", Type: "varchar(50)", Nullable: true},
+		}},
+	}
+
+	result := Diff(old, new)
+
+	if len(result.AddedTables) != 1 || result.AddedTables[0] != "orders" {
+		t.Errorf("AddedTables = %v, expected [orders]", result.AddedTables)
+	}
+	if len(result.RemovedTables) != 1 || result.RemovedTables[0] != "legacy" {
+		t.Errorf("RemovedTables = %v, expected [legacy]", result.RemovedTables)
+	}
+	if len(result.ChangedTables) != 1 {
+		t.Fatalf("ChangedTables = %v, expected one changed table", result.ChangedTables)
+	}
+
+	users := result.ChangedTables[0]
+	if len(users.AddedColumns) != 1 || users.AddedColumns[0].Name != "nickname" {
+		t.Errorf("AddedColumns = %v, expected nickname", users.AddedColumns)
+	}
+	if len(users.RemovedColumns) != 1 || users.RemovedColumns[0].Name != "age" {
+		t.Errorf("RemovedColumns = %v, expected age", users.RemovedColumns)
+	}
+	if len(users.ChangedColumns) != 1 || users.ChangedColumns[0].Column != "email" {
+		t.Fatalf("ChangedColumns = %v, expected email", users.ChangedColumns)
+	}
+
+	expected := "+ table orders\n" +
+		"- table legacy\n" +
+		"~ table users\n" +
+		"  + column nickname varchar(50) NULL\n" +
+		"  - column age\n" +
+		"  ~ column email: varchar(100) NULL -> varchar(255) NOT NULL\n"
+	if result.String() != expected {
+		t.Errorf("String() = \n%s\nexpected:\n%s", result.String(), expected)
+	}
+}
+
+func TestDiff_Identical(t *testing.T) {
+	tables := []TableInfo{{Name: "users", Columns: []ColumnInfo{{Name: "id", Type: "int(11)"}}}}
+
+	result := Diff(tables, tables)
+	if !result.Empty() {
+		t.Errorf("Diff() of identical snapshots = %v, expected empty", result)
+	}
+	if result.String() != "no schema changes\n" {
+		t.Errorf("String() of empty diff = %q", result.String())
+	}
+}
@@ -0,0 +1,85 @@
+package schema
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+// rewriteDSN parses dsn with the mysql driver, lets modify change the parsed
+// configuration and serializes it again. Going through the driver keeps every
+// DSN form it accepts intact, including unix socket addresses and parameters.
+func rewriteDSN(dsn string, modify func(cfg *mysql.Config)) (string, error) {
+	cfg, err := mysql.ParseDSN(dsn)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse connection string %s: %w", RedactDSN(dsn), err)
+	}
+
+	modify(cfg)
+
+	return cfg.FormatDSN(), nil
+}
+
+// prepareDSN applies the connection overrides from config to dsn. The DSN is
+// returned unchanged when there is nothing to override.
+func prepareDSN(dsn string, config *Config) (string, error) {
+	if config == nil || config.Schema == "" {
+		return dsn, nil
+	}
+
+	return rewriteDSN(dsn, func(cfg *mysql.Config) {
+		cfg.DBName = config.Schema
+	})
+}
+
+// readOnlyVariable returns the system variable that makes a session read-only
+// on the server: MariaDB only added transaction_read_only in 11.1 and MySQL
+// in 5.7.20, older servers use tx_read_only.
+func readOnlyVariable(version ServerVersion) string {
+	if version.MariaDB && !version.AtLeast(11, 1, 0) || !version.MariaDB && !version.AtLeast(5, 7, 20) {
+		return "tx_read_only"
+	}
+	return "transaction_read_only"
+}
+
+// readOnlyDSN adds the read-only session variable for version to the
+// parameters of dsn. The driver sets DSN parameters on every new connection,
+// so the whole pool stays read-only across reconnects.
+func readOnlyDSN(dsn string, version ServerVersion) (string, error) {
+	return rewriteDSN(dsn, func(cfg *mysql.Config) {
+		if cfg.Params == nil {
+			cfg.Params = make(map[string]string)
+		}
+		cfg.Params[readOnlyVariable(version)] = "1"
+	})
+}
+
+// RedactDSN masks the password of a connection string so it can be logged.
+// It handles the driver's user:password@net(addr)/dbname form, including
+// passwords containing @ or /, as well as URL-style DSNs. Everything except
+// the password is returned unchanged.
+func RedactDSN(dsn string) string {
+	if strings.Contains(dsn, "://") {
+		if u, err := url.Parse(dsn); err == nil {
+			return u.Redacted()
+		}
+	}
+
+	// Like the driver, the credentials end at the last @ before the last /
+	end := strings.LastIndex(dsn, "/")
+	if end < 0 {
+		end = len(dsn)
+	}
+	at := strings.LastIndex(dsn[:end], "@")
+	if at < 0 {
+		return dsn
+	}
+
+	colon := strings.Index(dsn[:at], ":")
+	if colon < 0 {
+		return dsn
+	}
+	return dsn[:colon+1] + "xxxxx" + dsn[at:]
+}
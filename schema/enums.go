@@ -0,0 +1,268 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+)
+
+// enumMode returns the configured enum generation mode
+func (sg *SchemaGenerator) enumMode() string {
+	return sg.config.enumMode()
+}
+
+// generateEnumValuesMethod generates a Values method returning a copy of the
+// raw declared values. The receiver is ignored; the method lets typed enums
+// satisfy interfaces that list allowed values, such as for UI pickers.
+func (sg *SchemaGenerator) generateEnumValuesMethod(tableName string, enum EnumInfo) string {
+	typeName := sg.toEnumTypeName(tableName, enum.ColumnName)
+	allowedName := sg.toEnumAllowedName(tableName, enum.ColumnName)
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("// Values returns the raw declared values of the %s.%s column in declaration order\n", tableName, enum.ColumnName))
+	builder.WriteString(fmt.Sprintf("func (%s) Values() []string {\n", typeName))
+	builder.WriteString(fmt.Sprintf("\treturn append([]string(nil), %s...)\n", allowedName))
+	builder.WriteString("}\n\n")
+	return builder.String()
+}
+
+// generateTypedEnumMethods generates the methods of a typed enum
+func (sg *SchemaGenerator) generateTypedEnumMethods(tableName string, enum EnumInfo) string {
+	typeName := sg.toEnumTypeName(tableName, enum.ColumnName)
+	allowedName := sg.toEnumAllowedName(tableName, enum.ColumnName)
+	allName := sg.toEnumAllFuncName(tableName, enum.ColumnName)
+	fromIndexName := sg.toEnumFromIndexName(tableName, enum.ColumnName)
+
+	setName := sg.toEnumSetName(tableName, enum.ColumnName)
+
+	var builder strings.Builder
+
+	members := make([]string, len(enum.Values))
+	for i, value := range enum.Values {
+		members[i] = fmt.Sprintf("%q: {}", value)
+	}
+	builder.WriteString(fmt.Sprintf("// %s holds the declared values of the %s.%s column for Valid\n", setName, tableName, enum.ColumnName))
+	builder.WriteString(fmt.Sprintf("var %s = map[%s]struct{}{%s}\n\n", setName, typeName, strings.Join(members, ", ")))
+
+	builder.WriteString(fmt.Sprintf("// Valid returns true if e is a declared value of the %s.%s column\n", tableName, enum.ColumnName))
+	builder.WriteString(fmt.Sprintf("func (e %s) Valid() bool {\n", typeName))
+	builder.WriteString(fmt.Sprintf("\t_, ok := %s[e]\n", setName))
+	builder.WriteString("\treturn ok\n")
+	builder.WriteString("}\n\n")
+
+	builder.WriteString(fmt.Sprintf("// %s returns the declared values of the %s.%s column in declaration order\n", allName, tableName, enum.ColumnName))
+	builder.WriteString(fmt.Sprintf("func %s() []%s {\n", allName, typeName))
+	builder.WriteString(fmt.Sprintf("\tvalues := make([]%s, len(%s))\n", typeName, allowedName))
+	builder.WriteString(fmt.Sprintf("\tfor i, v := range %s {\n", allowedName))
+	builder.WriteString(fmt.Sprintf("\t\tvalues[i] = %s(v)\n", typeName))
+	builder.WriteString("\t}\n")
+	builder.WriteString("\treturn values\n")
+	builder.WriteString("}\n\n")
+
+	builder.WriteString(fmt.Sprintf("// AllValues returns the declared values of the %s.%s column in declaration order\n", tableName, enum.ColumnName))
+	builder.WriteString(fmt.Sprintf("func (%s) AllValues() []%s {\n", typeName, typeName))
+	builder.WriteString(fmt.Sprintf("\treturn %s()\n", allName))
+	builder.WriteString("}\n\n")
+
+	builder.WriteString(sg.generateEnumValuesMethod(tableName, enum))
+
+	builder.WriteString("// MarshalText implements encoding.TextMarshaler\n")
+	builder.WriteString(fmt.Sprintf("func (e %s) MarshalText() ([]byte, error) {\n", typeName))
+	builder.WriteString("\treturn []byte(e), nil\n")
+	builder.WriteString("}\n\n")
+
+	builder.WriteString("// UnmarshalText implements encoding.TextUnmarshaler and rejects undeclared values\n")
+	builder.WriteString(fmt.Sprintf("func (e *%s) UnmarshalText(text []byte) error {\n", typeName))
+	builder.WriteString(fmt.Sprintf("\tv := %s(text)\n", typeName))
+	builder.WriteString("\tif !v.Valid() {\n")
+	builder.WriteString(fmt.Sprintf("\t\treturn fmt.Errorf(\"invalid %s value %%q\", text)\n", typeName))
+	builder.WriteString("\t}\n")
+	builder.WriteString("\t*e = v\n")
+	builder.WriteString("\treturn nil\n")
+	builder.WriteString("}\n\n")
+
+	builder.WriteString(fmt.Sprintf("// Index returns the 1-based position of e in the %s.%s column definition, matching\n", tableName, enum.ColumnName))
+	builder.WriteString("// MariaDB's numeric enum value, or 0 if e is not a declared value\n")
+	builder.WriteString(fmt.Sprintf("func (e %s) Index() int {\n", typeName))
+	builder.WriteString(fmt.Sprintf("\tfor i, v := range %s {\n", allowedName))
+	builder.WriteString("\t\tif string(e) == v {\n")
+	builder.WriteString("\t\t\treturn i + 1\n")
+	builder.WriteString("\t\t}\n")
+	builder.WriteString("\t}\n")
+	builder.WriteString("\treturn 0\n")
+	builder.WriteString("}\n\n")
+
+	builder.WriteString(fmt.Sprintf("// Next returns the value declared after e in the %s.%s column. It returns e and\n", tableName, enum.ColumnName))
+	builder.WriteString("// false for the last value and for undeclared values.\n")
+	builder.WriteString(fmt.Sprintf("func (e %s) Next() (%s, bool) {\n", typeName, typeName))
+	builder.WriteString("\ti := e.Index()\n")
+	builder.WriteString(fmt.Sprintf("\tif i == 0 || i == len(%s) {\n", allowedName))
+	builder.WriteString("\t\treturn e, false\n")
+	builder.WriteString("\t}\n")
+	builder.WriteString(fmt.Sprintf("\treturn %s(%s[i]), true\n", typeName, allowedName))
+	builder.WriteString("}\n\n")
+
+	builder.WriteString(fmt.Sprintf("// Prev returns the value declared before e in the %s.%s column. It returns e and\n", tableName, enum.ColumnName))
+	builder.WriteString("// false for the first value and for undeclared values.\n")
+	builder.WriteString(fmt.Sprintf("func (e %s) Prev() (%s, bool) {\n", typeName, typeName))
+	builder.WriteString("\ti := e.Index()\n")
+	builder.WriteString("\tif i <= 1 {\n")
+	builder.WriteString("\t\treturn e, false\n")
+	builder.WriteString("\t}\n")
+	builder.WriteString(fmt.Sprintf("\treturn %s(%s[i-2]), true\n", typeName, allowedName))
+	builder.WriteString("}\n\n")
+
+	builder.WriteString(fmt.Sprintf("// %s returns the %s at the 1-based position i of the column definition\n", fromIndexName, typeName))
+	builder.WriteString(fmt.Sprintf("func %s(i int) (%s, error) {\n", fromIndexName, typeName))
+	builder.WriteString(fmt.Sprintf("\tif i < 1 || i > len(%s) {\n", allowedName))
+	builder.WriteString(fmt.Sprintf("\t\treturn \"\", fmt.Errorf(\"%s index %%d out of range [1, %%d]\", i, len(%s))\n", typeName, allowedName))
+	builder.WriteString("\t}\n")
+	builder.WriteString(fmt.Sprintf("\treturn %s(%s[i-1]), nil\n", typeName, allowedName))
+	builder.WriteString("}\n\n")
+
+	builder.WriteString(sg.generateNullEnum(tableName, enum))
+
+	return builder.String()
+}
+
+// generateNullEnum generates the nullable wrapper of a typed enum used for nullable enum columns
+func (sg *SchemaGenerator) generateNullEnum(tableName string, enum EnumInfo) string {
+	typeName := sg.toEnumTypeName(tableName, enum.ColumnName)
+	nullTypeName := sg.toNullEnumTypeName(tableName, enum.ColumnName)
+
+	var builder strings.Builder
+
+	builder.WriteString(fmt.Sprintf("// %s is a nullable %s\n", nullTypeName, typeName))
+	builder.WriteString(fmt.Sprintf("type %s struct {\n", nullTypeName))
+	builder.WriteString(fmt.Sprintf("\tEnum  %s\n", typeName))
+	builder.WriteString("\tValid bool // Valid is true if Enum is not NULL\n")
+	builder.WriteString("}\n\n")
+
+	builder.WriteString("// Scan implements the sql.Scanner interface and rejects undeclared values\n")
+	builder.WriteString(fmt.Sprintf("func (n *%s) Scan(value any) error {\n", nullTypeName))
+	builder.WriteString("\tvar s string\n")
+	builder.WriteString("\tswitch v := value.(type) {\n")
+	builder.WriteString("\tcase nil:\n")
+	builder.WriteString("\t\tn.Enum, n.Valid = \"\", false\n")
+	builder.WriteString("\t\treturn nil\n")
+	builder.WriteString("\tcase string:\n")
+	builder.WriteString("\t\ts = v\n")
+	builder.WriteString("\tcase []byte:\n")
+	builder.WriteString("\t\ts = string(v)\n")
+	builder.WriteString("\tdefault:\n")
+	builder.WriteString(fmt.Sprintf("\t\treturn fmt.Errorf(\"unsupported type for %s: %%T\", value)\n", nullTypeName))
+	builder.WriteString("\t}\n")
+	builder.WriteString(fmt.Sprintf("\te := %s(s)\n", typeName))
+	builder.WriteString("\tif !e.Valid() {\n")
+	builder.WriteString(fmt.Sprintf("\t\treturn fmt.Errorf(\"invalid %s value %%q\", s)\n", typeName))
+	builder.WriteString("\t}\n")
+	builder.WriteString("\tn.Enum, n.Valid = e, true\n")
+	builder.WriteString("\treturn nil\n")
+	builder.WriteString("}\n\n")
+
+	builder.WriteString("// Value implements the driver.Valuer interface\n")
+	builder.WriteString(fmt.Sprintf("func (n %s) Value() (driver.Value, error) {\n", nullTypeName))
+	builder.WriteString("\tif !n.Valid {\n")
+	builder.WriteString("\t\treturn nil, nil\n")
+	builder.WriteString("\t}\n")
+	builder.WriteString("\treturn string(n.Enum), nil\n")
+	builder.WriteString("}\n\n")
+
+	return builder.String()
+}
+
+// generateIntEnumMethods generates the methods of an int enum, which map its
+// values to and from the strings stored in MariaDB
+func (sg *SchemaGenerator) generateIntEnumMethods(tableName string, enum EnumInfo) string {
+	typeName := sg.toEnumTypeName(tableName, enum.ColumnName)
+	nullTypeName := sg.toNullEnumTypeName(tableName, enum.ColumnName)
+	allowedName := sg.toEnumAllowedName(tableName, enum.ColumnName)
+
+	var builder strings.Builder
+
+	builder.WriteString(fmt.Sprintf("// Valid returns true if e is a declared value of the %s.%s column\n", tableName, enum.ColumnName))
+	builder.WriteString(fmt.Sprintf("func (e %s) Valid() bool {\n", typeName))
+	builder.WriteString(fmt.Sprintf("\treturn e >= 0 && int(e) < len(%s)\n", allowedName))
+	builder.WriteString("}\n\n")
+
+	// Like stringer, undeclared values such as a newer database value
+	// print as the type name and number
+	builder.WriteString(fmt.Sprintf("// String returns the value stored in MariaDB, or %s(n) for undeclared values\n", typeName))
+	builder.WriteString(fmt.Sprintf("func (e %s) String() string {\n", typeName))
+	builder.WriteString("\tswitch e {\n")
+	for i, constName := range sg.enumConstantNames(tableName, enum) {
+		builder.WriteString(fmt.Sprintf("\tcase %s:\n", constName))
+		builder.WriteString(fmt.Sprintf("\t\treturn %q\n", enum.Values[i]))
+	}
+	builder.WriteString("\tdefault:\n")
+	builder.WriteString(fmt.Sprintf("\t\treturn fmt.Sprintf(\"%s(%%d)\", int(e))\n", typeName))
+	builder.WriteString("\t}\n")
+	builder.WriteString("}\n\n")
+
+	builder.WriteString(fmt.Sprintf("// AllValues returns the declared values of the %s.%s column in declaration order\n", tableName, enum.ColumnName))
+	builder.WriteString(fmt.Sprintf("func (%s) AllValues() []%s {\n", typeName, typeName))
+	builder.WriteString(fmt.Sprintf("\tvalues := make([]%s, len(%s))\n", typeName, allowedName))
+	builder.WriteString("\tfor i := range values {\n")
+	builder.WriteString(fmt.Sprintf("\t\tvalues[i] = %s(i)\n", typeName))
+	builder.WriteString("\t}\n")
+	builder.WriteString("\treturn values\n")
+	builder.WriteString("}\n\n")
+
+	builder.WriteString(sg.generateEnumValuesMethod(tableName, enum))
+
+	builder.WriteString("// Scan implements the sql.Scanner interface and rejects undeclared values\n")
+	builder.WriteString(fmt.Sprintf("func (e *%s) Scan(value any) error {\n", typeName))
+	builder.WriteString("\tvar s string\n")
+	builder.WriteString("\tswitch v := value.(type) {\n")
+	builder.WriteString("\tcase string:\n")
+	builder.WriteString("\t\ts = v\n")
+	builder.WriteString("\tcase []byte:\n")
+	builder.WriteString("\t\ts = string(v)\n")
+	builder.WriteString("\tdefault:\n")
+	builder.WriteString(fmt.Sprintf("\t\treturn fmt.Errorf(\"unsupported type for %s: %%T\", value)\n", typeName))
+	builder.WriteString("\t}\n")
+	builder.WriteString(fmt.Sprintf("\tfor i, v := range %s {\n", allowedName))
+	builder.WriteString("\t\tif s == v {\n")
+	builder.WriteString(fmt.Sprintf("\t\t\t*e = %s(i)\n", typeName))
+	builder.WriteString("\t\t\treturn nil\n")
+	builder.WriteString("\t\t}\n")
+	builder.WriteString("\t}\n")
+	builder.WriteString(fmt.Sprintf("\treturn fmt.Errorf(\"invalid %s value %%q\", s)\n", typeName))
+	builder.WriteString("}\n\n")
+
+	builder.WriteString("// Value implements the driver.Valuer interface and writes the declared string\n")
+	builder.WriteString(fmt.Sprintf("func (e %s) Value() (driver.Value, error) {\n", typeName))
+	builder.WriteString("\tif !e.Valid() {\n")
+	builder.WriteString(fmt.Sprintf("\t\treturn nil, fmt.Errorf(\"invalid %s value %%d\", int(e))\n", typeName))
+	builder.WriteString("\t}\n")
+	builder.WriteString(fmt.Sprintf("\treturn %s[e], nil\n", allowedName))
+	builder.WriteString("}\n\n")
+
+	builder.WriteString(fmt.Sprintf("// %s is a nullable %s\n", nullTypeName, typeName))
+	builder.WriteString(fmt.Sprintf("type %s struct {\n", nullTypeName))
+	builder.WriteString(fmt.Sprintf("\tEnum  %s\n", typeName))
+	builder.WriteString("\tValid bool // Valid is true if Enum is not NULL\n")
+	builder.WriteString("}\n\n")
+
+	builder.WriteString("// Scan implements the sql.Scanner interface and rejects undeclared values\n")
+	builder.WriteString(fmt.Sprintf("func (n *%s) Scan(value any) error {\n", nullTypeName))
+	builder.WriteString("\tif value == nil {\n")
+	builder.WriteString("\t\tn.Enum, n.Valid = 0, false\n")
+	builder.WriteString("\t\treturn nil\n")
+	builder.WriteString("\t}\n")
+	builder.WriteString("\tif err := n.Enum.Scan(value); err != nil {\n")
+	builder.WriteString("\t\treturn err\n")
+	builder.WriteString("\t}\n")
+	builder.WriteString("\tn.Valid = true\n")
+	builder.WriteString("\treturn nil\n")
+	builder.WriteString("}\n\n")
+
+	builder.WriteString("// Value implements the driver.Valuer interface\n")
+	builder.WriteString(fmt.Sprintf("func (n %s) Value() (driver.Value, error) {\n", nullTypeName))
+	builder.WriteString("\tif !n.Valid {\n")
+	builder.WriteString("\t\treturn nil, nil\n")
+	builder.WriteString("\t}\n")
+	builder.WriteString("\treturn n.Enum.Value()\n")
+	builder.WriteString("}\n\n")
+
+	return builder.String()
+}
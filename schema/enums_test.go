@@ -0,0 +1,439 @@
+package schema
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func enumsTestTable() *TableInfo {
+	return &TableInfo{
+		Name: "users",
+		Columns: []ColumnInfo{
+			{Name: "id", Type: "int(11)"},
+			{Name: "status", Type: "enum('active','inactive','banned')", IsEnum: true, EnumValues: []string{"active", "inactive", "banned"}},
+		},
+		PrimaryKeys: []string{"id"},
+	}
+}
+
+func generateTypedEnums(t *testing.T, config *Config, tables ...*TableInfo) string {
+	t.Helper()
+
+	sg := NewSchemaGeneratorFromSource(newMemorySource(tables...), config)
+	result, err := sg.GenerateEnumConstants(context.Background(), "main")
+	if err != nil {
+		t.Fatalf("GenerateEnumConstants() error: %v", err)
+	}
+	return result
+}
+
+func TestGenerateEnumConstants_Typed(t *testing.T) {
+	result := generateTypedEnums(t, &Config{EnumMode: EnumModeTyped}, enumsTestTable())
+
+	expected := []string{
+		"type UsersStatus string",
+		`Users_Status_Active UsersStatus = "active"`,
+		"func (e UsersStatus) Valid() bool {",
+		"func (e UsersStatus) MarshalText() ([]byte, error) {",
+		"func (e *UsersStatus) UnmarshalText(text []byte) error {",
+	}
+	for _, exp := range expected {
+		if !strings.Contains(result, exp) {
+			t.Errorf("GenerateEnumConstants() missing %q in:\n%s", exp, result)
+		}
+	}
+
+	sg := &SchemaGenerator{config: &Config{EnumMode: EnumModeTyped}}
+	if goType := sg.mysqlTypeToGoType("enum('active','inactive','banned')", false, false, "users", "status"); goType != "UsersStatus" {
+		t.Errorf("mysqlTypeToGoType() for typed enum = %q, expected %q", goType, "UsersStatus")
+	}
+}
+
+func TestGenerateEnumConstants_TypedTextMarshaling(t *testing.T) {
+	result := generateTypedEnums(t, &Config{EnumMode: EnumModeTyped}, enumsTestTable())
+
+	output := runGenerated(t, map[string]string{"enum_constants.go": result}, `package main
+
+import "fmt"
+
+func main() {
+	text, err := Users_Status_Inactive.MarshalText()
+	fmt.Println(string(text), err)
+
+	var status UsersStatus
+	fmt.Println(status.UnmarshalText([]byte("banned")), status)
+	fmt.Println(status.UnmarshalText([]byte("deleted")), status)
+}
+`)
+
+	expected := "inactive <nil>\n<nil> banned\ninvalid UsersStatus value \"deleted\" banned\n"
+	if output != expected {
+		t.Errorf("typed enum text marshaling output = %q, expected %q", output, expected)
+	}
+}
+
+func TestGenerateEnumConstants_TypedNullEnum(t *testing.T) {
+	result := generateTypedEnums(t, &Config{EnumMode: EnumModeTyped}, enumsTestTable())
+
+	sg := &SchemaGenerator{config: &Config{EnumMode: EnumModeTyped}}
+	if goType := sg.mysqlTypeToGoType("enum('active','inactive','banned')", true, false, "users", "status"); goType != "NullUsersStatus" {
+		t.Errorf("mysqlTypeToGoType() for nullable typed enum = %q, expected %q", goType, "NullUsersStatus")
+	}
+
+	output := runGenerated(t, map[string]string{"enum_constants.go": result}, `package main
+
+import "fmt"
+
+func main() {
+	var n NullUsersStatus
+	fmt.Println(n.Scan([]byte("inactive")), n.Enum, n.Valid)
+	value, err := n.Value()
+	fmt.Println(value, err)
+
+	fmt.Println(n.Scan("deleted"), n.Enum, n.Valid)
+
+	fmt.Println(n.Scan(nil), n.Valid)
+	value, err = n.Value()
+	fmt.Println(value, err)
+}
+`)
+
+	expected := "<nil> inactive true\n" +
+		"inactive <nil>\n" +
+		"invalid UsersStatus value \"deleted\" inactive true\n" +
+		"<nil> false\n" +
+		"<nil> <nil>\n"
+	if output != expected {
+		t.Errorf("nullable typed enum round trip output = %q, expected %q", output, expected)
+	}
+}
+
+func TestGenerateEnumConstants_TypedIndex(t *testing.T) {
+	result := generateTypedEnums(t, &Config{EnumMode: EnumModeTyped}, enumsTestTable())
+
+	output := runGenerated(t, map[string]string{"enum_constants.go": result}, `package main
+
+import "fmt"
+
+func main() {
+	fmt.Println(Users_Status_Active.Index(), Users_Status_Inactive.Index(), Users_Status_Banned.Index(), UsersStatus("deleted").Index())
+
+	for i := 0; i <= 4; i++ {
+		status, err := UsersStatusFromIndex(i)
+		fmt.Println(status, err)
+	}
+}
+`)
+
+	expected := "1 2 3 0\n" +
+		" UsersStatus index 0 out of range [1, 3]\n" +
+		"active <nil>\n" +
+		"inactive <nil>\n" +
+		"banned <nil>\n" +
+		" UsersStatus index 4 out of range [1, 3]\n"
+	if output != expected {
+		t.Errorf("typed enum index output = %q, expected %q", output, expected)
+	}
+}
+
+func TestGenerateEnumConstants_TypedSet(t *testing.T) {
+	result := generateTypedEnums(t, &Config{EnumMode: EnumModeTyped}, enumsTestTable())
+
+	expected := `var usersStatusSet = map[UsersStatus]struct{}{"active": {}, "inactive": {}, "banned": {}}`
+	if !strings.Contains(result, expected) {
+		t.Errorf("GenerateEnumConstants() missing %q in:\n%s", expected, result)
+	}
+
+	_, valid, _ := strings.Cut(result, "func (e UsersStatus) Valid() bool {\n")
+	valid, _, _ = strings.Cut(valid, "}\n")
+	if valid != "\t_, ok := usersStatusSet[e]\n\treturn ok\n" {
+		t.Errorf("Valid() body = %q, expected a single lookup in usersStatusSet", valid)
+	}
+
+	output := runGenerated(t, map[string]string{"enum_constants.go": result}, `package main
+
+import "fmt"
+
+func main() {
+	fmt.Println(AllUsersStatus(), Users_Status_Banned.AllValues())
+
+	values := AllUsersStatus()
+	values[0] = "deleted"
+	fmt.Println(AllUsersStatus()[0], UsersStatus("deleted").Valid(), Users_Status_Inactive.Valid())
+}
+`)
+
+	expected = "[active inactive banned] [active inactive banned]\nactive false true\n"
+	if output != expected {
+		t.Errorf("typed enum set output = %q, expected %q", output, expected)
+	}
+}
+
+func TestGenerateEnumConstants_TypedValuesMethod(t *testing.T) {
+	for _, mode := range []string{EnumModeTyped, EnumModeInt} {
+		result := generateTypedEnums(t, &Config{EnumMode: mode}, enumsTestTable())
+		if !strings.Contains(result, "func (UsersStatus) Values() []string {") {
+			t.Fatalf("GenerateEnumConstants() in %s mode missing Values method in:\n%s", mode, result)
+		}
+
+		output := runGenerated(t, map[string]string{"enum_constants.go": result}, `package main
+
+import "fmt"
+
+type Enumerable interface{ Values() []string }
+
+func main() {
+	var status UsersStatus
+	var e Enumerable = status
+	values := e.Values()
+	fmt.Println(values, len(values))
+
+	values[0] = "deleted"
+	fmt.Println(status.Values()[0])
+}
+`)
+		expected := "[active inactive banned] 3\nactive\n"
+		if output != expected {
+			t.Errorf("Values() output in %s mode = %q, expected %q", mode, output, expected)
+		}
+	}
+}
+
+func TestGenerateEnumBlock_CollidingValues(t *testing.T) {
+	sg := &SchemaGenerator{}
+	enum := EnumInfo{
+		TableName:  "tasks",
+		ColumnName: "state",
+		Values:     []string{"in-progress", "in_progress", "in progress", "done"},
+	}
+
+	names := sg.enumConstantNames("tasks", enum)
+	expected := []string{"Tasks_State_InProgress", "Tasks_State_InProgress_2", "Tasks_State_InProgress_3", "Tasks_State_Done"}
+	if strings.Join(names, ",") != strings.Join(expected, ",") {
+		t.Errorf("enumConstantNames() = %v, expected %v", names, expected)
+	}
+
+	if len(sg.Warnings()) != 2 || !strings.Contains(sg.Warnings()[0], `"in-progress" and "in_progress" of tasks.state both map to Tasks_State_InProgress`) {
+		t.Errorf("Warnings() = %v, expected collision warnings", sg.Warnings())
+	}
+
+	// Generating the block again does not repeat warnings
+	sg.generateEnumBlock("tasks", enum)
+	if len(sg.Warnings()) != 2 {
+		t.Errorf("Warnings() after regenerating = %v, expected 2 warnings", sg.Warnings())
+	}
+}
+
+func TestGenerateStructs_CollidingTables(t *testing.T) {
+	sg := NewSchemaGeneratorFromSource(newMemorySource(
+		&TableInfo{Name: "user_log", Columns: []ColumnInfo{{Name: "id", Type: "int(11)"}}},
+		&TableInfo{Name: "UserLog", Columns: []ColumnInfo{{Name: "id", Type: "int(11)"}}},
+	), nil)
+
+	_, err := sg.GenerateStructs(context.Background(), "models")
+	if err == nil || !strings.Contains(err.Error(), "both map to struct UserLog") {
+		t.Errorf("GenerateStructs() error = %v, expected struct collision error", err)
+	}
+}
+
+func TestGenerateEnumTypes(t *testing.T) {
+	sg := NewSchemaGeneratorFromSource(newMemorySource(enumsTestTable()), &Config{EnumMode: EnumModeTyped})
+	result, err := sg.GenerateEnumTypes(context.Background(), "main")
+	if err != nil {
+		t.Fatalf("GenerateEnumTypes() error: %v", err)
+	}
+
+	expected := []string{
+		"type UsersStatus string",
+		`var UsersStatusAllowed = []string{"active", "inactive", "banned"}`,
+		"func (e UsersStatus) Valid() bool {",
+		"func (UsersStatus) AllValues() []UsersStatus {",
+	}
+	for _, exp := range expected {
+		if !strings.Contains(result, exp) {
+			t.Errorf("GenerateEnumTypes() missing %q in:\n%s", exp, result)
+		}
+	}
+	if strings.Contains(result, "const (") || strings.Contains(result, "Users_Status_Active") {
+		t.Errorf("GenerateEnumTypes() should only emit type definitions:\n%s", result)
+	}
+
+	output := runGenerated(t, map[string]string{"enum_types.go": result}, `package main
+
+import "fmt"
+
+func main() {
+	var s UsersStatus
+	fmt.Println(s.AllValues(), UsersStatus("banned").Valid(), UsersStatus("gone").Valid())
+}
+`)
+	if expected := "[active inactive banned] true false\n"; output != expected {
+		t.Errorf("generated enum types output = %q, expected %q", output, expected)
+	}
+
+	sg = NewSchemaGeneratorFromSource(newMemorySource(enumsTestTable()), nil)
+	if _, err := sg.GenerateEnumTypes(context.Background(), "main"); err == nil {
+		t.Error("GenerateEnumTypes() without typed enum mode expected error, got nil")
+	}
+}
+
+func TestGenerateEnumConstants_Int(t *testing.T) {
+	result := generateTypedEnums(t, &Config{EnumMode: EnumModeInt}, enumsTestTable())
+
+	expected := []string{
+		"type UsersStatus int",
+		"Users_Status_Active UsersStatus = iota // active",
+		"Users_Status_Inactive // inactive",
+		"Users_Status_Banned // banned",
+		"func (e UsersStatus) String() string {",
+		"func (e *UsersStatus) Scan(value any) error {",
+		"func (e UsersStatus) Value() (driver.Value, error) {",
+	}
+	for _, exp := range expected {
+		if !strings.Contains(result, exp) {
+			t.Errorf("GenerateEnumConstants() missing %q in:\n%s", exp, result)
+		}
+	}
+
+	sg := &SchemaGenerator{config: &Config{EnumMode: EnumModeInt}}
+	if goType := sg.mysqlTypeToGoType("enum('active','inactive','banned')", true, false, "users", "status"); goType != "NullUsersStatus" {
+		t.Errorf("mysqlTypeToGoType() for nullable int enum = %q, expected %q", goType, "NullUsersStatus")
+	}
+}
+
+func TestGenerateEnumConstants_IntScanValue(t *testing.T) {
+	result := generateTypedEnums(t, &Config{EnumMode: EnumModeInt}, enumsTestTable())
+
+	output := runGenerated(t, map[string]string{"enum_constants.go": result}, `package main
+
+import "fmt"
+
+func main() {
+	var e UsersStatus
+	fmt.Println(int(Users_Status_Active), int(Users_Status_Banned), Users_Status_Inactive)
+
+	fmt.Println(e.Scan([]byte("banned")), int(e), e)
+	fmt.Println(e.Scan("gone") != nil, e)
+
+	value, err := Users_Status_Inactive.Value()
+	fmt.Println(value, err)
+	_, err = UsersStatus(7).Value()
+	fmt.Println(err, UsersStatus(7))
+
+	var n NullUsersStatus
+	fmt.Println(n.Scan(nil), n.Valid)
+	fmt.Println(n.Scan("active"), n.Valid, n.Enum)
+}
+`)
+
+	expected := "0 2 inactive\n" +
+		"<nil> 2 banned\n" +
+		"true banned\n" +
+		"inactive <nil>\n" +
+		"invalid UsersStatus value 7 UsersStatus(7)\n" +
+		"<nil> false\n" +
+		"<nil> true active\n"
+	if output != expected {
+		t.Errorf("int enum output = %q, expected %q", output, expected)
+	}
+}
+
+func singleValueEnumTable() *TableInfo {
+	sg := &SchemaGenerator{}
+	return &TableInfo{
+		Name: "users",
+		Columns: []ColumnInfo{
+			{Name: "id", Type: "int(11)"},
+			{Name: "kind", Type: "enum('only')", IsEnum: true, EnumValues: sg.parseEnumValues("enum('only')")},
+		},
+		PrimaryKeys: []string{"id"},
+	}
+}
+
+func TestGenerateEnumConstants_SingleValue(t *testing.T) {
+	table := singleValueEnumTable()
+	if values := table.Columns[1].EnumValues; len(values) != 1 || values[0] != "only" {
+		t.Fatalf("parseEnumValues(enum('only')) = %v, expected [only]", values)
+	}
+
+	result := generateTypedEnums(t, nil, table)
+	for _, exp := range []string{`Users_Kind_Only = "only"`, `var UsersKindAllowed = []string{"only"}`} {
+		if !strings.Contains(result, exp) {
+			t.Errorf("GenerateEnumConstants() missing %q in:\n%s", exp, result)
+		}
+	}
+}
+
+func TestGenerateEnumConstants_TypedSingleValue(t *testing.T) {
+	result := generateTypedEnums(t, &Config{EnumMode: EnumModeTyped}, singleValueEnumTable())
+
+	if !strings.Contains(result, `Users_Kind_Only UsersKind = "only"`) {
+		t.Errorf("GenerateEnumConstants() missing the single typed constant in:\n%s", result)
+	}
+
+	output := runGenerated(t, map[string]string{"enum_constants.go": result}, `package main
+
+import "fmt"
+
+func main() {
+	fmt.Println(Users_Kind_Only.Valid(), UsersKind("other").Valid(), Users_Kind_Only.AllValues(), Users_Kind_Only.Index())
+}
+`)
+	if expected := "true false [only] 1\n"; output != expected {
+		t.Errorf("single-value typed enum output = %q, expected %q", output, expected)
+	}
+}
+
+func TestGenerateEnumConstants_TypedNextPrev(t *testing.T) {
+	result := generateTypedEnums(t, &Config{EnumMode: EnumModeTyped}, enumsTestTable())
+
+	output := runGenerated(t, map[string]string{"enum_constants.go": result}, `package main
+
+import "fmt"
+
+func main() {
+	for e, ok := Users_Status_Active, true; ok; e, ok = e.Next() {
+		fmt.Print(e, " ")
+	}
+	fmt.Println()
+	for e, ok := Users_Status_Banned, true; ok; e, ok = e.Prev() {
+		fmt.Print(e, " ")
+	}
+	fmt.Println()
+
+	last, ok := Users_Status_Banned.Next()
+	first, ok2 := Users_Status_Active.Prev()
+	unknown, ok3 := UsersStatus("gone").Next()
+	fmt.Println(last, ok, first, ok2, unknown, ok3)
+}
+`)
+
+	expected := "active inactive banned \n" +
+		"banned inactive active \n" +
+		"banned false active false gone false\n"
+	if output != expected {
+		t.Errorf("Next/Prev output = %q, expected %q", output, expected)
+	}
+}
+
+func TestGenerateEnumConstants_IntStringUnknown(t *testing.T) {
+	result := generateTypedEnums(t, &Config{EnumMode: EnumModeInt}, enumsTestTable())
+	if !strings.Contains(result, "\tcase Users_Status_Banned:\n\t\treturn \"banned\"\n") {
+		t.Errorf("GenerateEnumConstants() String() should switch over the constants:\n%s", result)
+	}
+
+	output := runGenerated(t, map[string]string{"enum_constants.go": result}, `package main
+
+import "fmt"
+
+func main() {
+	fmt.Println(Users_Status_Banned.String(), UsersStatus(3).String(), UsersStatus(-1).String())
+	fmt.Printf("%v %s\n", UsersStatus(42), UsersStatus(0))
+}
+`)
+
+	if expected := "banned UsersStatus(3) UsersStatus(-1)\nUsersStatus(42) active\n"; output != expected {
+		t.Errorf("int enum String() output = %q, expected %q", output, expected)
+	}
+}
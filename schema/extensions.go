@@ -0,0 +1,50 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// ExtensionFileSuffix ends the names of the extension files generated by
+// GenerateExtensionStubs
+const ExtensionFileSuffix = "_ext.go"
+
+// GenerateExtensionStubs generates a stub <table>_ext.go per table for
+// hand-written methods on the generated structs. The stubs carry no
+// generated-code banner because they belong to the application once created:
+// callers only write the stubs whose files do not exist yet.
+func (sg *SchemaGenerator) GenerateExtensionStubs(ctx context.Context, packageName string) (map[string]string, error) {
+	tableInfos, err := sg.loadTables(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	stubs := make(map[string]string)
+	for _, tableInfo := range tableInfos {
+		filename := extensionFileName(tableInfo.Name)
+		if _, exists := stubs[filename]; exists {
+			return nil, fmt.Errorf("tables map to the same extension file %s", filename)
+		}
+
+		var builder strings.Builder
+		builder.WriteString("package " + packageName + "\n\n")
+		builder.WriteString(fmt.Sprintf("// Hand-written methods of %s go here. mariakit created this file because it\n", sg.toStructName(tableInfo.Name)))
+		builder.WriteString("// was missing and never overwrites it.\n")
+		stubs[filename] = builder.String()
+	}
+
+	return stubs, nil
+}
+
+// extensionFileName returns the extension file name of a table, replacing
+// characters that do not belong in file names with underscores
+func extensionFileName(tableName string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return '_'
+	}, tableName) + ExtensionFileSuffix
+}
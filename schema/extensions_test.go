@@ -0,0 +1,35 @@
+package schema
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestGenerateExtensionStubs(t *testing.T) {
+	sg := NewSchemaGeneratorFromSource(newMemorySource(enumsTestTable(), &TableInfo{Name: "order-items"}), nil)
+
+	stubs, err := sg.GenerateExtensionStubs(context.Background(), "models")
+	if err != nil {
+		t.Fatalf("GenerateExtensionStubs() error: %v", err)
+	}
+
+	var names []string
+	for name := range stubs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if !reflect.DeepEqual(names, []string{"order_items_ext.go", "users_ext.go"}) {
+		t.Errorf("GenerateExtensionStubs() files = %v, expected [order_items_ext.go users_ext.go]", names)
+	}
+
+	stub := stubs["users_ext.go"]
+	if !strings.HasPrefix(stub, "package models\n") || !strings.Contains(stub, "Hand-written methods of Users") {
+		t.Errorf("users_ext.go = %q, expected a package clause and a comment naming Users", stub)
+	}
+	if strings.Contains(stub, "Code generated") {
+		t.Errorf("users_ext.go = %q, expected no generated-code banner", stub)
+	}
+}
@@ -0,0 +1,106 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// filterTables returns the tables matching any include pattern (all tables if
+// there are none) and no exclude pattern. Patterns use path.Match syntax. An
+// include entry without glob characters that names no table is an error.
+func filterTables(tables, include, exclude []string) ([]string, error) {
+	if err := validatePatterns(append(append([]string{}, include...), exclude...)); err != nil {
+		return nil, err
+	}
+
+	exists := make(map[string]bool)
+	for _, table := range tables {
+		exists[table] = true
+	}
+	for _, pattern := range include {
+		if !isGlob(pattern) && !exists[pattern] {
+			return nil, fmt.Errorf("table %s does not exist", pattern)
+		}
+	}
+
+	var result []string
+	for _, table := range tables {
+		if len(include) > 0 && !matchTable(include, table) {
+			continue
+		}
+		if matchTable(exclude, table) {
+			continue
+		}
+		result = append(result, table)
+	}
+
+	return result, nil
+}
+
+// GetTablesMatching returns the tables whose names match any of the glob
+// patterns, using the same path.Match syntax as the include and exclude
+// configuration. Unlike include, exact names that match no table are not an
+// error. All tables are returned when there are no patterns.
+func (sg *SchemaGenerator) GetTablesMatching(ctx context.Context, patterns []string) ([]string, error) {
+	if err := validatePatterns(patterns); err != nil {
+		return nil, err
+	}
+
+	tables, err := sg.GetTables(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tables: %w", err)
+	}
+
+	if len(patterns) == 0 {
+		return tables, nil
+	}
+
+	var result []string
+	for _, table := range tables {
+		if matchTable(patterns, table) {
+			result = append(result, table)
+		}
+	}
+
+	return result, nil
+}
+
+// validatePatterns checks that all patterns are valid path.Match patterns
+func validatePatterns(patterns []string) error {
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid table pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// matchTable reports whether the table name matches any of the glob patterns
+func matchTable(patterns []string, tableName string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, tableName); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// matchColumn reports whether the column name matches any of the glob
+// patterns. Patterns use the path.Match syntax of table patterns, but unlike
+// table names, which are case-sensitive on most servers, column names are
+// case-insensitive in MariaDB, so "*_at" also matches Created_AT.
+func matchColumn(patterns []string, columnName string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(strings.ToLower(pattern), strings.ToLower(columnName)); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// isGlob reports whether pattern contains glob metacharacters
+func isGlob(pattern string) bool {
+	return strings.ContainsAny(pattern, `*?[\`)
+}
@@ -0,0 +1,125 @@
+package schema
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestFilterTables(t *testing.T) {
+	tables := []string{"audit_log", "orders", "users", "users_archive"}
+
+	tests := []struct {
+		include  []string
+		exclude  []string
+		expected string
+	}{
+		{nil, nil, "audit_log,orders,users,users_archive"},
+		{[]string{"users"}, nil, "users"},
+		{[]string{"users*"}, nil, "users,users_archive"},
+		{[]string{"users*", "orders"}, []string{"*_archive"}, "orders,users"},
+		{nil, []string{"audit_*"}, "orders,users,users_archive"},
+	}
+
+	for _, test := range tests {
+		result, err := filterTables(tables, test.include, test.exclude)
+		if err != nil {
+			t.Errorf("filterTables(%v, %v) error: %v", test.include, test.exclude, err)
+			continue
+		}
+		if got := strings.Join(result, ","); got != test.expected {
+			t.Errorf("filterTables(%v, %v) = %q, expected %q", test.include, test.exclude, got, test.expected)
+		}
+	}
+}
+
+func TestFilterTables_MissingTable(t *testing.T) {
+	_, err := filterTables([]string{"users"}, []string{"users", "invoices"}, nil)
+	if err == nil || !strings.Contains(err.Error(), "table invoices does not exist") {
+		t.Errorf("filterTables() error = %v, expected missing table error", err)
+	}
+
+	// Patterns that match nothing are not an error
+	if _, err := filterTables([]string{"users"}, []string{"invoice*"}, nil); err != nil {
+		t.Errorf("filterTables() with unmatched glob unexpected error: %v", err)
+	}
+
+	if _, err := filterTables([]string{"users"}, []string{"[users"}, nil); err == nil {
+		t.Error("filterTables() with malformed pattern expected error, got nil")
+	}
+}
+
+func TestGenerateStructs_Include(t *testing.T) {
+	source := newMemorySource(
+		&TableInfo{Name: "users", Columns: []ColumnInfo{{Name: "id", Type: "int(11)"}}},
+		&TableInfo{Name: "orders", Columns: []ColumnInfo{{Name: "id", Type: "int(11)"}}},
+	)
+	sg := NewSchemaGeneratorFromSource(source, &Config{Include: []string{"users"}})
+
+	result, err := sg.GenerateStructs(context.Background(), "models")
+	if err != nil {
+		t.Fatalf("GenerateStructs() error: %v", err)
+	}
+	if !strings.Contains(result, "type Users struct") || strings.Contains(result, "type Orders struct") {
+		t.Errorf("GenerateStructs() should only contain the included table:\n%s", result)
+	}
+}
+
+func TestGetTablesMatching(t *testing.T) {
+	var tables []*TableInfo
+	for _, name := range []string{"audit_log", "orders", "users", "users_archive"} {
+		tables = append(tables, &TableInfo{Name: name})
+	}
+	sg := NewSchemaGeneratorFromSource(newMemorySource(tables...), nil)
+
+	tests := []struct {
+		patterns []string
+		expected string
+	}{
+		{nil, "audit_log,orders,users,users_archive"},
+		{[]string{"users"}, "users"},
+		{[]string{"users*"}, "users,users_archive"},
+		{[]string{"*_log", "orders"}, "audit_log,orders"},
+		{[]string{"invoices"}, ""},
+	}
+
+	for _, test := range tests {
+		result, err := sg.GetTablesMatching(context.Background(), test.patterns)
+		if err != nil {
+			t.Errorf("GetTablesMatching(%v) error: %v", test.patterns, err)
+			continue
+		}
+		if got := strings.Join(result, ","); got != test.expected {
+			t.Errorf("GetTablesMatching(%v) = %q, expected %q", test.patterns, got, test.expected)
+		}
+	}
+
+	if _, err := sg.GetTablesMatching(context.Background(), []string{"[users"}); err == nil {
+		t.Error("GetTablesMatching() with malformed pattern expected error, got nil")
+	}
+}
+
+func TestMatchColumn(t *testing.T) {
+	tests := []struct {
+		patterns []string
+		column   string
+		expected bool
+	}{
+		{[]string{"*_at"}, "created_at", true},
+		{[]string{"*_at"}, "Created_AT", true},
+		{[]string{"*_AT"}, "created_at", true},
+		{[]string{"*_at"}, "status", false},
+		{nil, "created_at", false},
+	}
+
+	for _, test := range tests {
+		if result := matchColumn(test.patterns, test.column); result != test.expected {
+			t.Errorf("matchColumn(%q, %q) = %t, expected %t", test.patterns, test.column, result, test.expected)
+		}
+	}
+
+	// Table names keep matching case-sensitively
+	if matchTable([]string{"users"}, "Users") {
+		t.Error("matchTable() matched a table name of different case")
+	}
+}
@@ -0,0 +1,112 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+)
+
+// GenerateOptions configures a one-shot Generate call
+type GenerateOptions struct {
+	// DSN is the MariaDB connection string. It is ignored when Source is set.
+	DSN string
+	// Source provides schema metadata instead of a database connection
+	Source Source
+	// Config holds optional custom mappings
+	Config *Config
+	// TypeMapper replaces the default column to Go type mapping when set
+	TypeMapper TypeMapper
+	// PackageName is the package clause of the generated files
+	PackageName string
+	// Types selects what to generate: constants, structs, types, columntypes
+	// (or its alias columnnames), queries, enums, metadata and schemainfo.
+	// All types are generated when empty.
+	Types []string
+}
+
+// generateFunc is the signature shared by the per-type generator methods
+type generateFunc func(sg *SchemaGenerator, ctx context.Context, packageName string) (string, error)
+
+// generateTypes maps generation type names to their output file and generator
+var generateTypes = map[string]struct {
+	filename string
+	generate generateFunc
+}{
+	"constants":   {"column_constants.go", (*SchemaGenerator).GenerateColumnConstants},
+	"structs":     {"structs.go", (*SchemaGenerator).GenerateStructs},
+	"types":       {"column_types.go", (*SchemaGenerator).GenerateColumnTypes},
+	"columntypes": {"column_names.go", (*SchemaGenerator).GenerateColumnNameTypes},
+	"queries":     {"queries.go", (*SchemaGenerator).GenerateQueries},
+	"enums":       {"enum_constants.go", (*SchemaGenerator).GenerateEnumConstants},
+	"metadata":    {"metadata.go", (*SchemaGenerator).GenerateMetadata},
+	"schemainfo":  {"schema_info.go", (*SchemaGenerator).GenerateSchemaInfo},
+}
+
+// generateTypeAliases maps alternative generation type names to their name in
+// generateTypes. "columnnames" matches the column_names.go file that
+// "columntypes" writes, which is easily confused with the column_types.go
+// file of "types".
+var generateTypeAliases = map[string]string{
+	"columnnames": "columntypes",
+}
+
+// canonicalGenerateType resolves a generation type alias to its name in
+// generateTypes
+func canonicalGenerateType(name string) string {
+	if canonical, exists := generateTypeAliases[name]; exists {
+		return canonical
+	}
+	return name
+}
+
+// Generate creates a generator from opts, generates the requested code and
+// closes the generator again. The result maps file names to their content.
+func Generate(ctx context.Context, opts GenerateOptions) (map[string]string, error) {
+	if opts.PackageName == "" {
+		return nil, fmt.Errorf("package name is required")
+	}
+
+	var sg *SchemaGenerator
+	if opts.Source != nil {
+		sg = NewSchemaGeneratorFromSource(opts.Source, opts.Config)
+	} else {
+		if opts.DSN == "" {
+			return nil, fmt.Errorf("either a DSN or a source is required")
+		}
+		var err error
+		sg, err = NewSchemaGeneratorWithConfigContext(ctx, opts.DSN, opts.Config)
+		if err != nil {
+			return nil, err
+		}
+	}
+	defer sg.Close()
+
+	if opts.TypeMapper != nil {
+		sg.SetTypeMapper(opts.TypeMapper)
+	}
+
+	if len(opts.Types) == 0 {
+		return sg.GenerateAll(ctx, opts.PackageName)
+	}
+
+	defer sg.enableCache()()
+
+	files := make(map[string]string)
+	for _, name := range opts.Types {
+		generateType, exists := generateTypes[canonicalGenerateType(name)]
+		if !exists {
+			return nil, fmt.Errorf("unknown generation type: %s", name)
+		}
+
+		content, err := generateType.generate(sg, ctx, opts.PackageName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate %s: %w", name, err)
+		}
+		files[generateType.filename] = content
+	}
+
+	if err := checkDeclarationCollisions(files); err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
@@ -0,0 +1,282 @@
+package schema
+
+import (
+	"context"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestGenerate(t *testing.T) {
+	source := newMemorySource(&TableInfo{
+		Name: "users",
+		Columns: []ColumnInfo{
+			{Name: "id", Type: "int(11)"},
+			{Name: "status", Type: "enum('active','inactive')", IsEnum: true, EnumValues: []string{"active", "inactive"}},
+		},
+		PrimaryKeys: []string{"id"},
+	})
+
+	files, err := Generate(context.Background(), GenerateOptions{
+		Source:      source,
+		PackageName: "models",
+		Types:       []string{"structs", "enums"},
+	})
+	if err != nil {
+		t.Fatalf("Generate() error: %v", err)
+	}
+
+	if len(files) != 2 {
+		t.Errorf("Generate() returned %d files, expected 2", len(files))
+	}
+	if !strings.Contains(files["structs.go"], "package models") || !strings.Contains(files["structs.go"], "type Users struct") {
+		t.Errorf("Generate() structs.go content unexpected:\n%s", files["structs.go"])
+	}
+	if !strings.Contains(files["enum_constants.go"], `Users_Status_Active = "active"`) {
+		t.Errorf("Generate() enum_constants.go content unexpected:\n%s", files["enum_constants.go"])
+	}
+
+	all, err := Generate(context.Background(), GenerateOptions{Source: source, PackageName: "models"})
+	if err != nil {
+		t.Fatalf("Generate() for all types error: %v", err)
+	}
+	// schema_info.go is opt-in
+	if len(all) != len(generateTypes)-1 {
+		t.Errorf("Generate() for all types returned %d files, expected %d", len(all), len(generateTypes)-1)
+	}
+	all, err = Generate(context.Background(), GenerateOptions{Source: source, PackageName: "models", Config: &Config{GenerateSchemaInfo: true}})
+	if err != nil {
+		t.Fatalf("Generate() for all types with schema info error: %v", err)
+	}
+	if len(all) != len(generateTypes) {
+		t.Errorf("Generate() for all types with schema info returned %d files, expected %d", len(all), len(generateTypes))
+	}
+
+	// "types" writes the column type aliases and "columnnames" is an alias of
+	// "columntypes" for the typed column names
+	named, err := Generate(context.Background(), GenerateOptions{Source: source, PackageName: "models", Types: []string{"types", "columnnames"}})
+	if err != nil {
+		t.Fatalf("Generate() for types and columnnames error: %v", err)
+	}
+	if len(named) != 2 || !strings.Contains(named["column_types.go"], "type Users_Id = int32") || !strings.Contains(named["column_names.go"], "type UsersColumn string") {
+		t.Errorf("Generate() for types and columnnames returned unexpected files: %v", named)
+	}
+	aliased, err := Generate(context.Background(), GenerateOptions{Source: source, PackageName: "models", Types: []string{"columntypes"}})
+	if err != nil {
+		t.Fatalf("Generate() for columntypes error: %v", err)
+	}
+	if aliased["column_names.go"] != named["column_names.go"] {
+		t.Errorf("Generate() for columnnames differs from columntypes:\n%s\n%s", named["column_names.go"], aliased["column_names.go"])
+	}
+
+	if _, err := Generate(context.Background(), GenerateOptions{Source: source, PackageName: "models", Types: []string{"bogus"}}); err == nil {
+		t.Error("Generate() with unknown type expected error, got nil")
+	}
+	if _, err := Generate(context.Background(), GenerateOptions{PackageName: "models"}); err == nil {
+		t.Error("Generate() without DSN or source expected error, got nil")
+	}
+}
+
+func TestGenerateAll_Banner(t *testing.T) {
+	// The convention from https://go.dev/s/generatedcode
+	generatedRegexp := regexp.MustCompile(`(?m)^// Code generated .* DO NOT EDIT\.$`)
+
+	tests := []struct {
+		config   *Config
+		expected string
+	}{
+		{nil, "// Code generated by mariakit; DO NOT EDIT.\n"},
+		{&Config{ToolName: "acme-gen"}, "// Code generated by acme-gen; DO NOT EDIT.\n"},
+	}
+
+	for _, test := range tests {
+		sg := NewSchemaGeneratorFromSource(newMemorySource(enumsTestTable()), test.config)
+		files, err := sg.GenerateAll(context.Background(), "models")
+		if err != nil {
+			t.Fatalf("GenerateAll() error: %v", err)
+		}
+
+		for name, content := range files {
+			if !strings.HasPrefix(content, test.expected) {
+				t.Errorf("%s starts with %q, expected %q", name, strings.SplitN(content, "\n", 2)[0], test.expected)
+			}
+			if !generatedRegexp.MatchString(content) {
+				t.Errorf("%s banner does not match %s", name, generatedRegexp)
+			}
+
+			file, err := parser.ParseFile(token.NewFileSet(), name, content, parser.ParseComments|parser.PackageClauseOnly)
+			if err != nil {
+				t.Fatalf("failed to parse %s: %v", name, err)
+			}
+			if !ast.IsGenerated(file) {
+				t.Errorf("ast.IsGenerated(%s) = false, expected true", name)
+			}
+		}
+	}
+
+	if err := (&Config{ToolName: "acme\ngen"}).Validate(); err == nil {
+		t.Error("Validate() with a multi-line tool name expected error, got nil")
+	}
+}
+
+func TestGenerateAll_DeclarationCollision(t *testing.T) {
+	// The column constant of status is Users_Status_Name, and so is the
+	// enum constant of its "name" value
+	table := &TableInfo{
+		Name: "users",
+		Columns: []ColumnInfo{
+			{Name: "id", Type: "int(11)"},
+			{Name: "status", Type: "enum('name','email')", IsEnum: true, EnumValues: []string{"name", "email"}},
+		},
+		PrimaryKeys: []string{"id"},
+	}
+
+	sg := NewSchemaGeneratorFromSource(newMemorySource(table), nil)
+	_, err := sg.GenerateAll(context.Background(), "models")
+	if err == nil || !strings.Contains(err.Error(), "Users_Status_Name is declared in both column_constants.go and enum_constants.go") {
+		t.Errorf("GenerateAll() error = %v, expected a Users_Status_Name collision", err)
+	}
+
+	_, err = Generate(context.Background(), GenerateOptions{
+		Source:      newMemorySource(table),
+		PackageName: "models",
+		Types:       []string{"constants", "enums"},
+	})
+	if err == nil {
+		t.Error("Generate() of constants and enums expected a collision error, got nil")
+	}
+
+	// Either file alone compiles
+	if _, err := Generate(context.Background(), GenerateOptions{Source: newMemorySource(table), PackageName: "models", Types: []string{"enums"}}); err != nil {
+		t.Errorf("Generate() of enums only error: %v", err)
+	}
+}
+
+func TestGenerateAll_DeclarationCollisionsListed(t *testing.T) {
+	// Both enum columns have a "name" value whose constant collides with the
+	// column constant of status and role
+	users := &TableInfo{
+		Name: "users",
+		Columns: []ColumnInfo{
+			{Name: "id", Type: "int(11)"},
+			{Name: "status", Type: "enum('name','email')", IsEnum: true, EnumValues: []string{"name", "email"}},
+			{Name: "role", Type: "enum('admin','name')", IsEnum: true, EnumValues: []string{"admin", "name"}},
+		},
+		PrimaryKeys: []string{"id"},
+	}
+
+	sg := NewSchemaGeneratorFromSource(newMemorySource(users), nil)
+	_, err := sg.GenerateAll(context.Background(), "models")
+	if err == nil {
+		t.Fatal("GenerateAll() expected collision error, got nil")
+	}
+
+	expected := "Users_Role_Name is declared in both column_constants.go and enum_constants.go\n" +
+		"Users_Status_Name is declared in both column_constants.go and enum_constants.go"
+	if !strings.Contains(err.Error(), expected) {
+		t.Errorf("GenerateAll() error = %q, expected both collisions:\n%s", err, expected)
+	}
+}
+
+func TestGenerateStructs_MethodFieldCollision(t *testing.T) {
+	table := &TableInfo{
+		Name: "users",
+		Columns: []ColumnInfo{
+			{Name: "id", Type: "int(11)"},
+			{Name: "scan_row", Type: "varchar(255)"},
+			{Name: "without_p_k", Type: "varchar(255)"},
+			{Name: "exists_s_q_l", Type: "varchar(255)"},
+			{Name: "string", Type: "varchar(255)"},
+		},
+		PrimaryKeys: []string{"id"},
+	}
+	config := &Config{GenerateStringers: true}
+
+	sg := NewSchemaGeneratorFromSource(newMemorySource(table), config)
+	_, err := sg.GenerateStructs(context.Background(), "models")
+	if err == nil {
+		t.Fatal("GenerateStructs() expected method collision error, got nil")
+	}
+	expected := "table users: method ScanRow collides with field ScanRow of Users, rename the field with field_renames\n" +
+		"table users: method WithoutPK collides with field WithoutPK of Users, rename the field with field_renames\n" +
+		"table users: method ExistsSQL collides with field ExistsSQL of Users, rename the field with field_renames\n" +
+		"table users: method String collides with field String of Users, rename the field with field_renames"
+	if err.Error() != expected {
+		t.Errorf("GenerateStructs() error = %q, expected:\n%s", err, expected)
+	}
+
+	// Renamed fields leave the method names free
+	config.FieldRenames = map[string]string{
+		"users.scan_row":     "ScanRowValue",
+		"users.without_p_k":  "WithoutPKValue",
+		"users.exists_s_q_l": "ExistsSQLValue",
+		"users.string":       "StringValue",
+	}
+	sg = NewSchemaGeneratorFromSource(newMemorySource(table), config)
+	result, err := sg.GenerateStructs(context.Background(), "main")
+	if err != nil {
+		t.Fatalf("GenerateStructs() with field renames error: %v", err)
+	}
+	runGenerated(t, map[string]string{"structs.go": result}, `package main
+
+import "fmt"
+
+func main() {
+	fmt.Println(Users{StringValue: "s"}.String() != "")
+}
+`)
+}
+
+func TestGenerateAll_LintDirective(t *testing.T) {
+	tests := []struct {
+		config   *Config
+		expected string
+	}{
+		{&Config{LintDirective: LintDirectiveNolint}, "//nolint:all"},
+		{&Config{LintDirective: LintDirectiveStaticcheck}, "//lint:file-ignore U1000,ST1003 generated by mariakit"},
+		{&Config{LintDirective: LintDirectiveNolint, GoGenerate: "mariakit -output=."}, "//nolint:all"},
+		{&Config{LintDirective: LintDirectiveNolint, SingleFile: true, GoGenerate: "mariakit -output=."}, "//nolint:all"},
+	}
+
+	for _, test := range tests {
+		sg := NewSchemaGeneratorFromSource(newMemorySource(enumsTestTable()), test.config)
+		files, err := sg.GenerateAll(context.Background(), "models")
+		if err != nil {
+			t.Fatalf("GenerateAll() error: %v", err)
+		}
+
+		for name, content := range files {
+			formatted, err := format.Source([]byte(content))
+			if err != nil {
+				t.Fatalf("format.Source(%s) error: %v", name, err)
+			}
+
+			// The directive must be the line right before the package clause
+			lines := strings.Split(string(formatted), "\n")
+			for i, line := range lines {
+				if strings.HasPrefix(line, "package ") {
+					if i == 0 || lines[i-1] != test.expected {
+						t.Errorf("%s line before the package clause = %q, expected %q", name, lines[max(i-1, 0)], test.expected)
+					}
+					break
+				}
+			}
+
+			file, err := parser.ParseFile(token.NewFileSet(), name, formatted, parser.ParseComments|parser.PackageClauseOnly)
+			if err != nil {
+				t.Fatalf("failed to parse %s: %v", name, err)
+			}
+			if !ast.IsGenerated(file) {
+				t.Errorf("ast.IsGenerated(%s) = false with a lint directive, expected true", name)
+			}
+		}
+	}
+
+	if err := (&Config{LintDirective: "eslint"}).Validate(); err == nil {
+		t.Error("Validate() with an unknown lint directive expected error, got nil")
+	}
+}
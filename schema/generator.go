@@ -4,22 +4,63 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
 	"sort"
+	"strconv"
 	"strings"
 	"time"
+	"unicode"
 
 	_ "github.com/go-sql-driver/mysql"
 )
 
 // SchemaGenerator generates Go code from MariaDB schema
 type SchemaGenerator struct {
-	db     *sql.DB
-	config *Config
+	db          *sql.DB
+	ownsDB      bool // ownsDB is false for pools passed in by the caller
+	config      *Config
+	source      Source
+	tableErrors []TableError
+	warnings    []string
+	typeMapper  TypeMapper
+	cache       *schemaCache
+	inspectHook InspectHook
+	version     *ServerVersion    // nil until queried
+	enumPackage *GeneratedPackage // qualifies enum types defined in another package
 }
 
+// Source provides schema metadata to the generator. When a generator is
+// created with a Source, it is used instead of querying the database.
+type Source interface {
+	GetTables(ctx context.Context) ([]string, error)
+	GetTableInfo(ctx context.Context, tableName string) (*TableInfo, error)
+	GetAllEnums(ctx context.Context) ([]EnumInfo, error)
+}
+
+// TableError records a table that failed inspection and was skipped
+type TableError struct {
+	Table string
+	Err   error
+}
+
+func (e TableError) Error() string {
+	return fmt.Sprintf("table %s: %v", e.Table, e.Err)
+}
+
+func (e TableError) Unwrap() error {
+	return e.Err
+}
+
+// openDB opens the connection pools of the constructors. Tests replace it to
+// observe the DSNs a constructor opens.
+var openDB = sql.Open
+
 // NewSchemaGenerator creates a new schema generator
 func NewSchemaGenerator(connectionString string) (*SchemaGenerator, error) {
-	db, err := sql.Open("mysql", connectionString)
+	db, err := openDB("mysql", connectionString)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create connector: %w", err)
 	}
@@ -28,26 +69,72 @@ func NewSchemaGenerator(connectionString string) (*SchemaGenerator, error) {
 		return nil, fmt.Errorf("cannot ping database: %w", err)
 	}
 
-	return &SchemaGenerator{db: db}, nil
+	sg := &SchemaGenerator{db: db, ownsDB: true}
+	sg.detectServerVersion(context.Background())
+	return sg, nil
 }
 
 // NewSchemaGeneratorWithConfig creates a new schema generator with custom configuration
 func NewSchemaGeneratorWithConfig(connectionString string, config *Config) (*SchemaGenerator, error) {
-	db, err := sql.Open("mysql", connectionString)
+	return NewSchemaGeneratorWithConfigContext(context.Background(), connectionString, config)
+}
+
+// NewSchemaGeneratorWithConfigContext creates a new schema generator with custom
+// configuration. ctx bounds connecting to the database.
+func NewSchemaGeneratorWithConfigContext(ctx context.Context, connectionString string, config *Config) (*SchemaGenerator, error) {
+	dsn, err := prepareDSN(connectionString, config)
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := openDB("mysql", dsn)
 	if err != nil {
 		return nil, fmt.Errorf("cannot create connector: %w", err)
 	}
 
-	if err := db.Ping(); err != nil {
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
 		return nil, fmt.Errorf("cannot ping database: %w", err)
 	}
 
-	return &SchemaGenerator{db: db, config: config}, nil
+	sg := &SchemaGenerator{db: db, ownsDB: true, config: config}
+	version := sg.detectServerVersion(ctx)
+
+	if config != nil && config.ReadOnly {
+		// The variable depends on the server version, so the pool is
+		// reopened with it once the version is known
+		db.Close()
+
+		dsn, err = readOnlyDSN(dsn, version)
+		if err != nil {
+			return nil, err
+		}
+		if sg.db, err = openDB("mysql", dsn); err != nil {
+			return nil, fmt.Errorf("cannot create connector: %w", err)
+		}
+		if err := sg.db.PingContext(ctx); err != nil {
+			sg.db.Close()
+			return nil, fmt.Errorf("cannot start read-only session: %w", err)
+		}
+	}
+	return sg, nil
+}
+
+// NewSchemaGeneratorFromDB creates a new schema generator that reuses an existing
+// connection pool. The pool stays owned by the caller, so Close does not close it,
+// and the Schema option is ignored because the pool's database is inspected.
+func NewSchemaGeneratorFromDB(db *sql.DB, config *Config) *SchemaGenerator {
+	return &SchemaGenerator{db: db, config: config}
 }
 
-// Close closes the database connection
+// NewSchemaGeneratorFromSource creates a new schema generator that reads schema metadata from source
+func NewSchemaGeneratorFromSource(source Source, config *Config) *SchemaGenerator {
+	return &SchemaGenerator{source: source, config: config}
+}
+
+// Close closes the database connection unless it was passed to NewSchemaGeneratorFromDB
 func (sg *SchemaGenerator) Close() error {
-	if sg.db != nil {
+	if sg.db != nil && sg.ownsDB {
 		return sg.db.Close()
 	}
 	return nil
@@ -58,21 +145,73 @@ type TableInfo struct {
 	Name        string
 	Columns     []ColumnInfo
 	PrimaryKeys []string
+	Indexes     []IndexInfo
+	IsVersioned bool // created WITH SYSTEM VERSIONING
+}
+
+// IndexInfo represents an index of a database table
+type IndexInfo struct {
+	Name    string
+	Columns []string // In index order
+	Unique  bool
+	Type    string // BTREE, HASH, FULLTEXT or SPATIAL
+}
+
+// IsSpatial returns true if the index is a SPATIAL index
+func (i IndexInfo) IsSpatial() bool {
+	return strings.EqualFold(i.Type, "SPATIAL")
+}
+
+// HasSpatialIndex returns true if the column is covered by a SPATIAL index
+func (t *TableInfo) HasSpatialIndex(columnName string) bool {
+	for _, index := range t.Indexes {
+		if !index.IsSpatial() {
+			continue
+		}
+		for _, col := range index.Columns {
+			if col == columnName {
+				return true
+			}
+		}
+	}
+	return false
 }
 
 // ColumnInfo represents information about a database column
 type ColumnInfo struct {
 	Name                 string
+	Table                string // name of the table the column belongs to
 	Type                 string
 	Nullable             bool
 	DefaultValue         sql.NullString
 	Comment              sql.NullString
 	IsEnum               bool
-	EnumValues           []string
+	IsSet                bool     // SET column, whose members are held in EnumValues
+	EnumValues           []string // enum values or set members in declaration order
 	IsJSON               bool
 	IsGenerated          bool
+	AutoIncrement        bool
+	AutoUpdate           bool           // ON UPDATE CURRENT_TIMESTAMP
+	Invisible            bool           // INVISIBLE or system-versioning period column
 	GenerationType       sql.NullString // VIRTUAL or STORED
 	GenerationExpression sql.NullString
+	MaxLength            sql.NullInt64 // CHARACTER_MAXIMUM_LENGTH of string columns
+	VectorDimension      int           // declared dimension of VECTOR columns, 0 if unknown
+}
+
+// SetMemberBit returns the bit MariaDB uses for value in the numeric
+// representation of a SET column: 1 for the first member, 2 for the second,
+// 4 for the third and so on. It returns 0 if value is not a member.
+func (c ColumnInfo) SetMemberBit(value string) int {
+	if !c.IsSet {
+		return 0
+	}
+	for i, member := range c.EnumValues {
+		if member == value {
+			return 1 << i
+		}
+	}
+	return 0
 }
 
 // EnumInfo represents information about an enum type
@@ -82,17 +221,30 @@ type EnumInfo struct {
 	Values     []string
 }
 
-// GetTables retrieves all table names from the database
+// GetTables retrieves the names of all tables of the configured table types,
+// base tables by default
 func (sg *SchemaGenerator) GetTables(ctx context.Context) ([]string, error) {
+	if sg.source != nil {
+		return sg.source.GetTables(ctx)
+	}
+
+	tableTypes := sg.config.tableTypes()
+	placeholders := make([]string, len(tableTypes))
+	args := make([]any, len(tableTypes))
+	for i, tableType := range tableTypes {
+		placeholders[i] = "?"
+		args[i] = tableType
+	}
+
 	query := `
 		SELECT TABLE_NAME
 		FROM information_schema.TABLES
 		WHERE TABLE_SCHEMA = DATABASE()
-		AND TABLE_TYPE = 'BASE TABLE'
+		AND TABLE_TYPE IN (` + strings.Join(placeholders, ", ") + `)
 		ORDER BY TABLE_NAME
 	`
 
-	rows, err := sg.db.QueryContext(ctx, query)
+	rows, err := sg.db.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query tables: %w", err)
 	}
@@ -112,6 +264,12 @@ func (sg *SchemaGenerator) GetTables(ctx context.Context) ([]string, error) {
 
 // GetTableInfo retrieves detailed information about a table
 func (sg *SchemaGenerator) GetTableInfo(ctx context.Context, tableName string) (*TableInfo, error) {
+	if sg.source != nil {
+		return sg.source.GetTableInfo(ctx, tableName)
+	}
+
+	version := sg.detectServerVersion(ctx)
+
 	// Get column information
 	columnsQuery := `
 		SELECT
@@ -122,7 +280,8 @@ func (sg *SchemaGenerator) GetTableInfo(ctx context.Context, tableName string) (
 			COLUMN_COMMENT,
 			COALESCE(IS_GENERATED, 'NO') as IS_GENERATED,
 			GENERATION_EXPRESSION,
-			EXTRA
+			EXTRA,
+			CHARACTER_MAXIMUM_LENGTH
 		FROM information_schema.COLUMNS
 		WHERE TABLE_SCHEMA = DATABASE()
 		AND TABLE_NAME = ?
@@ -139,22 +298,13 @@ func (sg *SchemaGenerator) GetTableInfo(ctx context.Context, tableName string) (
 	for rows.Next() {
 		var col ColumnInfo
 		var nullable, isGenerated, extra string
-		if err := rows.Scan(&col.Name, &col.Type, &nullable, &col.DefaultValue, &col.Comment, &isGenerated, &col.GenerationExpression, &extra); err != nil {
+		if err := rows.Scan(&col.Name, &col.Type, &nullable, &col.DefaultValue, &col.Comment, &isGenerated, &col.GenerationExpression, &extra, &col.MaxLength); err != nil {
 			return nil, fmt.Errorf("failed to scan column info: %w", err)
 		}
+		col.Table = tableName
 		col.Nullable = nullable == "YES"
 		col.IsGenerated = isGenerated == "YES"
-		
-		// Extract generation type from EXTRA field
-		if col.IsGenerated {
-			if strings.Contains(strings.ToLower(extra), "virtual") {
-				col.GenerationType.String = "VIRTUAL"
-				col.GenerationType.Valid = true
-			} else if strings.Contains(strings.ToLower(extra), "stored") {
-				col.GenerationType.String = "STORED"
-				col.GenerationType.Valid = true
-			}
-		}
+		sg.parseExtra(&col, extra)
 
 		// Check if this is an enum column
 		if strings.HasPrefix(col.Type, "enum(") {
@@ -162,8 +312,17 @@ func (sg *SchemaGenerator) GetTableInfo(ctx context.Context, tableName string) (
 			col.EnumValues = sg.parseEnumValues(col.Type)
 		}
 
-		// Check if this is a JSON column (LONGTEXT with json_valid() constraint)
-		if strings.ToLower(col.Type) == "longtext" {
+		// Check if this is a set column
+		if strings.HasPrefix(col.Type, "set(") {
+			col.IsSet = true
+			col.EnumValues = sg.parseEnumValues(col.Type)
+		}
+
+		col.VectorDimension = vectorDimension(col.Type)
+
+		// Check if this is a JSON column (a TEXT type with json_valid() constraint).
+		// Servers without information_schema.CHECK_CONSTRAINTS cannot report one.
+		if isTextType(col.Type) && version.SupportsCheckConstraints() {
 			isJSON, err := sg.checkJSONConstraint(ctx, tableName, col.Name)
 			if err != nil {
 				return nil, fmt.Errorf("failed to check JSON constraint for column %s: %w", col.Name, err)
@@ -203,15 +362,117 @@ func (sg *SchemaGenerator) GetTableInfo(ctx context.Context, tableName string) (
 		primaryKeys = append(primaryKeys, pk)
 	}
 
+	indexes, err := sg.getIndexes(ctx, tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	var isVersioned bool
+	if version.SupportsSystemVersioning() {
+		isVersioned, err = sg.isSystemVersioned(ctx, tableName)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return &TableInfo{
 		Name:        tableName,
 		Columns:     columns,
 		PrimaryKeys: primaryKeys,
+		Indexes:     indexes,
+		IsVersioned: isVersioned,
 	}, nil
 }
 
+// isSystemVersioned reports whether a table was created WITH SYSTEM VERSIONING
+func (sg *SchemaGenerator) isSystemVersioned(ctx context.Context, tableName string) (bool, error) {
+	query := `
+		SELECT TABLE_TYPE
+		FROM information_schema.TABLES
+		WHERE TABLE_SCHEMA = DATABASE()
+		AND TABLE_NAME = ?
+	`
+
+	var tableType string
+	err := sg.db.QueryRowContext(ctx, query, tableName).Scan(&tableType)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to query table type for table %s: %w", tableName, err)
+	}
+
+	return tableType == "SYSTEM VERSIONED", nil
+}
+
+// indexColumn is a single row of information_schema.STATISTICS
+type indexColumn struct {
+	IndexName  string
+	ColumnName string
+	NonUnique  bool
+	IndexType  string
+}
+
+// getIndexes retrieves the indexes of a table with their columns in index order
+func (sg *SchemaGenerator) getIndexes(ctx context.Context, tableName string) ([]IndexInfo, error) {
+	query := `
+		SELECT
+			INDEX_NAME,
+			COLUMN_NAME,
+			NON_UNIQUE,
+			INDEX_TYPE
+		FROM information_schema.STATISTICS
+		WHERE TABLE_SCHEMA = DATABASE()
+		AND TABLE_NAME = ?
+		ORDER BY INDEX_NAME, SEQ_IN_INDEX
+	`
+
+	rows, err := sg.db.QueryContext(ctx, query, tableName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query indexes for table %s: %w", tableName, err)
+	}
+	defer rows.Close()
+
+	var indexColumns []indexColumn
+	for rows.Next() {
+		var ic indexColumn
+		if err := rows.Scan(&ic.IndexName, &ic.ColumnName, &ic.NonUnique, &ic.IndexType); err != nil {
+			return nil, fmt.Errorf("failed to scan index info: %w", err)
+		}
+		indexColumns = append(indexColumns, ic)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating indexes: %w", err)
+	}
+
+	return groupIndexColumns(indexColumns), nil
+}
+
+// groupIndexColumns folds STATISTICS rows, ordered by index name and sequence, into indexes
+func groupIndexColumns(indexColumns []indexColumn) []IndexInfo {
+	var indexes []IndexInfo
+	for _, ic := range indexColumns {
+		if n := len(indexes); n > 0 && indexes[n-1].Name == ic.IndexName {
+			indexes[n-1].Columns = append(indexes[n-1].Columns, ic.ColumnName)
+			continue
+		}
+		indexes = append(indexes, IndexInfo{
+			Name:    ic.IndexName,
+			Columns: []string{ic.ColumnName},
+			Unique:  !ic.NonUnique,
+			Type:    ic.IndexType,
+		})
+	}
+	return indexes
+}
+
 // GetAllEnums retrieves all enum columns from all tables
 func (sg *SchemaGenerator) GetAllEnums(ctx context.Context) ([]EnumInfo, error) {
+	if sg.source != nil {
+		return sg.source.GetAllEnums(ctx)
+	}
+
 	query := `
 		SELECT
 			TABLE_NAME,
@@ -243,32 +504,211 @@ func (sg *SchemaGenerator) GetAllEnums(ctx context.Context) ([]EnumInfo, error)
 	return enums, rows.Err()
 }
 
-// parseEnumValues extracts enum values from MariaDB enum type string
+// TableErrors returns the tables that failed inspection and were skipped
+// because ContinueOnError is enabled
+func (sg *SchemaGenerator) TableErrors() []TableError {
+	return sg.tableErrors
+}
+
+// Warnings returns non-fatal problems found during generation, such as
+// enum values that had to be renamed to keep constant names unique
+func (sg *SchemaGenerator) Warnings() []string {
+	return sg.warnings
+}
+
+// warn records a warning once, even if several generators hit it
+func (sg *SchemaGenerator) warn(format string, args ...any) {
+	message := fmt.Sprintf(format, args...)
+	for _, warning := range sg.warnings {
+		if warning == message {
+			return
+		}
+	}
+	sg.warnings = append(sg.warnings, message)
+}
+
+// loadTables retrieves information about all tables. When ContinueOnError is
+// enabled, tables that fail inspection are recorded and skipped.
+func (sg *SchemaGenerator) loadTables(ctx context.Context) ([]*TableInfo, error) {
+	tables, err := sg.selectedTables(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var tableInfos []*TableInfo
+	for _, tableName := range tables {
+		tableInfo, err := sg.cachedTableInfo(ctx, tableName)
+		if err != nil {
+			if sg.config != nil && sg.config.ContinueOnError {
+				sg.recordTableError(tableName, err)
+				continue
+			}
+			return nil, fmt.Errorf("failed to get table info for %s: %w", tableName, err)
+		}
+		tableInfos = append(tableInfos, sg.withNullableOverrides(sg.withoutSkippedColumns(tableInfo)))
+	}
+
+	return tableInfos, nil
+}
+
+// withoutSkippedColumns returns the table without the invisible columns that
+// are excluded from generation. The original table info is not modified.
+func (sg *SchemaGenerator) withoutSkippedColumns(tableInfo *TableInfo) *TableInfo {
+	if !sg.config.skipInvisible() {
+		return tableInfo
+	}
+
+	var columns []ColumnInfo
+	for _, col := range tableInfo.Columns {
+		if !col.Invisible {
+			columns = append(columns, col)
+		}
+	}
+	if len(columns) == len(tableInfo.Columns) {
+		return tableInfo
+	}
+
+	filtered := *tableInfo
+	filtered.Columns = columns
+	return &filtered
+}
+
+// withNullableOverrides returns the table with the nullability of columns
+// listed in Config.NullableOverrides replaced, so the Go types and everything
+// else derived from nullability follow the override. The original table info
+// is not modified.
+func (sg *SchemaGenerator) withNullableOverrides(tableInfo *TableInfo) *TableInfo {
+	if sg.config == nil || len(sg.config.NullableOverrides) == 0 {
+		return tableInfo
+	}
+
+	var overridden *TableInfo
+	for i, col := range tableInfo.Columns {
+		nullable, exists := sg.config.NullableOverrides[tableInfo.Name+"."+col.Name]
+		if !exists || nullable == col.Nullable {
+			continue
+		}
+		if overridden == nil {
+			copied := *tableInfo
+			copied.Columns = append([]ColumnInfo(nil), tableInfo.Columns...)
+			overridden = &copied
+		}
+		overridden.Columns[i].Nullable = nullable
+	}
+	if overridden == nil {
+		return tableInfo
+	}
+	return overridden
+}
+
+// selectedTables retrieves the tables to generate code for, applying the
+// include and exclude patterns from the configuration
+func (sg *SchemaGenerator) selectedTables(ctx context.Context) ([]string, error) {
+	tables, err := sg.cachedTables(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tables: %w", err)
+	}
+
+	if sg.config == nil {
+		return tables, nil
+	}
+
+	return filterTables(tables, sg.config.Include, sg.config.Exclude)
+}
+
+// recordTableError remembers a failed table once, even if several generators hit it
+func (sg *SchemaGenerator) recordTableError(tableName string, err error) {
+	if sg.tableFailed(tableName) {
+		return
+	}
+	sg.tableErrors = append(sg.tableErrors, TableError{Table: tableName, Err: err})
+}
+
+func (sg *SchemaGenerator) tableFailed(tableName string) bool {
+	for _, tableErr := range sg.tableErrors {
+		if tableErr.Table == tableName {
+			return true
+		}
+	}
+	return false
+}
+
+// parseExtra applies the flags found in a column's EXTRA field, such as
+// "auto_increment", "on update current_timestamp()" or "STORED GENERATED"
+func (sg *SchemaGenerator) parseExtra(col *ColumnInfo, extra string) {
+	extra = strings.ToLower(extra)
+
+	col.AutoIncrement = strings.Contains(extra, "auto_increment")
+	col.AutoUpdate = strings.Contains(extra, "on update current_timestamp")
+
+	// System-versioning period columns are generated as ROW START/ROW END
+	// and are usually hidden as well
+	switch strings.ToUpper(strings.TrimSpace(col.GenerationExpression.String)) {
+	case "ROW START", "ROW END":
+		col.Invisible = true
+	}
+	if strings.Contains(extra, "invisible") {
+		col.Invisible = true
+	}
+
+	// Extract generation type from EXTRA field
+	if col.IsGenerated {
+		if strings.Contains(extra, "virtual") {
+			col.GenerationType.String = "VIRTUAL"
+			col.GenerationType.Valid = true
+		} else if strings.Contains(extra, "stored") {
+			col.GenerationType.String = "STORED"
+			col.GenerationType.Valid = true
+		}
+	}
+}
+
+// parseEnumValues extracts enum values or set members from a MariaDB enum or
+// set type string, keeping their declaration order
 func (sg *SchemaGenerator) parseEnumValues(enumType string) []string {
-	// enumType looks like: enum('value1','value2','value3')
-	if !strings.HasPrefix(enumType, "enum(") || !strings.HasSuffix(enumType, ")") {
+	return enumValues(enumType)
+}
+
+// enumValues implements parseEnumValues for sources without a generator
+func enumValues(enumType string) []string {
+	// enumType looks like: enum('value1','value2','value3') or set('a','b')
+	if !strings.HasSuffix(enumType, ")") {
 		return nil
 	}
 
 	// Extract the values part
-	valuesStr := enumType[5 : len(enumType)-1] // Remove "enum(" and ")"
+	var valuesStr string
+	switch {
+	case strings.HasPrefix(enumType, "enum("):
+		valuesStr = enumType[5 : len(enumType)-1] // Remove "enum(" and ")"
+	case strings.HasPrefix(enumType, "set("):
+		valuesStr = enumType[4 : len(enumType)-1] // Remove "set(" and ")"
+	default:
+		return nil
+	}
 
-	// Split by comma and clean up quotes
+	// Split by comma and clean up quotes. Whitespace around a quoted value
+	// (as in "enum('a', 'b')") is dropped, whitespace inside it is kept.
 	parts := strings.Split(valuesStr, ",")
 	values := make([]string, len(parts))
 
 	for i, part := range parts {
-		// Remove surrounding quotes
-		values[i] = strings.Trim(part, "'")
+		part = strings.TrimSpace(part)
+		if len(part) >= 2 && part[0] == '\'' && part[len(part)-1] == '\'' {
+			part = part[1 : len(part)-1]
+		}
+		values[i] = part
 	}
 
 	return values
 }
 
-// checkJSONConstraint checks if a LONGTEXT column has a json_valid() CHECK constraint
+// checkJSONConstraint checks if a TEXT column has a json_valid() CHECK constraint.
+// The clauses are matched here rather than with LIKE, so the column reference
+// may be backtick-quoted and must match the whole column name.
 func (sg *SchemaGenerator) checkJSONConstraint(ctx context.Context, tableName, columnName string) (bool, error) {
 	query := `
-		SELECT COUNT(*)
+		SELECT cc.CHECK_CLAUSE
 		FROM information_schema.CHECK_CONSTRAINTS cc
 		JOIN information_schema.TABLE_CONSTRAINTS tc 
 			ON cc.CONSTRAINT_NAME = tc.CONSTRAINT_NAME 
@@ -276,211 +716,766 @@ func (sg *SchemaGenerator) checkJSONConstraint(ctx context.Context, tableName, c
 		WHERE tc.TABLE_SCHEMA = DATABASE()
 		AND tc.TABLE_NAME = ?
 		AND tc.CONSTRAINT_TYPE = 'CHECK'
-		AND cc.CHECK_CLAUSE LIKE CONCAT('%json_valid(%', ?, '%)%')
+		AND cc.CHECK_CLAUSE LIKE '%json_valid(%'
 	`
 
-	var count int
-	err := sg.db.QueryRowContext(ctx, query, tableName, columnName).Scan(&count)
+	rows, err := sg.db.QueryContext(ctx, query, tableName)
 	if err != nil {
 		return false, fmt.Errorf("failed to query JSON constraints: %w", err)
 	}
+	defer rows.Close()
+
+	pattern := jsonValidPattern(columnName)
+	for rows.Next() {
+		var clause string
+		if err := rows.Scan(&clause); err != nil {
+			return false, fmt.Errorf("failed to scan JSON constraint: %w", err)
+		}
+		if pattern.MatchString(clause) {
+			return true, nil
+		}
+	}
 
-	return count > 0, nil
+	return false, rows.Err()
 }
 
 // GenerateColumnConstants generates Go constants for all column names
 func (sg *SchemaGenerator) GenerateColumnConstants(ctx context.Context, packageName string) (string, error) {
-	tables, err := sg.GetTables(ctx)
+	tableInfos, err := sg.loadTables(ctx)
 	if err != nil {
-		return "", fmt.Errorf("failed to get tables: %w", err)
+		return "", err
 	}
 
 	var builder strings.Builder
-	builder.WriteString("// Code generated by MariaDB Schema Generator. DO NOT EDIT.\n")
-	builder.WriteString("// Generated on: " + time.Now().Format(time.RFC3339) + "\n\n")
+	builder.WriteString(sg.banner())
 	builder.WriteString("package " + packageName + "\n\n")
 
-	for _, tableName := range tables {
-		tableInfo, err := sg.GetTableInfo(ctx, tableName)
-		if err != nil {
-			return "", fmt.Errorf("failed to get table info for %s: %w", tableName, err)
-		}
+	for _, tableInfo := range tableInfos {
+		tableName := tableInfo.Name
 
 		// Generate constants for this table
 		builder.WriteString(fmt.Sprintf("// %s table column constants\n", sg.toCamelCase(tableName)))
 		builder.WriteString("const (\n")
 
-		for _, col := range tableInfo.Columns {
-			constName := sg.toConstantName(tableName, col.Name)
-			builder.WriteString(fmt.Sprintf("\t%s = \"%s\"\n", constName, col.Name))
+		if sg.config != nil && sg.config.GroupColumns {
+			sg.writeGroupedColumnConstants(&builder, tableInfo)
+		} else {
+			for _, col := range tableInfo.Columns {
+				constName := sg.toConstantName(tableName, col.Name)
+				builder.WriteString(fmt.Sprintf("\t%s = \"%s\"\n", constName, col.Name))
+			}
 		}
 
 		builder.WriteString(")\n\n")
+
+		builder.WriteString(sg.columnIndexes(tableInfo))
+
+		uniqueKeys, err := sg.uniqueKeys(tableInfo)
+		if err != nil {
+			return "", err
+		}
+		builder.WriteString(uniqueKeys)
 	}
 
 	return builder.String(), nil
 }
 
-// GenerateStructs generates Go structs for all tables
-func (sg *SchemaGenerator) GenerateStructs(ctx context.Context, packageName string) (string, error) {
-	tables, err := sg.GetTables(ctx)
-	if err != nil {
-		return "", fmt.Errorf("failed to get tables: %w", err)
+// columnIndexes generates a <Table>ColIdx<Column> constant per column holding
+// its position in the generated SELECT and ScanRow order, for indexing into
+// a []any scan target
+func (sg *SchemaGenerator) columnIndexes(tableInfo *TableInfo) string {
+	if len(tableInfo.Columns) == 0 {
+		return ""
 	}
 
 	var builder strings.Builder
-	builder.WriteString("// Code generated by MariaDB Schema Generator. DO NOT EDIT.\n")
-	builder.WriteString("// Generated on: " + time.Now().Format(time.RFC3339) + "\n\n")
-	builder.WriteString("package " + packageName + "\n\n")
-	builder.WriteString("import (\n")
-	builder.WriteString("\t\"database/sql\"\n")
-	builder.WriteString("\t\"time\"\n")
-
-	// Add custom imports from config
-	customImports := sg.getCustomImports()
-	if len(customImports) > 0 {
-		builder.WriteString("\n")
-		for _, imp := range customImports {
-			builder.WriteString(fmt.Sprintf("\t\"%s\"\n", imp))
-		}
+	builder.WriteString(fmt.Sprintf("// %s table column indexes in SELECT and ScanRow order\n", sg.toCamelCase(tableInfo.Name)))
+	builder.WriteString("const (\n")
+	for i, col := range tableInfo.Columns {
+		builder.WriteString(fmt.Sprintf("\t%s = %d\n", sg.toColumnIndexName(tableInfo.Name, col.Name), i))
 	}
-
-	builder.WriteString("\n")
-	builder.WriteString("\t\"github.com/louis77/mariakit/types\"\n")
 	builder.WriteString(")\n\n")
+	return builder.String()
+}
 
-	for _, tableName := range tables {
-		tableInfo, err := sg.GetTableInfo(ctx, tableName)
-		if err != nil {
-			return "", fmt.Errorf("failed to get table info for %s: %w", tableName, err)
+// uniqueKeys generates a <Table><Index>UniqueKey slice per unique index other
+// than the primary key, listing its columns in index order so upserts know
+// their conflict target
+func (sg *SchemaGenerator) uniqueKeys(tableInfo *TableInfo) (string, error) {
+	var builder strings.Builder
+	indexNames := make(map[string]string)
+	for _, index := range tableInfo.Indexes {
+		if !index.Unique || index.Name == "PRIMARY" {
+			continue
 		}
 
-		// Generate struct for this table
-		structName := sg.toStructName(tableName)
-		builder.WriteString(fmt.Sprintf("// %s represents the %s table\n", structName, tableName))
-		builder.WriteString(fmt.Sprintf("type %s struct {\n", structName))
-
-		for _, col := range tableInfo.Columns {
-			fieldName := sg.toFieldName(col.Name)
-			goType := sg.mysqlTypeToGoType(col.Type, col.Nullable, col.IsJSON, tableName, col.Name)
-
-			// Add db tag with comments
-			tag := fmt.Sprintf("`db:\"%s\"`", col.Name)
-			var comments []string
-			
-			if col.Comment.Valid && col.Comment.String != "" {
-				comments = append(comments, col.Comment.String)
-			}
-			
-			if col.IsGenerated {
-				genType := "VIRTUAL"
-				if col.GenerationType.Valid && col.GenerationType.String != "" {
-					genType = col.GenerationType.String
-				}
-				genComment := fmt.Sprintf("Generated (%s): %s", genType, col.GenerationExpression.String)
-				comments = append(comments, genComment)
-			}
-			
-			if len(comments) > 0 {
-				tag = fmt.Sprintf("`db:\"%s\"` // %s", col.Name, strings.Join(comments, "; "))
-			}
+		name := sg.toUniqueKeyName(tableInfo.Name, index.Name)
+		if other, exists := indexNames[name]; exists {
+			return "", fmt.Errorf("unique indexes %s and %s of table %s both map to %s", other, index.Name, tableInfo.Name, name)
+		}
+		indexNames[name] = index.Name
 
-			builder.WriteString(fmt.Sprintf("\t%s %s %s\n", fieldName, goType, tag))
+		columns := make([]string, len(index.Columns))
+		for i, column := range index.Columns {
+			columns[i] = fmt.Sprintf("%q", column)
 		}
 
-		builder.WriteString("}\n\n")
+		builder.WriteString(fmt.Sprintf("// %s lists the columns of the unique index %s in index order\n", name, index.Name))
+		builder.WriteString(fmt.Sprintf("var %s = []string{%s}\n\n", name, strings.Join(columns, ", ")))
 	}
-
 	return builder.String(), nil
 }
 
-// GenerateColumnTypes generates Go type aliases for all table columns
-func (sg *SchemaGenerator) GenerateColumnTypes(ctx context.Context, packageName string) (string, error) {
-	tables, err := sg.GetTables(ctx)
-	if err != nil {
-		return "", fmt.Errorf("failed to get tables: %w", err)
+// writeGroupedColumnConstants writes a table's column constants in labeled
+// groups: primary keys, generated columns and all remaining columns
+func (sg *SchemaGenerator) writeGroupedColumnConstants(builder *strings.Builder, tableInfo *TableInfo) {
+	isPK := make(map[string]bool)
+	for _, pk := range tableInfo.PrimaryKeys {
+		isPK[pk] = true
 	}
 
-	var builder strings.Builder
-	builder.WriteString("// Code generated by MariaDB Schema Generator. DO NOT EDIT.\n")
-	builder.WriteString("// Generated on: " + time.Now().Format(time.RFC3339) + "\n\n")
-	builder.WriteString("package " + packageName + "\n\n")
-	builder.WriteString("import (\n")
-	builder.WriteString("\t\"database/sql\"\n")
-	builder.WriteString("\t\"time\"\n")
-
-	// Add custom imports from config
-	customImports := sg.getCustomImports()
-	if len(customImports) > 0 {
-		builder.WriteString("\n")
-		for _, imp := range customImports {
-			builder.WriteString(fmt.Sprintf("\t\"%s\"\n", imp))
+	var primaryKeys, generated, columns []ColumnInfo
+	for _, col := range tableInfo.Columns {
+		switch {
+		case isPK[col.Name]:
+			primaryKeys = append(primaryKeys, col)
+		case col.IsGenerated:
+			generated = append(generated, col)
+		default:
+			columns = append(columns, col)
 		}
 	}
 
-	builder.WriteString("\n")
-	builder.WriteString("\t\"github.com/louis77/mariakit/types\"\n")
-	builder.WriteString(")\n\n")
+	groups := []struct {
+		label   string
+		columns []ColumnInfo
+	}{
+		{"primary keys", primaryKeys},
+		{"generated", generated},
+		{"columns", columns},
+	}
 
-	for _, tableName := range tables {
-		tableInfo, err := sg.GetTableInfo(ctx, tableName)
-		if err != nil {
-			return "", fmt.Errorf("failed to get table info for %s: %w", tableName, err)
+	first := true
+	for _, group := range groups {
+		if len(group.columns) == 0 {
+			continue
 		}
+		if !first {
+			builder.WriteString("\n")
+		}
+		first = false
 
-		// Generate type aliases for this table
-		builder.WriteString(fmt.Sprintf("// %s table column type aliases\n", sg.toCamelCase(tableName)))
-		
-		for _, col := range tableInfo.Columns {
-			goType := sg.mysqlTypeToGoType(col.Type, col.Nullable, col.IsJSON, tableName, col.Name)
-			typeName := sg.toColumnTypeName(tableName, col.Name)
-			
-			var comments []string
-			if col.Comment.Valid && col.Comment.String != "" {
-				comments = append(comments, col.Comment.String)
-			}
-			
-			if col.IsGenerated {
-				genType := "VIRTUAL"
-				if col.GenerationType.Valid && col.GenerationType.String != "" {
-					genType = col.GenerationType.String
-				}
-				genComment := fmt.Sprintf("Generated (%s): %s", genType, col.GenerationExpression.String)
-				comments = append(comments, genComment)
-			}
-			
-			if len(comments) > 0 {
-				builder.WriteString(fmt.Sprintf("type %s = %s // %s\n", typeName, goType, strings.Join(comments, "; ")))
-			} else {
-				builder.WriteString(fmt.Sprintf("type %s = %s\n", typeName, goType))
-			}
+		builder.WriteString(fmt.Sprintf("\t// %s\n", group.label))
+		for _, col := range group.columns {
+			constName := sg.toConstantName(tableInfo.Name, col.Name)
+			builder.WriteString(fmt.Sprintf("\t%s = \"%s\"\n", constName, col.Name))
 		}
-		
-		builder.WriteString("\n")
 	}
-
-	return builder.String(), nil
 }
 
-// GenerateEnumConstants generates Go constants for all enum values
-func (sg *SchemaGenerator) GenerateEnumConstants(ctx context.Context, packageName string) (string, error) {
-	enums, err := sg.GetAllEnums(ctx)
+// GenerateColumnNameTypes generates a string type per table with typed constants
+// for its column names, so query builders can reject unknown columns at compile time
+func (sg *SchemaGenerator) GenerateColumnNameTypes(ctx context.Context, packageName string) (string, error) {
+	tableInfos, err := sg.loadTables(ctx)
 	if err != nil {
-		return "", fmt.Errorf("failed to get enums: %w", err)
+		return "", err
 	}
 
-	if len(enums) == 0 {
-		return "// No enum types found in the database\n", nil
+	var builder strings.Builder
+	builder.WriteString(sg.banner())
+	builder.WriteString("package " + packageName + "\n\n")
+
+	for _, tableInfo := range tableInfos {
+		tableName := tableInfo.Name
+		typeName := sg.toColumnNameTypeName(tableName)
+
+		builder.WriteString(fmt.Sprintf("// %s is a column name of the %s table\n", typeName, tableName))
+		builder.WriteString(fmt.Sprintf("type %s string\n\n", typeName))
+		builder.WriteString("const (\n")
+
+		for _, col := range tableInfo.Columns {
+			constName := sg.toColumnNameConstantName(tableName, col.Name)
+			builder.WriteString(fmt.Sprintf("\t%s %s = %q\n", constName, typeName, col.Name))
+		}
+
+		builder.WriteString(")\n\n")
+	}
+
+	return builder.String(), nil
+}
+
+// GenerateStructs generates Go structs for all tables
+func (sg *SchemaGenerator) GenerateStructs(ctx context.Context, packageName string) (string, error) {
+	decls, err := sg.structDecls(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	imports := make(map[string]bool)
+	var builder strings.Builder
+	builder.WriteString(decls.prelude)
+	for _, table := range decls.tables {
+		builder.WriteString(table.code)
+		for imp := range table.imports {
+			imports[imp] = true
+		}
+	}
+	builder.WriteString(decls.helpers)
+	for imp := range decls.imports {
+		imports[imp] = true
+	}
+
+	var header strings.Builder
+	header.WriteString(sg.banner())
+	header.WriteString("package " + packageName + "\n\n")
+	writeImports(&header, imports)
+
+	return header.String() + builder.String(), nil
+}
+
+// structDeclarations is the code of structs.go, kept per table so that
+// Config.FilePerTable can write every table to its own file
+type structDeclarations struct {
+	prelude string          // the embedded mixin, declared before the tables
+	helpers string          // helper functions shared by the tables
+	imports map[string]bool // imports of prelude and helpers
+	tables  []tableDeclarations
+}
+
+// tableDeclarations is the struct of a table with its functions and methods
+type tableDeclarations struct {
+	table   string
+	code    string
+	imports map[string]bool
+}
+
+// structDecls generates the structs of all tables with their functions and methods
+func (sg *SchemaGenerator) structDecls(ctx context.Context) (*structDeclarations, error) {
+	tableInfos, err := sg.loadTables(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	decls := &structDeclarations{imports: make(map[string]bool)}
+
+	mixin, err := sg.buildMixin(tableInfos)
+	if err != nil {
+		return nil, err
+	}
+	if mixin != nil {
+		decls.prelude = mixin.declaration()
+		for _, imp := range mixin.imports {
+			decls.imports[imp] = true
+		}
+	}
+
+	structTables := make(map[string]string)
+	scanFuncTables := make(map[string]string)
+	for _, tableInfo := range tableInfos {
+		tableName := tableInfo.Name
+		// Imports are collected from the mapped types while writing the body
+		imports := make(map[string]bool)
+		var builder strings.Builder
+
+		// Generate struct for this table
+		structName := sg.toStructName(tableName)
+		if other, exists := structTables[structName]; exists {
+			return nil, fmt.Errorf("tables %s and %s both map to struct %s", other, tableName, structName)
+		}
+		if other, exists := scanFuncTables[structName]; exists {
+			return nil, fmt.Errorf("struct %s of table %s collides with the scan function of table %s", structName, tableName, other)
+		}
+		if mixin != nil && structName == mixin.name {
+			return nil, fmt.Errorf("table %s maps to struct %s, the name of the embedded mixin", tableName, structName)
+		}
+		structTables[structName] = tableName
+		scanFuncName := sg.toScanFuncName(tableName)
+		if other, exists := structTables[scanFuncName]; exists {
+			return nil, fmt.Errorf("scan function %s of table %s collides with the struct of table %s", scanFuncName, tableName, other)
+		}
+		scanFuncTables[scanFuncName] = tableName
+		builder.WriteString(fmt.Sprintf("// %s represents the %s table\n", structName, tableName))
+		if tableInfo.IsVersioned {
+			builder.WriteString("//\n")
+			builder.WriteString("// The table is system-versioned; query past rows with FOR SYSTEM_TIME.\n")
+		}
+		fieldNames, err := sg.fieldNames(tableInfo)
+		if err != nil {
+			return nil, err
+		}
+
+		// The mixin is embedded in place of its first column
+		var tableMixin *embedMixin
+		if mixin.embeds(sg, tableInfo, fieldNames) {
+			tableMixin = mixin
+			for _, fieldName := range fieldNames {
+				if fieldName == mixin.name {
+					return nil, fmt.Errorf("table %s: field %s collides with the embedded mixin", tableName, fieldName)
+				}
+			}
+		}
+
+		builder.WriteString(fmt.Sprintf("type %s struct {\n", structName))
+
+		goTypes := make([]string, len(tableInfo.Columns))
+		mixinWritten := false
+		for i, col := range tableInfo.Columns {
+			fieldName := fieldNames[i]
+			goType, typeImports := sg.goType(tableInfo, col)
+			goTypes[i] = goType
+
+			// Embedded mixin fields are declared with the mixin, so their
+			// types only need an import here as constructor parameters
+			embedded := tableMixin != nil && sg.isMixinColumn(col.Name)
+			if !embedded || isRequired(col) {
+				for _, imp := range typeImports {
+					imports[imp] = true
+				}
+			}
+
+			if embedded {
+				if !mixinWritten {
+					builder.WriteString(fmt.Sprintf("\t%s\n", tableMixin.name))
+					mixinWritten = true
+				}
+				continue
+			}
+
+			// Add struct tags with comments
+			tag := "`" + sg.structTag(tableInfo, col) + "`"
+			comments := sg.columnComments(tableInfo, col)
+
+			if len(comments) > 0 {
+				tag = fmt.Sprintf("%s // %s", tag, strings.Join(comments, "; "))
+			}
+
+			builder.WriteString(fmt.Sprintf("\t%s %s %s\n", fieldName, goType, tag))
+		}
+
+		builder.WriteString("}\n\n")
+
+		builder.WriteString(sg.generateConstructor(tableInfo, structName, fieldNames, goTypes, tableMixin))
+		builder.WriteString(sg.generateScanRow(tableInfo, structName, fieldNames))
+		builder.WriteString(sg.generateScanAll(tableInfo, structName))
+		imports["database/sql"] = true
+		builder.WriteString(sg.generateWithoutPK(tableInfo, structName, fieldNames))
+		builder.WriteString(sg.generateExistsSQL(tableInfo, structName))
+		builder.WriteString(sg.generateJSONAccessors(tableInfo, structName, fieldNames))
+		if sg.config != nil && sg.config.GenerateStringers {
+			builder.WriteString(sg.generateStringer(tableInfo, structName, fieldNames))
+		}
+
+		if err := checkMethodCollisions(tableName, builder.String()); err != nil {
+			return nil, err
+		}
+		decls.tables = append(decls.tables, tableDeclarations{table: tableName, code: builder.String(), imports: imports})
+	}
+
+	if sg.config != nil && sg.config.GenerateStringers && len(tableInfos) > 0 {
+		decls.helpers = stringerHelper
+		for _, imp := range stringerImports {
+			decls.imports[imp] = true
+		}
+	}
+
+	return decls, nil
+}
+
+// generateConstructor generates a New<Struct> function taking the required
+// columns in column order: those that are not nullable, have no default and
+// are neither generated nor auto-incremented. Fields of an embedded mixin are
+// set through a nested mixin literal.
+func (sg *SchemaGenerator) generateConstructor(tableInfo *TableInfo, structName string, fieldNames, goTypes []string, mixin *embedMixin) string {
+	constructorName := sg.toConstructorName(tableInfo.Name)
+
+	var params, assignments, mixinAssignments []string
+	mixinIndex := -1
+	for i, col := range tableInfo.Columns {
+		if !isRequired(col) {
+			continue
+		}
+
+		param := toParamName(fieldNames[i])
+		params = append(params, fmt.Sprintf("%s %s", param, goTypes[i]))
+		if mixin != nil && sg.isMixinColumn(col.Name) {
+			if mixinIndex < 0 {
+				mixinIndex = len(assignments)
+				assignments = append(assignments, "")
+			}
+			mixinAssignments = append(mixinAssignments, fmt.Sprintf("%s: %s", fieldNames[i], param))
+			continue
+		}
+		assignments = append(assignments, fmt.Sprintf("\t\t%s: %s,\n", fieldNames[i], param))
+	}
+	if mixinIndex >= 0 {
+		assignments[mixinIndex] = fmt.Sprintf("\t\t%s: %s{%s},\n", mixin.name, mixin.name, strings.Join(mixinAssignments, ", "))
+	}
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("// %s returns a new %s with its required columns set. Nullable, defaulted,\n", constructorName, structName))
+	builder.WriteString("// generated and auto-increment columns are left zero.\n")
+	builder.WriteString(fmt.Sprintf("func %s(%s) %s {\n", constructorName, strings.Join(params, ", "), structName))
+	if len(assignments) == 0 {
+		builder.WriteString(fmt.Sprintf("\treturn %s{}\n", structName))
+	} else {
+		builder.WriteString(fmt.Sprintf("\treturn %s{\n", structName))
+		builder.WriteString(strings.Join(assignments, ""))
+		builder.WriteString("\t}\n")
+	}
+	builder.WriteString("}\n\n")
+
+	return builder.String()
+}
+
+// generateScanRow generates a ScanRow method passing pointers to the fields
+// in column order to any scanner, so the struct is not tied to *sql.Rows
+func (sg *SchemaGenerator) generateScanRow(tableInfo *TableInfo, structName string, fieldNames []string) string {
+	receiver := sg.toReceiverName(structName)
+
+	pointers := make([]string, len(fieldNames))
+	for i, fieldName := range fieldNames {
+		pointers[i] = fmt.Sprintf("&%s.%s", receiver, fieldName)
+	}
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("// ScanRow scans a row of the %s columns in column order, as the generated\n", tableInfo.Name))
+	builder.WriteString("// SELECT statement returns them, from *sql.Row, *sql.Rows, *sqlx.Rows or any other scanner\n")
+	builder.WriteString(fmt.Sprintf("func (%s *%s) ScanRow(scanner interface{ Scan(...any) error }) error {\n", receiver, structName))
+	builder.WriteString(fmt.Sprintf("\treturn scanner.Scan(%s)\n", strings.Join(pointers, ", ")))
+	builder.WriteString("}\n\n")
+
+	return builder.String()
+}
+
+// generateScanAll generates a Scan<Table> function collecting all rows of a
+// query into a slice with ScanRow, closing the rows when done
+func (sg *SchemaGenerator) generateScanAll(tableInfo *TableInfo, structName string) string {
+	funcName := sg.toScanFuncName(tableInfo.Name)
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("// %s scans every row of a query of the %s columns with ScanRow.\n", funcName, tableInfo.Name))
+	builder.WriteString("// It closes rows and reports the iteration error, if any.\n")
+	builder.WriteString(fmt.Sprintf("func %s(rows *sql.Rows) ([]%s, error) {\n", funcName, structName))
+	builder.WriteString("\tdefer rows.Close()\n\n")
+	builder.WriteString(fmt.Sprintf("\tvar result []%s\n", structName))
+	builder.WriteString("\tfor rows.Next() {\n")
+	builder.WriteString(fmt.Sprintf("\t\tvar row %s\n", structName))
+	builder.WriteString("\t\tif err := row.ScanRow(rows); err != nil {\n")
+	builder.WriteString("\t\t\treturn nil, err\n")
+	builder.WriteString("\t\t}\n")
+	builder.WriteString("\t\tresult = append(result, row)\n")
+	builder.WriteString("\t}\n")
+	builder.WriteString("\tif err := rows.Err(); err != nil {\n")
+	builder.WriteString("\t\treturn nil, err\n")
+	builder.WriteString("\t}\n")
+	builder.WriteString("\treturn result, nil\n")
+	builder.WriteString("}\n\n")
+
+	return builder.String()
+}
+
+// generateWithoutPK generates a WithoutPK method returning a copy with the
+// primary key and auto-increment fields zeroed, for inserting a row as new
+func (sg *SchemaGenerator) generateWithoutPK(tableInfo *TableInfo, structName string, fieldNames []string) string {
+	receiver := sg.toReceiverName(structName)
+
+	isPK := make(map[string]bool)
+	for _, pk := range tableInfo.PrimaryKeys {
+		isPK[pk] = true
+	}
+
+	var zeroed []string
+	for i, col := range tableInfo.Columns {
+		if isPK[col.Name] || col.AutoIncrement {
+			zeroed = append(zeroed, fieldNames[i])
+		}
+	}
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("// WithoutPK returns a copy of %s with the primary key and auto-increment fields\n", receiver))
+	builder.WriteString("// zeroed, ready to be inserted as a new row\n")
+	builder.WriteString(fmt.Sprintf("func (%s %s) WithoutPK() %s {\n", receiver, structName, structName))
+	if len(zeroed) > 0 {
+		builder.WriteString(fmt.Sprintf("\tvar zero %s\n", structName))
+		for _, field := range zeroed {
+			builder.WriteString(fmt.Sprintf("\t%s.%s = zero.%s\n", receiver, field, field))
+		}
+	}
+	builder.WriteString(fmt.Sprintf("\treturn %s\n", receiver))
+	builder.WriteString("}\n\n")
+
+	return builder.String()
+}
+
+// isRequired reports whether a value must be provided for a column on insert:
+// it is not nullable, has no default and is neither generated nor auto-incremented
+func isRequired(col ColumnInfo) bool {
+	return !col.Nullable && !col.DefaultValue.Valid && !col.IsGenerated && !col.AutoIncrement
+}
+
+// generateJSONAccessors generates a method per JSON column mapped to
+// types.JSON[T] that returns the decoded T, or its zero value when invalid.
+// Accessors whose name is taken by a field, such as SettingsValue for a
+// settings_value column, are skipped with a warning.
+func (sg *SchemaGenerator) generateJSONAccessors(tableInfo *TableInfo, structName string, fieldNames []string) string {
+	if sg.config == nil {
+		return ""
+	}
+
+	receiver := sg.toReceiverName(structName)
+	fields := make(map[string]bool, len(fieldNames))
+	for _, name := range fieldNames {
+		fields[name] = true
+	}
+
+	var builder strings.Builder
+	for i, col := range tableInfo.Columns {
+		if !col.IsJSON {
+			continue
+		}
+		mapping, exists := sg.config.GetJSONMapping(tableInfo.Name, col.Name)
+		if !exists {
+			continue
+		}
+		dataType, ok := jsonDataType(mapping.Type)
+		if !ok {
+			continue
+		}
+
+		fieldName := fieldNames[i]
+		if fields[fieldName+"Value"] {
+			sg.warn("table %s: skipped accessor %sValue for JSON column %s, the name is taken by a field", tableInfo.Name, fieldName, col.Name)
+			continue
+		}
+		builder.WriteString(fmt.Sprintf("// %sValue returns the decoded %s column, or the zero value when it is not valid\n", fieldName, col.Name))
+		builder.WriteString(fmt.Sprintf("func (%s %s) %sValue() %s {\n", receiver, structName, fieldName, dataType))
+		builder.WriteString(fmt.Sprintf("\tif !%s.%s.Valid {\n", receiver, fieldName))
+		builder.WriteString(fmt.Sprintf("\t\tvar zero %s\n", dataType))
+		builder.WriteString("\t\treturn zero\n")
+		builder.WriteString("\t}\n")
+		builder.WriteString(fmt.Sprintf("\treturn %s.%s.Data\n", receiver, fieldName))
+		builder.WriteString("}\n\n")
+	}
+
+	return builder.String()
+}
+
+// jsonDataType returns the type argument T of a types.JSON[T] type expression
+func jsonDataType(goType string) (string, bool) {
+	expr, err := parser.ParseExpr(goType)
+	if err != nil {
+		return "", false
+	}
+
+	index, ok := expr.(*ast.IndexExpr)
+	if !ok {
+		return "", false
+	}
+	selector, ok := index.X.(*ast.SelectorExpr)
+	if !ok || selector.Sel.Name != "JSON" && selector.Sel.Name != "PreciseJSON" && selector.Sel.Name != "NullJSON" {
+		return "", false
+	}
+	if pkg, ok := selector.X.(*ast.Ident); !ok || pkg.Name != "types" {
+		return "", false
+	}
+
+	// Positions are 1-based offsets into goType
+	return goType[index.Index.Pos()-1 : index.Index.End()-1], true
+}
+
+// columnComments collects the comments emitted next to a column's field or type alias
+func (sg *SchemaGenerator) columnComments(tableInfo *TableInfo, col ColumnInfo) []string {
+	var comments []string
+
+	if col.Comment.Valid && col.Comment.String != "" {
+		comments = append(comments, col.Comment.String)
+	}
+
+	if col.IsGenerated {
+		genType := "VIRTUAL"
+		if col.GenerationType.Valid && col.GenerationType.String != "" {
+			genType = col.GenerationType.String
+		}
+		genComment := fmt.Sprintf("Generated (%s): %s", genType, col.GenerationExpression.String)
+		comments = append(comments, genComment)
+	}
+
+	if length, ok := stringLength(col); ok {
+		comments = append(comments, fmt.Sprintf("max length %d", length))
+	}
+
+	if col.AutoUpdate {
+		comments = append(comments, "auto-update")
+	}
+
+	if col.VectorDimension > 0 {
+		comments = append(comments, fmt.Sprintf("dim=%d", col.VectorDimension))
+	}
+
+	if col.IsSet && len(col.EnumValues) > 0 {
+		bits := make([]string, len(col.EnumValues))
+		for i, member := range col.EnumValues {
+			bits[i] = fmt.Sprintf("%s=%d", member, col.SetMemberBit(member))
+		}
+		comments = append(comments, "set bits: "+strings.Join(bits, ", "))
+	}
+
+	if tableInfo.HasSpatialIndex(col.Name) {
+		comments = append(comments, "spatial index")
+	}
+
+	return comments
+}
+
+// GenerateColumnTypes generates Go type aliases for all table columns
+func (sg *SchemaGenerator) GenerateColumnTypes(ctx context.Context, packageName string) (string, error) {
+	tableInfos, err := sg.loadTables(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	// Imports are collected from the mapped types while writing the body
+	imports := make(map[string]bool)
+	var builder strings.Builder
+
+	for _, tableInfo := range tableInfos {
+		tableName := tableInfo.Name
+
+		// Generate type aliases for this table
+		builder.WriteString(fmt.Sprintf("// %s table column type aliases\n", sg.toCamelCase(tableName)))
+		
+		for _, col := range tableInfo.Columns {
+			goType, typeImports := sg.goType(tableInfo, col)
+			for _, imp := range typeImports {
+				imports[imp] = true
+			}
+			typeName := sg.toColumnTypeName(tableName, col.Name)
+			
+			comments := sg.columnComments(tableInfo, col)
+			if len(comments) > 0 {
+				builder.WriteString(fmt.Sprintf("type %s = %s // %s\n", typeName, goType, strings.Join(comments, "; ")))
+			} else {
+				builder.WriteString(fmt.Sprintf("type %s = %s\n", typeName, goType))
+			}
+		}
+		
+		builder.WriteString("\n")
+	}
+
+	var header strings.Builder
+	header.WriteString(sg.banner())
+	header.WriteString("package " + packageName + "\n\n")
+	writeImports(&header, imports)
+
+	return header.String() + builder.String(), nil
+}
+
+// GenerateEnumConstants generates Go constants for all enum values
+func (sg *SchemaGenerator) GenerateEnumConstants(ctx context.Context, packageName string) (string, error) {
+	tableNames, tableEnums, err := sg.selectedEnums(ctx)
+	if err != nil {
+		return "", err
+	}
+	lookups, err := sg.lookupEnums(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if len(tableNames) == 0 && len(lookups) == 0 {
+		return sg.banner() + "// No enum types found in the database\n", nil
+	}
+
+	var builder strings.Builder
+	builder.WriteString(sg.banner())
+	builder.WriteString("package " + packageName + "\n\n")
+
+	if sg.enumMode() != EnumModeConstants && len(tableNames) > 0 {
+		builder.WriteString("import (\n")
+		builder.WriteString("\t\"database/sql/driver\"\n")
+		builder.WriteString("\t\"fmt\"\n")
+		builder.WriteString(")\n\n")
+	}
+
+	for _, tableName := range tableNames {
+		enums := tableEnums[tableName]
+		builder.WriteString(fmt.Sprintf("// %s table enum constants\n", sg.toCamelCase(tableName)))
+
+		for _, enum := range enums {
+			builder.WriteString(sg.generateEnumBlock(tableName, enum))
+		}
+	}
+
+	for _, lookup := range lookups {
+		block, err := sg.generateLookupBlock(lookup)
+		if err != nil {
+			return "", err
+		}
+		builder.WriteString(block)
+	}
+
+	return builder.String(), nil
+}
+
+// GenerateEnumTypes generates only the typed enum definitions with their
+// allowed values and methods, leaving out the value constants, so the enums
+// can live in a package of their own. It requires the typed enum mode.
+func (sg *SchemaGenerator) GenerateEnumTypes(ctx context.Context, packageName string) (string, error) {
+	if sg.enumMode() != EnumModeTyped {
+		return "", fmt.Errorf("enum types require enum_mode %s", EnumModeTyped)
+	}
+
+	tableNames, tableEnums, err := sg.selectedEnums(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if len(tableNames) == 0 {
+		return sg.banner() + "// No enum types found in the database\n", nil
 	}
 
 	var builder strings.Builder
-	builder.WriteString("// Code generated by MariaDB Schema Generator. DO NOT EDIT.\n")
-	builder.WriteString("// Generated on: " + time.Now().Format(time.RFC3339) + "\n\n")
+	builder.WriteString(sg.banner())
 	builder.WriteString("package " + packageName + "\n\n")
+	builder.WriteString("import (\n")
+	builder.WriteString("\t\"database/sql/driver\"\n")
+	builder.WriteString("\t\"fmt\"\n")
+	builder.WriteString(")\n\n")
+
+	for _, tableName := range tableNames {
+		builder.WriteString(fmt.Sprintf("// %s table enum types\n", sg.toCamelCase(tableName)))
+
+		for _, enum := range tableEnums[tableName] {
+			builder.WriteString(sg.generateEnumTypeDecl(tableName, enum))
+			builder.WriteString(sg.generateEnumAllowed(tableName, enum))
+			builder.WriteString(sg.generateTypedEnumMethods(tableName, enum))
+		}
+	}
+
+	return builder.String(), nil
+}
+
+// selectedEnums returns the enums grouped by table with the sorted names of
+// their tables, leaving out tables that are not selected or failed inspection
+func (sg *SchemaGenerator) selectedEnums(ctx context.Context) ([]string, map[string][]EnumInfo, error) {
+	enums, err := sg.enums(ctx)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get enums: %w", err)
+	}
+
+	tables, err := sg.selectedTables(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	selected := make(map[string]bool)
+	for _, tableName := range tables {
+		selected[tableName] = true
+	}
 
-	// Group enums by table for better organization
 	tableEnums := make(map[string][]EnumInfo)
 	for _, enum := range enums {
+		if !selected[enum.TableName] || sg.tableFailed(enum.TableName) {
+			continue
+		}
 		tableEnums[enum.TableName] = append(tableEnums[enum.TableName], enum)
 	}
 
@@ -491,27 +1486,105 @@ func (sg *SchemaGenerator) GenerateEnumConstants(ctx context.Context, packageNam
 	}
 	sort.Strings(tableNames)
 
-	for _, tableName := range tableNames {
-		enums := tableEnums[tableName]
-		builder.WriteString(fmt.Sprintf("// %s table enum constants\n", sg.toCamelCase(tableName)))
+	return tableNames, tableEnums, nil
+}
 
-		for _, enum := range enums {
-			builder.WriteString("const (\n")
+// generateEnumBlock generates the constants and the allowed-values slice for a
+// single enum column, plus the enum type and its methods in typed and int mode
+func (sg *SchemaGenerator) generateEnumBlock(tableName string, enum EnumInfo) string {
+	mode := sg.enumMode()
+	typeName := sg.toEnumTypeName(tableName, enum.ColumnName)
 
-			for _, value := range enum.Values {
-				constName := sg.toEnumConstantName(tableName, enum.ColumnName, value)
-				builder.WriteString(fmt.Sprintf("\t%s = \"%s\"\n", constName, value))
-			}
+	var builder strings.Builder
+	switch mode {
+	case EnumModeTyped:
+		builder.WriteString(sg.generateEnumTypeDecl(tableName, enum))
+	case EnumModeInt:
+		builder.WriteString(fmt.Sprintf("// %s is a value of the %s.%s enum column, stored as its string in MariaDB\n", typeName, tableName, enum.ColumnName))
+		builder.WriteString(fmt.Sprintf("type %s int\n\n", typeName))
+	}
 
-			builder.WriteString(")\n\n")
+	builder.WriteString("const (\n")
+
+	constNames := sg.enumConstantNames(tableName, enum)
+	for i, value := range enum.Values {
+		constName := constNames[i]
+		switch {
+		case mode == EnumModeTyped:
+			builder.WriteString(fmt.Sprintf("\t%s %s = %q\n", constName, typeName, value))
+		case mode == EnumModeInt && i == 0:
+			builder.WriteString(fmt.Sprintf("\t%s %s = iota // %s\n", constName, typeName, value))
+		case mode == EnumModeInt:
+			builder.WriteString(fmt.Sprintf("\t%s // %s\n", constName, value))
+		default:
+			builder.WriteString(fmt.Sprintf("\t%s = %q\n", constName, value))
 		}
 	}
 
-	return builder.String(), nil
+	builder.WriteString(")\n\n")
+
+	builder.WriteString(sg.generateEnumAllowed(tableName, enum))
+
+	switch mode {
+	case EnumModeTyped:
+		builder.WriteString(sg.generateTypedEnumMethods(tableName, enum))
+	case EnumModeInt:
+		builder.WriteString(sg.generateIntEnumMethods(tableName, enum))
+	}
+
+	return builder.String()
 }
 
-// GenerateAll generates all types of code (constants, structs, enums, and column types)
+// generateEnumTypeDecl generates the string type of a typed enum
+func (sg *SchemaGenerator) generateEnumTypeDecl(tableName string, enum EnumInfo) string {
+	typeName := sg.toEnumTypeName(tableName, enum.ColumnName)
+	return fmt.Sprintf("// %s is a value of the %s.%s enum column\ntype %s string\n\n", typeName, tableName, enum.ColumnName, typeName)
+}
+
+// generateEnumAllowed generates the slice of an enum's allowed values in MariaDB declaration order
+func (sg *SchemaGenerator) generateEnumAllowed(tableName string, enum EnumInfo) string {
+	quoted := make([]string, len(enum.Values))
+	for i, value := range enum.Values {
+		quoted[i] = fmt.Sprintf("%q", value)
+	}
+	return fmt.Sprintf("var %s = []string{%s}\n\n",
+		sg.toEnumAllowedName(tableName, enum.ColumnName), strings.Join(quoted, ", "))
+}
+
+// enumConstantNames returns the constant names of an enum's values. Values
+// that normalize to the same name, like "in-progress" and "in_progress", get
+// a numeric suffix in declaration order and a warning is recorded.
+func (sg *SchemaGenerator) enumConstantNames(tableName string, enum EnumInfo) []string {
+	names := make([]string, len(enum.Values))
+	taken := make(map[string]string)
+
+	for i, value := range enum.Values {
+		name := sg.toEnumConstantName(tableName, enum.ColumnName, value)
+		unique := name
+		for n := 2; ; n++ {
+			if _, exists := taken[unique]; !exists {
+				break
+			}
+			unique = fmt.Sprintf("%s_%d", name, n)
+		}
+		if unique != name {
+			sg.warn("enum values %q and %q of %s.%s both map to %s, using %s for %q",
+				taken[name], value, tableName, enum.ColumnName, name, unique, value)
+		}
+		taken[unique] = value
+		names[i] = unique
+	}
+
+	return names
+}
+
+// GenerateAll generates all types of code (constants, structs, enums, column types, column name types, queries, metadata
+// and, with Config.GenerateSchemaInfo set, schema info).
+// Each table is inspected once and shared by all generators. With Config.SingleFile set, everything is merged into a
+// single models.go. With Config.FilePerTable set, the structs are written to a file per table.
 func (sg *SchemaGenerator) GenerateAll(ctx context.Context, packageName string) (map[string]string, error) {
+	defer sg.enableCache()()
+
 	columnConstants, err := sg.GenerateColumnConstants(ctx, packageName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate column constants: %w", err)
@@ -527,22 +1600,104 @@ func (sg *SchemaGenerator) GenerateAll(ctx context.Context, packageName string)
 		return nil, fmt.Errorf("failed to generate column types: %w", err)
 	}
 
+	columnNames, err := sg.GenerateColumnNameTypes(ctx, packageName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate column name types: %w", err)
+	}
+
+	queries, err := sg.GenerateQueries(ctx, packageName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate queries: %w", err)
+	}
+
 	enumConstants, err := sg.GenerateEnumConstants(ctx, packageName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate enum constants: %w", err)
 	}
 
-	return map[string]string{
+	metadata, err := sg.GenerateMetadata(ctx, packageName)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate metadata: %w", err)
+	}
+
+	files := map[string]string{
 		"column_constants.go": columnConstants,
 		"structs.go":          structs,
 		"column_types.go":     columnTypes,
+		"column_names.go":     columnNames,
+		"queries.go":          queries,
 		"enum_constants.go":   enumConstants,
-	}, nil
+		"metadata.go":         metadata,
+	}
+	sections := []string{columnConstants, structs, columnTypes, columnNames, queries, enumConstants, metadata}
+
+	if sg.config != nil && sg.config.GenerateSchemaInfo {
+		schemaInfo, err := sg.GenerateSchemaInfo(ctx, packageName)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate schema info: %w", err)
+		}
+		files["schema_info.go"] = schemaInfo
+		sections = append(sections, schemaInfo)
+	}
+
+	if err := checkDeclarationCollisions(files); err != nil {
+		return nil, err
+	}
+
+	if sg.config != nil && sg.config.FilePerTable {
+		return sg.withStructFiles(ctx, files, packageName)
+	}
+
+	if sg.config != nil && sg.config.SingleFile {
+		merged, err := mergeSections(sg.bannerWith(sg.goGenerateDirective()), packageName, sections)
+		if err != nil {
+			return nil, fmt.Errorf("failed to merge generated files: %w", err)
+		}
+		return map[string]string{SingleFileName: merged}, nil
+	}
+
+	return files, nil
+}
+
+// banner returns the comment starting every generated file. Its first line
+// follows the "// Code generated ... DO NOT EDIT." convention recognized by
+// Go tools. The configured lint directive ends it.
+func (sg *SchemaGenerator) banner() string {
+	return sg.bannerWith("")
+}
+
+// bannerWith returns the banner with extra lines, such as a //go:generate
+// directive, inserted before the lint directive. golangci-lint only applies
+// //nolint to the whole file when it directly precedes the package clause.
+func (sg *SchemaGenerator) bannerWith(extra string) string {
+	banner := fmt.Sprintf("// Code generated by %s; DO NOT EDIT.\n// Generated on: %s\n\n",
+		sg.config.toolName(), time.Now().Format(time.RFC3339))
+	return banner + extra + sg.lintDirective()
+}
+
+// lintDirective returns the configured file-level lint directive, or ""
+func (sg *SchemaGenerator) lintDirective() string {
+	if sg.config == nil {
+		return ""
+	}
+	switch sg.config.LintDirective {
+	case LintDirectiveNolint:
+		return "//nolint:all\n"
+	case LintDirectiveStaticcheck:
+		return fmt.Sprintf("//lint:file-ignore U1000,ST1003 generated by %s\n", sg.config.toolName())
+	default:
+		return ""
+	}
 }
 
 // Helper functions for name conversion
 
 func (sg *SchemaGenerator) toCamelCase(s string) string {
+	return camelCase(s)
+}
+
+// camelCase joins the underscore-separated parts of s with their first letters upper-cased
+func camelCase(s string) string {
 	parts := strings.Split(s, "_")
 	for i := range parts {
 		if len(parts[i]) > 0 {
@@ -555,52 +1710,210 @@ func (sg *SchemaGenerator) toCamelCase(s string) string {
 func (sg *SchemaGenerator) toConstantName(tableName, columnName string) string {
 	table := sg.toCamelCase(tableName)
 	column := sg.toCamelCase(columnName)
-	return fmt.Sprintf("%s_%s_Name", table, column)
+	return exportName(fmt.Sprintf("%s_%s_Name", table, column), sg.config.exportConstants())
+}
+
+func (sg *SchemaGenerator) toColumnIndexName(tableName, columnName string) string {
+	return exportName(sg.toCamelCase(tableName)+"ColIdx"+sg.toCamelCase(columnName), sg.config.exportConstants())
+}
+
+func (sg *SchemaGenerator) toCSVHeaderName(tableName string) string {
+	return exportName(sg.toCamelCase(tableName)+"CSVHeader", sg.config.exportConstants())
+}
+
+func (sg *SchemaGenerator) toScanFuncName(tableName string) string {
+	return exportName("Scan"+sg.toCamelCase(tableName), sg.config.exportStructs())
 }
 
 func (sg *SchemaGenerator) toStructName(tableName string) string {
-	return sg.toCamelCase(tableName)
+	return exportName(sg.toCamelCase(tableName), sg.config.exportStructs())
+}
+
+func (sg *SchemaGenerator) toConstructorName(tableName string) string {
+	return exportName("New"+sg.toCamelCase(tableName), sg.config.exportStructs())
+}
+
+func (sg *SchemaGenerator) toQueryConstantName(tableName, statement string) string {
+	return exportName(sg.toCamelCase(tableName)+statement+"SQL", sg.config.exportConstants())
 }
 
 func (sg *SchemaGenerator) toFieldName(columnName string) string {
 	return sg.toCamelCase(columnName)
 }
 
+func (sg *SchemaGenerator) toReceiverName(structName string) string {
+	if structName == "" {
+		return "r"
+	}
+	return strings.ToLower(structName[:1])
+}
+
+func (sg *SchemaGenerator) toUniqueKeyName(tableName, indexName string) string {
+	index := sg.toCamelCase(strings.Map(func(r rune) rune {
+		if r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return '_'
+	}, indexName))
+	return exportName(sg.toCamelCase(tableName)+index+"UniqueKey", sg.config.exportConstants())
+}
+
 func (sg *SchemaGenerator) toEnumConstantName(tableName, columnName, value string) string {
 	table := sg.toCamelCase(tableName)
 	column := sg.toCamelCase(columnName)
-	val := sg.toCamelCase(value)
-	return fmt.Sprintf("%s_%s_%s", table, column, val)
+	val := sg.toCamelCase(strings.Map(func(r rune) rune {
+		if r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return '_'
+	}, value))
+	return exportName(fmt.Sprintf("%s_%s_%s", table, column, val), sg.config.exportConstants())
+}
+
+func (sg *SchemaGenerator) toEnumTypeName(tableName, columnName string) string {
+	return enumTypeName(sg.config, tableName, columnName)
+}
+
+func (sg *SchemaGenerator) toNullEnumTypeName(tableName, columnName string) string {
+	return nullEnumTypeName(sg.config, tableName, columnName)
+}
+
+// enumTypeName names the typed enum of a column. The type mapping needs it
+// without a generator.
+func enumTypeName(cfg *Config, tableName, columnName string) string {
+	return exportName(camelCase(tableName)+camelCase(columnName), cfg.exportConstants())
+}
+
+func nullEnumTypeName(cfg *Config, tableName, columnName string) string {
+	return exportName("Null"+camelCase(tableName)+camelCase(columnName), cfg.exportConstants())
+}
+
+func (sg *SchemaGenerator) toEnumAllowedName(tableName, columnName string) string {
+	return exportName(sg.toCamelCase(tableName)+sg.toCamelCase(columnName)+"Allowed", sg.config.exportConstants())
+}
+
+func (sg *SchemaGenerator) toEnumAllFuncName(tableName, columnName string) string {
+	return exportName("All"+sg.toCamelCase(tableName)+sg.toCamelCase(columnName), sg.config.exportConstants())
+}
+
+func (sg *SchemaGenerator) toEnumFromIndexName(tableName, columnName string) string {
+	return exportName(sg.toCamelCase(tableName)+sg.toCamelCase(columnName)+"FromIndex", sg.config.exportConstants())
+}
+
+func (sg *SchemaGenerator) toEnumSetName(tableName, columnName string) string {
+	return exportName(sg.toCamelCase(tableName)+sg.toCamelCase(columnName)+"Set", false)
+}
+
+func (sg *SchemaGenerator) toColumnNameTypeName(tableName string) string {
+	return exportName(sg.toCamelCase(tableName)+"Column", sg.config.exportConstants())
+}
+
+func (sg *SchemaGenerator) toColumnNameConstantName(tableName, columnName string) string {
+	return exportName(sg.toCamelCase(tableName)+"Column"+sg.toInitialismCamelCase(columnName), sg.config.exportConstants())
+}
+
+func (sg *SchemaGenerator) toRepositoryName(tableName string) string {
+	return exportName(sg.toCamelCase(tableName)+"Repository", sg.config.exportStructs())
+}
+
+// exportName lower-cases the first letter of name unless it is exported.
+// Unexported names that are Go keywords or predeclared identifiers, such as
+// a table named type or string, get a trailing underscore.
+func exportName(name string, exported bool) string {
+	if exported || name == "" {
+		return name
+	}
+	name = strings.ToLower(name[:1]) + name[1:]
+	if token.IsKeyword(name) || types.Universe.Lookup(name) != nil {
+		return name + "_"
+	}
+	return name
 }
 
 func (sg *SchemaGenerator) toColumnTypeName(tableName, columnName string) string {
 	table := sg.toCamelCase(tableName)
 	column := sg.toCamelCase(columnName)
-	return fmt.Sprintf("%s_%s", table, column)
+	return exportName(fmt.Sprintf("%s_%s", table, column), sg.config.exportConstants())
 }
 
 func (sg *SchemaGenerator) mysqlTypeToGoType(mysqlType string, nullable bool, isJSON bool, tableName, columnName string) string {
+	return mapMySQLType(sg.config, mysqlType, nullable, isJSON, tableName, columnName)
+}
+
+// mapMySQLType maps a column type to its Go type with the nullable mode,
+// JSON mappings and other type settings of cfg, which may be nil
+func mapMySQLType(cfg *Config, mysqlType string, nullable bool, isJSON bool, tableName, columnName string) string {
+	goType := columnGoType(cfg, mysqlType, nullable, isJSON, tableName, columnName)
+
+	// In pointer mode, sql.Null* types become a pointer to the NOT NULL type,
+	// so boolean and other special mappings carry over
+	if nullable && strings.HasPrefix(goType, "sql.Null") && cfg != nil && cfg.NullableMode == NullableModePointer {
+		return "*" + columnGoType(cfg, mysqlType, false, isJSON, tableName, columnName)
+	}
+	return goType
+}
+
+// splitIntegerAttributes removes the signed, unsigned and zerofill
+// attributes from a column type and reports whether the column is unsigned
+func splitIntegerAttributes(mysqlType string) (string, bool) {
+	fields := strings.Fields(mysqlType)
+	if len(fields) < 2 {
+		return mysqlType, false
+	}
+
+	unsigned := false
+	kept := fields[:1]
+	for _, field := range fields[1:] {
+		switch strings.ToLower(field) {
+		case "unsigned", "zerofill":
+			unsigned = true
+		case "signed":
+		default:
+			kept = append(kept, field)
+		}
+	}
+	return strings.Join(kept, " "), unsigned
+}
+
+// columnGoType maps a column type to its Go type in the default sql nullable mode
+func columnGoType(cfg *Config, mysqlType string, nullable bool, isJSON bool, tableName, columnName string) string {
 	// Handle JSON types (detected LONGTEXT with json_valid() constraint)
 	if isJSON {
 		// Check for custom JSON mapping
-		if sg.config != nil {
-			if mapping, exists := sg.config.GetJSONMapping(tableName, columnName); exists {
+		if cfg != nil {
+			if mapping, exists := cfg.GetJSONMapping(tableName, columnName); exists {
 				return mapping.Type
 			}
+			if cfg.PreciseJSON {
+				return "types.PreciseJSON[any]"
+			}
 		}
 		return "types.JSON[any]"
 	}
 
 	// Handle enum types
 	if strings.HasPrefix(mysqlType, "enum(") {
+		if cfg.enumMode() != EnumModeConstants {
+			if nullable {
+				return nullEnumTypeName(cfg, tableName, columnName)
+			}
+			return enumTypeName(cfg, tableName, columnName)
+		}
 		if nullable {
 			return "sql.NullString"
 		}
 		return "string"
 	}
 
-	// Check for TINYINT(1) which is MariaDB's boolean type before stripping size
-	if strings.ToLower(mysqlType) == "tinyint(1)" {
+	// Strip the unsigned and zerofill attributes, as in "int(10) unsigned
+	// zerofill". Zerofill columns are always unsigned, but only map to
+	// unsigned Go types with Config.UnsignedIntegers.
+	sizedType, hasUnsigned := splitIntegerAttributes(mysqlType)
+	unsigned := hasUnsigned && cfg != nil && cfg.UnsignedIntegers
+
+	// Check for TINYINT(1) which is MariaDB's boolean type before stripping size.
+	// An unsigned TINYINT(1) maps like any other integer, as it always has
+	if strings.ToLower(sizedType) == "tinyint(1)" && !hasUnsigned {
 		if nullable {
 			return "sql.NullBool"
 		} else {
@@ -609,7 +1922,7 @@ func (sg *SchemaGenerator) mysqlTypeToGoType(mysqlType string, nullable bool, is
 	}
 
 	// Extract base type (remove size specifications)
-	baseType := mysqlType
+	baseType := sizedType
 	if idx := strings.Index(baseType, "("); idx > 0 {
 		baseType = baseType[:idx]
 	}
@@ -617,13 +1930,21 @@ func (sg *SchemaGenerator) mysqlTypeToGoType(mysqlType string, nullable bool, is
 	var goType string
 	switch strings.ToLower(baseType) {
 	case "tinyint", "smallint", "mediumint", "int", "integer":
-		if nullable {
+		if unsigned && nullable {
+			goType = "sql.Null[uint32]"
+		} else if unsigned {
+			goType = "uint32"
+		} else if nullable {
 			goType = "sql.NullInt32"
 		} else {
 			goType = "int32"
 		}
 	case "bigint":
-		if nullable {
+		if unsigned && nullable {
+			goType = "sql.Null[uint64]"
+		} else if unsigned {
+			goType = "uint64"
+		} else if nullable {
 			goType = "sql.NullInt64"
 		} else {
 			goType = "int64"
@@ -634,7 +1955,15 @@ func (sg *SchemaGenerator) mysqlTypeToGoType(mysqlType string, nullable bool, is
 		} else {
 			goType = "float32"
 		}
-	case "double", "decimal", "numeric":
+	case "decimal", "numeric":
+		if cfg != nil && cfg.ExactDecimals {
+			goType = "types.Decimal"
+		} else if nullable {
+			goType = "sql.NullFloat64"
+		} else {
+			goType = "float64"
+		}
+	case "double":
 		if nullable {
 			goType = "sql.NullFloat64"
 		} else {
@@ -646,10 +1975,20 @@ func (sg *SchemaGenerator) mysqlTypeToGoType(mysqlType string, nullable bool, is
 		} else {
 			goType = "string"
 		}
-	case "binary", "varbinary", "blob", "tinyblob", "mediumblob", "longblob":
+	case "binary", "varbinary":
 		goType = "[]byte"
+	case "blob", "tinyblob", "mediumblob", "longblob":
+		if cfg != nil && cfg.BlobColumns {
+			goType = "types.Blob"
+		} else {
+			goType = "[]byte"
+		}
 	case "date", "datetime", "timestamp":
-		if nullable {
+		if !strings.EqualFold(baseType, "date") && cfg != nil && matchColumn(cfg.TimestampColumns, columnName) {
+			goType = "types.Timestamp"
+		} else if cfg != nil && cfg.TolerantDateTimes {
+			goType = "types.DateTime"
+		} else if nullable {
 			goType = "sql.NullTime"
 		} else {
 			goType = "time.Time"
@@ -666,12 +2005,34 @@ func (sg *SchemaGenerator) mysqlTypeToGoType(mysqlType string, nullable bool, is
 		} else {
 			goType = "int32"
 		}
-	case "bit", "bool", "boolean":
+	case "bit":
+		if parseBitWidth(mysqlType) == 1 {
+			if nullable {
+				goType = "sql.NullBool"
+			} else {
+				goType = "bool"
+			}
+		} else if cfg != nil && cfg.BitMode == BitModeBytes {
+			goType = "[]byte"
+		} else {
+			goType = "types.Bits"
+		}
+	case "bool", "boolean":
 		if nullable {
 			goType = "sql.NullBool"
 		} else {
 			goType = "bool"
 		}
+	case "uuid":
+		if cfg != nil && cfg.UUIDMode == UUIDModeString {
+			if nullable {
+				goType = "sql.NullString"
+			} else {
+				goType = "string"
+			}
+		} else {
+			goType = "types.UUID"
+		}
 	case "json":
 		goType = "[]byte" // Simplified for standalone package
 	case "point":
@@ -680,7 +2041,7 @@ func (sg *SchemaGenerator) mysqlTypeToGoType(mysqlType string, nullable bool, is
 		goType = "[]byte"
 	case "vector":
 		// Parse vector type to determine element type and dimension
-		elementType := sg.parseVectorElementType(mysqlType)
+		elementType := vectorElementType(mysqlType)
 		switch elementType {
 		case "float":
 			goType = "types.Vector[float32]"
@@ -705,17 +2066,46 @@ func (sg *SchemaGenerator) mysqlTypeToGoType(mysqlType string, nullable bool, is
 	return goType
 }
 
-// getCustomImports returns all unique import paths needed for custom JSON mappings
-func (sg *SchemaGenerator) getCustomImports() []string {
-	if sg.config == nil {
-		return nil
+// parseBitWidth returns the width n of a BIT(n) type definition, which is 1
+// when the width is omitted or cannot be parsed
+func parseBitWidth(bitType string) int {
+	start := strings.Index(bitType, "(")
+	end := strings.LastIndex(bitType, ")")
+	if start == -1 || end <= start {
+		return 1
+	}
+
+	width, err := strconv.Atoi(strings.TrimSpace(bitType[start+1 : end]))
+	if err != nil || width < 1 {
+		return 1
 	}
-	return sg.config.GetRequiredImports()
+	return width
+}
+
+// vectorDimension returns the dimension of a VECTOR type definition, e.g.
+// "vector(1024)" -> 1024, or 0 for other types and unparseable or zero dimensions
+func vectorDimension(columnType string) int {
+	params, ok := strings.CutPrefix(strings.ToLower(columnType), "vector(")
+	if !ok {
+		return 0
+	}
+	params, _, _ = strings.Cut(params, ")")
+	params, _, _ = strings.Cut(params, ",")
+
+	dimension, err := strconv.Atoi(strings.TrimSpace(params))
+	if err != nil || dimension < 0 {
+		return 0
+	}
+	return dimension
 }
 
-// parseVectorElementType extracts the element type from a VECTOR type definition
-// e.g., "vector(128,float)" -> "float", "vector(256,double)" -> "double", "vector(1024)" -> "float" (default)
 func (sg *SchemaGenerator) parseVectorElementType(vectorType string) string {
+	return vectorElementType(vectorType)
+}
+
+// vectorElementType extracts the element type from a VECTOR type definition
+// e.g., "vector(128,float)" -> "float", "vector(256,double)" -> "double", "vector(1024)" -> "float" (default)
+func vectorElementType(vectorType string) string {
 	// vectorType looks like: vector(dimension,element_type) or vector(dimension)
 	if !strings.HasPrefix(strings.ToLower(vectorType), "vector(") {
 		return "float" // Default to float (MariaDB default)
@@ -1,7 +1,16 @@
 package schema
 
 import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestParseVectorElementType(t *testing.T) {
@@ -62,6 +71,142 @@ func TestMysqlTypeToGoType_Boolean(t *testing.T) {
 	}
 }
 
+func TestMysqlTypeToGoType_PointerMode(t *testing.T) {
+	sg := &SchemaGenerator{config: &Config{NullableMode: NullableModePointer}}
+
+	tests := []struct {
+		mysqlType string
+		nullable  bool
+		expected  string
+	}{
+		{"tinyint(1)", true, "*bool"},
+		{"tinyint(1)", false, "bool"},
+		{"tinyint(4)", true, "*int32"},
+		{"bit(1)", true, "*bool"},
+		{"float", true, "*float32"},
+		{"datetime", true, "*time.Time"},
+		{"varchar(255)", true, "*string"},
+		{"enum('a','b')", true, "*string"},
+		{"varbinary(16)", true, "[]byte"},
+		{"vector(3)", true, "types.Vector[float32]"},
+	}
+
+	for _, test := range tests {
+		result := sg.mysqlTypeToGoType(test.mysqlType, test.nullable, false, "test_table", "test_column")
+		if result != test.expected {
+			t.Errorf("mysqlTypeToGoType(%q, nullable=%t) in pointer mode = %q, expected %q",
+				test.mysqlType, test.nullable, result, test.expected)
+		}
+	}
+
+	if result := sg.mysqlTypeToGoType("longtext", true, true, "test_table", "test_column"); result != "types.JSON[any]" {
+		t.Errorf("mysqlTypeToGoType() of nullable JSON in pointer mode = %q, expected types.JSON[any]", result)
+	}
+
+	table := &TableInfo{
+		Name: "flags",
+		Columns: []ColumnInfo{
+			{Name: "id", Type: "int(11)"},
+			{Name: "enabled", Type: "tinyint(1)", Nullable: true},
+			{Name: "updated_at", Type: "datetime", Nullable: true},
+		},
+		PrimaryKeys: []string{"id"},
+	}
+	files, err := NewSchemaGeneratorFromSource(newMemorySource(table), sg.config).GenerateAll(context.Background(), "main")
+	if err != nil {
+		t.Fatalf("GenerateAll() error: %v", err)
+	}
+	if !strings.Contains(files["structs.go"], "Enabled *bool") {
+		t.Errorf("structs.go missing Enabled *bool in:\n%s", files["structs.go"])
+	}
+	// The placeholder for schemas without enums has no package clause
+	delete(files, "enum_constants.go")
+	runGenerated(t, files, "package main\n\nfunc main() {}\n")
+}
+
+func TestMysqlTypeToGoType_UnsignedDefault(t *testing.T) {
+	sg := &SchemaGenerator{}
+
+	// Without unsigned_integers the attributes are stripped but the signed
+	// mappings are kept
+	tests := []struct {
+		mysqlType string
+		nullable  bool
+		expected  string
+	}{
+		{"int unsigned", false, "int32"},
+		{"int(10) unsigned", false, "int32"},
+		{"int(10) unsigned zerofill", false, "int32"},
+		{"bigint unsigned", false, "int64"},
+		{"bigint(20) unsigned", false, "int64"},
+		{"int(10) unsigned", true, "sql.NullInt32"},
+		{"bigint(20) unsigned", true, "sql.NullInt64"},
+		{"tinyint(1) unsigned", false, "int32"},
+		{"tinyint(1) unsigned", true, "sql.NullInt32"},
+		{"tinyint(1) zerofill", false, "int32"},
+		{"tinyint(1)", false, "bool"},
+	}
+
+	for _, test := range tests {
+		result := sg.mysqlTypeToGoType(test.mysqlType, test.nullable, false, "test_table", "test_column")
+		if result != test.expected {
+			t.Errorf("mysqlTypeToGoType(%q, nullable=%t) = %q, expected %q",
+				test.mysqlType, test.nullable, result, test.expected)
+		}
+	}
+}
+
+func TestMysqlTypeToGoType_UnsignedZerofill(t *testing.T) {
+	sg := &SchemaGenerator{config: &Config{UnsignedIntegers: true}}
+
+	tests := []struct {
+		mysqlType string
+		nullable  bool
+		expected  string
+	}{
+		{"int(10) unsigned zerofill", false, "uint32"},
+		{"int(11) zerofill", false, "uint32"},
+		{"int unsigned", true, "sql.Null[uint32]"},
+		{"tinyint(3) unsigned", false, "uint32"},
+		{"tinyint(1) unsigned", false, "uint32"},
+		{"tinyint(1)", true, "sql.NullBool"},
+		{"bigint(20) unsigned", false, "uint64"},
+		{"bigint(20) UNSIGNED", true, "sql.Null[uint64]"},
+		{"int(11) signed", false, "int32"},
+		{"decimal(10,2) unsigned zerofill", false, "float64"},
+		{"double unsigned", true, "sql.NullFloat64"},
+	}
+
+	for _, test := range tests {
+		result := sg.mysqlTypeToGoType(test.mysqlType, test.nullable, false, "test_table", "test_column")
+		if result != test.expected {
+			t.Errorf("mysqlTypeToGoType(%q, nullable=%t) = %q, expected %q",
+				test.mysqlType, test.nullable, result, test.expected)
+		}
+	}
+
+	pointers := &SchemaGenerator{config: &Config{NullableMode: NullableModePointer, UnsignedIntegers: true}}
+	if result := pointers.mysqlTypeToGoType("int(10) unsigned zerofill", true, false, "test_table", "test_column"); result != "*uint32" {
+		t.Errorf("mysqlTypeToGoType() of nullable unsigned int in pointer mode = %q, expected *uint32", result)
+	}
+
+	table := &TableInfo{
+		Name: "counters",
+		Columns: []ColumnInfo{
+			{Name: "id", Type: "int(10) unsigned zerofill"},
+			{Name: "hits", Type: "bigint(20) unsigned", Nullable: true},
+		},
+		PrimaryKeys: []string{"id"},
+	}
+	files, err := NewSchemaGeneratorFromSource(newMemorySource(table), &Config{UnsignedIntegers: true}).GenerateAll(context.Background(), "main")
+	if err != nil {
+		t.Fatalf("GenerateAll() error: %v", err)
+	}
+	// The placeholder for schemas without enums has no package clause
+	delete(files, "enum_constants.go")
+	runGenerated(t, files, "package main\n\nfunc main() {}\n")
+}
+
 func TestMysqlTypeToGoType_Vector(t *testing.T) {
 	sg := &SchemaGenerator{}
 
@@ -112,3 +257,1035 @@ func TestToColumnTypeName(t *testing.T) {
 		}
 	}
 }
+
+func TestGenerateEnumBlock_AllowedValues(t *testing.T) {
+	sg := &SchemaGenerator{}
+
+	values := sg.parseEnumValues("enum('active','inactive','banned')")
+	enum := EnumInfo{TableName: "users", ColumnName: "status", Values: values}
+	result := sg.generateEnumBlock("users", enum)
+
+	expected := `var UsersStatusAllowed = []string{"active", "inactive", "banned"}`
+	if !strings.Contains(result, expected) {
+		t.Errorf("generateEnumBlock() missing allowed-values slice %q in:\n%s", expected, result)
+	}
+
+	for _, constant := range []string{`Users_Status_Active = "active"`, `Users_Status_Inactive = "inactive"`, `Users_Status_Banned = "banned"`} {
+		if !strings.Contains(result, constant) {
+			t.Errorf("generateEnumBlock() missing constant %q in:\n%s", constant, result)
+		}
+	}
+}
+
+func TestGenerateStructs_ContinueOnError(t *testing.T) {
+	source := newMemorySource(&TableInfo{
+		Name:        "users",
+		Columns:     []ColumnInfo{{Name: "id", Type: "int(11)"}},
+		PrimaryKeys: []string{"id"},
+	})
+	source.errors["secrets"] = errors.New("SELECT command denied")
+
+	// Without ContinueOnError the failing table aborts generation
+	sg := NewSchemaGeneratorFromSource(source, &Config{})
+	if _, err := sg.GenerateStructs(context.Background(), "models"); err == nil {
+		t.Error("GenerateStructs() expected error for failing table, got nil")
+	}
+
+	sg = NewSchemaGeneratorFromSource(source, &Config{ContinueOnError: true})
+	result, err := sg.GenerateStructs(context.Background(), "models")
+	if err != nil {
+		t.Fatalf("GenerateStructs() with ContinueOnError error: %v", err)
+	}
+
+	if !strings.Contains(result, "type Users struct") {
+		t.Errorf("GenerateStructs() missing struct for healthy table in:\n%s", result)
+	}
+	if strings.Contains(result, "Secrets") {
+		t.Errorf("GenerateStructs() should skip failing table in:\n%s", result)
+	}
+
+	tableErrors := sg.TableErrors()
+	if len(tableErrors) != 1 || tableErrors[0].Table != "secrets" {
+		t.Errorf("TableErrors() = %v, expected a single error for table secrets", tableErrors)
+	}
+}
+
+func TestGenerateColumnNameTypes(t *testing.T) {
+	source := newMemorySource(&TableInfo{
+		Name: "user_profiles",
+		Columns: []ColumnInfo{
+			{Name: "id", Type: "int(11)"},
+			{Name: "display_name", Type: "varchar(255)"},
+			{Name: "avatar_url", Type: "varchar(255)"},
+			{Name: "idle", Type: "tinyint(1)"},
+		},
+	})
+	sg := NewSchemaGeneratorFromSource(source, nil)
+
+	result, err := sg.GenerateColumnNameTypes(context.Background(), "models")
+	if err != nil {
+		t.Fatalf("GenerateColumnNameTypes() error: %v", err)
+	}
+
+	// Constants share the table naming used by toColumnTypeName, while their
+	// column part upper-cases initialisms
+	if typeName := sg.toColumnTypeName("user_profiles", "id"); typeName != "UserProfiles_Id" {
+		t.Fatalf("toColumnTypeName() = %q, expected %q", typeName, "UserProfiles_Id")
+	}
+
+	expected := []string{
+		"type UserProfilesColumn string",
+		`UserProfilesColumnID UserProfilesColumn = "id"`,
+		`UserProfilesColumnDisplayName UserProfilesColumn = "display_name"`,
+		`UserProfilesColumnAvatarURL UserProfilesColumn = "avatar_url"`,
+		`UserProfilesColumnIdle UserProfilesColumn = "idle"`,
+	}
+	for _, exp := range expected {
+		if !strings.Contains(result, exp) {
+			t.Errorf("GenerateColumnNameTypes() missing %q in:\n%s", exp, result)
+		}
+	}
+}
+
+func TestGroupIndexColumns_Spatial(t *testing.T) {
+	indexes := groupIndexColumns([]indexColumn{
+		{IndexName: "PRIMARY", ColumnName: "id", NonUnique: false, IndexType: "BTREE"},
+		{IndexName: "idx_position", ColumnName: "position", NonUnique: true, IndexType: "SPATIAL"},
+		{IndexName: "uniq_name_city", ColumnName: "name", NonUnique: false, IndexType: "BTREE"},
+		{IndexName: "uniq_name_city", ColumnName: "city", NonUnique: false, IndexType: "BTREE"},
+	})
+
+	if len(indexes) != 3 {
+		t.Fatalf("groupIndexColumns() returned %d indexes, expected 3", len(indexes))
+	}
+
+	if !indexes[1].IsSpatial() || indexes[1].Unique {
+		t.Errorf("index %q: IsSpatial() = %t, Unique = %t, expected spatial non-unique", indexes[1].Name, indexes[1].IsSpatial(), indexes[1].Unique)
+	}
+
+	if got := strings.Join(indexes[2].Columns, ","); got != "name,city" || !indexes[2].Unique {
+		t.Errorf("index %q columns = %q, unique = %t, expected \"name,city\" unique", indexes[2].Name, got, indexes[2].Unique)
+	}
+
+	table := &TableInfo{
+		Name: "locations",
+		Columns: []ColumnInfo{
+			{Name: "id", Type: "int(11)"},
+			{Name: "position", Type: "point"},
+			{Name: "area", Type: "polygon"},
+		},
+		Indexes: indexes,
+	}
+
+	if !table.HasSpatialIndex("position") {
+		t.Error("HasSpatialIndex(\"position\") = false, expected true")
+	}
+	if table.HasSpatialIndex("area") {
+		t.Error("HasSpatialIndex(\"area\") = true, expected false")
+	}
+
+	sg := NewSchemaGeneratorFromSource(newMemorySource(table), nil)
+	result, err := sg.GenerateStructs(context.Background(), "models")
+	if err != nil {
+		t.Fatalf("GenerateStructs() error: %v", err)
+	}
+	if !strings.Contains(result, "`db:\"position\"` // spatial index") {
+		t.Errorf("GenerateStructs() missing spatial index comment in:\n%s", result)
+	}
+}
+
+func TestParseExtra(t *testing.T) {
+	sg := &SchemaGenerator{}
+
+	tests := []struct {
+		extra         string
+		generated     bool
+		autoIncrement bool
+		autoUpdate    bool
+		genType       string
+	}{
+		{"auto_increment", false, true, false, ""},
+		{"on update current_timestamp()", false, false, true, ""},
+		{"DEFAULT_GENERATED on update CURRENT_TIMESTAMP", false, false, true, ""},
+		{"STORED GENERATED", true, false, false, "STORED"},
+		{"VIRTUAL GENERATED", true, false, false, "VIRTUAL"},
+		{"", false, false, false, ""},
+	}
+
+	for _, test := range tests {
+		col := ColumnInfo{IsGenerated: test.generated}
+		sg.parseExtra(&col, test.extra)
+		if col.AutoIncrement != test.autoIncrement || col.AutoUpdate != test.autoUpdate || col.GenerationType.String != test.genType {
+			t.Errorf("parseExtra(%q) = auto_increment %t, auto-update %t, generation %q, expected %t, %t, %q",
+				test.extra, col.AutoIncrement, col.AutoUpdate, col.GenerationType.String,
+				test.autoIncrement, test.autoUpdate, test.genType)
+		}
+	}
+}
+
+func TestGenerateColumnConstants_GroupColumns(t *testing.T) {
+	source := newMemorySource(&TableInfo{
+		Name: "users",
+		Columns: []ColumnInfo{
+			{Name: "id", Type: "int(11)"},
+			{Name: "first_name", Type: "varchar(255)"},
+			{Name: "full_name", Type: "varchar(511)", IsGenerated: true},
+			{Name: "email", Type: "varchar(255)"},
+		},
+		PrimaryKeys: []string{"id"},
+	})
+	sg := NewSchemaGeneratorFromSource(source, &Config{GroupColumns: true})
+
+	result, err := sg.GenerateColumnConstants(context.Background(), "models")
+	if err != nil {
+		t.Fatalf("GenerateColumnConstants() error: %v", err)
+	}
+
+	expected := "const (\n" +
+		"\t// primary keys\n" +
+		"\tUsers_Id_Name = \"id\"\n" +
+		"\n" +
+		"\t// generated\n" +
+		"\tUsers_FullName_Name = \"full_name\"\n" +
+		"\n" +
+		"\t// columns\n" +
+		"\tUsers_FirstName_Name = \"first_name\"\n" +
+		"\tUsers_Email_Name = \"email\"\n" +
+		")\n"
+	if !strings.Contains(result, expected) {
+		t.Errorf("GenerateColumnConstants() with GroupColumns = \n%s\nexpected block:\n%s", result, expected)
+	}
+}
+
+func TestParseExtra_Invisible(t *testing.T) {
+	sg := &SchemaGenerator{}
+
+	tests := []struct {
+		extra      string
+		expression string
+		expected   bool
+	}{
+		{"INVISIBLE", "", true},
+		{"STORED GENERATED INVISIBLE", "ROW START", true},
+		{"STORED GENERATED", "ROW END", true},
+		{"STORED GENERATED", "concat(`a`,`b`)", false},
+		{"", "", false},
+	}
+
+	for _, test := range tests {
+		col := ColumnInfo{IsGenerated: test.expression != ""}
+		col.GenerationExpression.String = test.expression
+		col.GenerationExpression.Valid = test.expression != ""
+		sg.parseExtra(&col, test.extra)
+		if col.Invisible != test.expected {
+			t.Errorf("parseExtra(%q, expression %q) Invisible = %t, expected %t", test.extra, test.expression, col.Invisible, test.expected)
+		}
+	}
+}
+
+func TestGenerateStructs_SkipInvisible(t *testing.T) {
+	table := &TableInfo{
+		Name: "users",
+		Columns: []ColumnInfo{
+			{Name: "id", Type: "int(11)"},
+			{Name: "row_start", Type: "timestamp(6)", Invisible: true},
+			{Name: "name", Type: "varchar(255)"},
+		},
+	}
+
+	sg := NewSchemaGeneratorFromSource(newMemorySource(table), nil)
+	result, err := sg.GenerateStructs(context.Background(), "models")
+	if err != nil {
+		t.Fatalf("GenerateStructs() error: %v", err)
+	}
+	if strings.Contains(result, "RowStart") {
+		t.Errorf("GenerateStructs() should skip invisible column by default:\n%s", result)
+	}
+	if !strings.Contains(result, "Name string") {
+		t.Errorf("GenerateStructs() missing visible column:\n%s", result)
+	}
+	if len(table.Columns) != 3 {
+		t.Errorf("skipping invisible columns modified the source table: %v", table.Columns)
+	}
+
+	skip := false
+	sg = NewSchemaGeneratorFromSource(newMemorySource(table), &Config{SkipInvisible: &skip})
+	result, err = sg.GenerateStructs(context.Background(), "models")
+	if err != nil {
+		t.Fatalf("GenerateStructs() error: %v", err)
+	}
+	if !strings.Contains(result, "RowStart") {
+		t.Errorf("GenerateStructs() with SkipInvisible=false should keep invisible column:\n%s", result)
+	}
+}
+
+func TestJSONDataType(t *testing.T) {
+	tests := []struct {
+		goType   string
+		expected string
+		ok       bool
+	}{
+		{"types.JSON[Settings]", "Settings", true},
+		{"types.JSON[map[string]any]", "map[string]any", true},
+		{"types.JSON[models.Profile]", "models.Profile", true},
+		{"types.JSON[any]", "any", true},
+		{"types.PreciseJSON[any]", "any", true},
+		{"types.NullJSON[Settings]", "Settings", true},
+		{"models.Profile", "", false},
+		{"map[string]interface{}", "", false},
+		{"other.JSON[Settings]", "", false},
+	}
+
+	for _, test := range tests {
+		result, ok := jsonDataType(test.goType)
+		if result != test.expected || ok != test.ok {
+			t.Errorf("jsonDataType(%q) = %q, %v, expected %q, %v", test.goType, result, ok, test.expected, test.ok)
+		}
+	}
+}
+
+func TestGenerateStructs_JSONAccessor(t *testing.T) {
+	table := &TableInfo{
+		Name: "users",
+		Columns: []ColumnInfo{
+			{Name: "id", Type: "int(11)"},
+			{Name: "settings", Type: "longtext", IsJSON: true},
+			{Name: "raw", Type: "longtext", IsJSON: true},
+		},
+	}
+	config := &Config{JSONMappings: map[string]JSONMapping{
+		"users.settings": {Type: "types.JSON[Settings]", Import: "github.com/louis77/mariakit/types"},
+	}}
+	if err := config.Validate(); err != nil {
+		t.Fatalf("Validate() error: %v", err)
+	}
+	sg := NewSchemaGeneratorFromSource(newMemorySource(table), config)
+
+	result, err := sg.GenerateStructs(context.Background(), "models")
+	if err != nil {
+		t.Fatalf("GenerateStructs() error: %v", err)
+	}
+
+	expected := "func (u Users) SettingsValue() Settings {\n" +
+		"\tif !u.Settings.Valid {\n" +
+		"\t\tvar zero Settings\n" +
+		"\t\treturn zero\n" +
+		"\t}\n" +
+		"\treturn u.Settings.Data\n" +
+		"}\n"
+	if !strings.Contains(result, expected) {
+		t.Errorf("GenerateStructs() missing accessor %q in:\n%s", expected, result)
+	}
+	if strings.Contains(result, "RawValue") {
+		t.Errorf("GenerateStructs() generated an accessor for an unmapped JSON column:\n%s", result)
+	}
+}
+
+func TestGenerateStructs_JSONAccessorFieldCollision(t *testing.T) {
+	table := &TableInfo{
+		Name: "users",
+		Columns: []ColumnInfo{
+			{Name: "id", Type: "int(11)"},
+			{Name: "settings", Type: "longtext", IsJSON: true},
+			{Name: "settings_value", Type: "varchar(255)"},
+		},
+	}
+	config := &Config{JSONMappings: map[string]JSONMapping{
+		"users.settings": {Type: "types.JSON[map[string]any]", Import: "github.com/louis77/mariakit/types"},
+	}}
+	if err := config.Validate(); err != nil {
+		t.Fatalf("Validate() error: %v", err)
+	}
+	sg := NewSchemaGeneratorFromSource(newMemorySource(table), config)
+
+	result, err := sg.GenerateStructs(context.Background(), "main")
+	if err != nil {
+		t.Fatalf("GenerateStructs() error: %v", err)
+	}
+	if strings.Contains(result, "SettingsValue()") {
+		t.Errorf("GenerateStructs() generated an accessor colliding with the SettingsValue field:\n%s", result)
+	}
+	if warnings := sg.Warnings(); len(warnings) != 1 || !strings.Contains(warnings[0], "SettingsValue") {
+		t.Errorf("Warnings() = %q, expected a warning about the skipped accessor", warnings)
+	}
+
+	runGenerated(t, map[string]string{"structs.go": result}, `package main
+
+func main() {
+	_ = Users{SettingsValue: "x"}
+}
+`)
+}
+
+// failingConnector is a driver.Connector whose connections always fail
+type failingConnector struct{}
+
+func (failingConnector) Connect(context.Context) (driver.Conn, error) {
+	return nil, errors.New("no connection")
+}
+
+func (failingConnector) Driver() driver.Driver {
+	return nil
+}
+
+func TestNewSchemaGeneratorFromDB(t *testing.T) {
+	db := sql.OpenDB(failingConnector{})
+	defer db.Close()
+
+	sg := NewSchemaGeneratorFromDB(db, nil)
+	if sg.db != db {
+		t.Fatal("NewSchemaGeneratorFromDB() did not reuse the passed pool")
+	}
+
+	if err := sg.Close(); err != nil {
+		t.Fatalf("Close() error: %v", err)
+	}
+
+	// The pool is still open, so using it reaches the connector instead of
+	// failing with sql: database is closed
+	_, err := db.Conn(context.Background())
+	if err == nil || err.Error() != "no connection" {
+		t.Errorf("db.Conn() after Close() error = %v, expected the connector error", err)
+	}
+}
+
+func TestGenerateStructs_Constructor(t *testing.T) {
+	table := &TableInfo{
+		Name: "users",
+		Columns: []ColumnInfo{
+			{Name: "id", Type: "int(11)", AutoIncrement: true},
+			{Name: "name", Type: "varchar(255)"},
+			{Name: "email", Type: "varchar(255)", Nullable: true},
+			{Name: "type", Type: "varchar(20)"},
+			{Name: "created_at", Type: "datetime", DefaultValue: sql.NullString{String: "current_timestamp()", Valid: true}},
+			{Name: "name_length", Type: "int(11)", IsGenerated: true},
+		},
+		PrimaryKeys: []string{"id"},
+	}
+	sg := NewSchemaGeneratorFromSource(newMemorySource(table), nil)
+
+	result, err := sg.GenerateStructs(context.Background(), "main")
+	if err != nil {
+		t.Fatalf("GenerateStructs() error: %v", err)
+	}
+
+	signature := "func NewUsers(name string, type_ string) Users {"
+	if !strings.Contains(result, signature) {
+		t.Errorf("GenerateStructs() missing %q in:\n%s", signature, result)
+	}
+
+	output := runGenerated(t, map[string]string{"structs.go": result}, `package main
+
+import "fmt"
+
+func main() {
+	user := NewUsers("alice", "admin")
+	fmt.Printf("%d %q %v %q %v %d\n", user.Id, user.Name, user.Email.Valid, user.Type, user.CreatedAt.IsZero(), user.NameLength)
+}
+`)
+	if expected := "0 \"alice\" false \"admin\" true 0\n"; output != expected {
+		t.Errorf("constructor output = %q, expected %q", output, expected)
+	}
+}
+
+func TestGenerateStructs_ConstructorInitialisms(t *testing.T) {
+	table := &TableInfo{
+		Name: "links",
+		Columns: []ColumnInfo{
+			{Name: "id", Type: "int(11)"},
+			{Name: "url", Type: "varchar(255)"},
+			{Name: "owner_id", Type: "int(11)"},
+			{Name: "url_path", Type: "varchar(255)"},
+		},
+		PrimaryKeys: []string{"id"},
+	}
+	config := &Config{FieldRenames: map[string]string{
+		"links.id":       "ID",
+		"links.url":      "URL",
+		"links.owner_id": "OwnerID",
+		"links.url_path": "URLPath",
+	}}
+	sg := NewSchemaGeneratorFromSource(newMemorySource(table), config)
+
+	result, err := sg.GenerateStructs(context.Background(), "main")
+	if err != nil {
+		t.Fatalf("GenerateStructs() error: %v", err)
+	}
+
+	for _, expected := range []string{
+		"// NewLinks returns a new Links with its required columns set.",
+		"func NewLinks(id int32, url string, ownerID int32, urlPath string) Links {",
+	} {
+		if !strings.Contains(result, expected) {
+			t.Errorf("GenerateStructs() missing %q in:\n%s", expected, result)
+		}
+	}
+
+	output := runGenerated(t, map[string]string{"structs.go": result}, `package main
+
+import "fmt"
+
+func main() {
+	link := NewLinks(1, "https://example.com", 2, "/docs")
+	fmt.Println(link.ID, link.URL, link.OwnerID, link.URLPath)
+}
+`)
+	if expected := "1 https://example.com 2 /docs\n"; output != expected {
+		t.Errorf("constructor output = %q, expected %q", output, expected)
+	}
+}
+
+func TestGetTableInfo_VectorDimension(t *testing.T) {
+	db := newFakeDB(map[string]fakeResult{
+		"SELECT VERSION()": fakeVersion,
+		"information_schema.COLUMNS": {
+			columns: []string{"COLUMN_NAME", "COLUMN_TYPE", "IS_NULLABLE", "COLUMN_DEFAULT", "COLUMN_COMMENT", "IS_GENERATED", "GENERATION_EXPRESSION", "EXTRA", "CHARACTER_MAXIMUM_LENGTH"},
+			rows: [][]driver.Value{
+				{"id", "int(11)", "NO", nil, "", "NO", nil, "auto_increment", nil},
+				{"embedding", "vector(1024)", "NO", nil, "", "NO", nil, "", nil},
+				{"broken", "vector(x)", "YES", nil, "", "NO", nil, "", nil},
+			},
+		},
+	})
+	defer db.Close()
+	sg := NewSchemaGeneratorFromDB(db, nil)
+
+	tableInfo, err := sg.GetTableInfo(context.Background(), "documents")
+	if err != nil {
+		t.Fatalf("GetTableInfo() error: %v", err)
+	}
+
+	expected := []int{0, 1024, 0}
+	for i, col := range tableInfo.Columns {
+		if col.VectorDimension != expected[i] {
+			t.Errorf("VectorDimension of %s = %d, expected %d", col.Name, col.VectorDimension, expected[i])
+		}
+	}
+
+	if comments := sg.columnComments(tableInfo, tableInfo.Columns[1]); len(comments) != 1 || comments[0] != "dim=1024" {
+		t.Errorf("columnComments() = %v, expected [dim=1024]", comments)
+	}
+	if goType, _ := sg.goType(tableInfo, tableInfo.Columns[2]); goType != "types.Vector[float32]" {
+		t.Errorf("goType() of unparseable vector = %q, expected types.Vector[float32]", goType)
+	}
+
+	for columnType, dimension := range map[string]int{"vector(128,double)": 128, "VECTOR(3)": 3, "vector(0)": 0, "vector": 0, "int(11)": 0} {
+		if result := vectorDimension(columnType); result != dimension {
+			t.Errorf("vectorDimension(%q) = %d, expected %d", columnType, result, dimension)
+		}
+	}
+}
+
+func TestGetTableInfo_MaxLength(t *testing.T) {
+	db := newFakeDB(map[string]fakeResult{
+		"SELECT VERSION()": fakeVersion,
+		"information_schema.COLUMNS": {
+			columns: []string{"COLUMN_NAME", "COLUMN_TYPE", "IS_NULLABLE", "COLUMN_DEFAULT", "COLUMN_COMMENT", "IS_GENERATED", "GENERATION_EXPRESSION", "EXTRA", "CHARACTER_MAXIMUM_LENGTH"},
+			rows: [][]driver.Value{
+				{"id", "int(11)", "NO", nil, "", "NO", nil, "auto_increment", nil},
+				{"email", "varchar(255)", "NO", nil, "", "NO", nil, "", int64(255)},
+			},
+		},
+	})
+	defer db.Close()
+	sg := NewSchemaGeneratorFromDB(db, &Config{GenerateValidatorTags: true})
+
+	tableInfo, err := sg.GetTableInfo(context.Background(), "users")
+	if err != nil {
+		t.Fatalf("GetTableInfo() error: %v", err)
+	}
+	if len(tableInfo.Columns) != 2 {
+		t.Fatalf("GetTableInfo() returned %d columns, expected 2", len(tableInfo.Columns))
+	}
+
+	if id := tableInfo.Columns[0]; id.MaxLength.Valid {
+		t.Errorf("MaxLength of int column = %v, expected NULL", id.MaxLength)
+	}
+	email := tableInfo.Columns[1]
+	if !email.MaxLength.Valid || email.MaxLength.Int64 != 255 {
+		t.Errorf("MaxLength of varchar(255) column = %v, expected 255", email.MaxLength)
+	}
+
+	if comments := sg.columnComments(tableInfo, email); len(comments) != 1 || comments[0] != "max length 255" {
+		t.Errorf("columnComments() = %v, expected [max length 255]", comments)
+	}
+	if tag := sg.structTag(tableInfo, email); tag != `db:"email" validate:"required,max=255"` {
+		t.Errorf("structTag() = %q", tag)
+	}
+}
+
+func TestGetTableInfo_SystemVersioned(t *testing.T) {
+	db := newFakeDB(map[string]fakeResult{
+		"SELECT VERSION()": fakeVersion,
+		"information_schema.COLUMNS": {
+			columns: []string{"COLUMN_NAME", "COLUMN_TYPE", "IS_NULLABLE", "COLUMN_DEFAULT", "COLUMN_COMMENT", "IS_GENERATED", "GENERATION_EXPRESSION", "EXTRA", "CHARACTER_MAXIMUM_LENGTH"},
+			rows: [][]driver.Value{
+				{"id", "int(11)", "NO", nil, "", "NO", nil, "", nil},
+				{"row_start", "timestamp(6)", "NO", nil, "", "ALWAYS", "ROW START", "INVISIBLE", nil},
+				{"row_end", "timestamp(6)", "NO", nil, "", "ALWAYS", "ROW END", "INVISIBLE", nil},
+			},
+		},
+		"information_schema.TABLES": {
+			columns: []string{"TABLE_TYPE"},
+			rows:    [][]driver.Value{{"SYSTEM VERSIONED"}},
+		},
+	})
+	defer db.Close()
+	sg := NewSchemaGeneratorFromDB(db, nil)
+
+	tableInfo, err := sg.GetTableInfo(context.Background(), "prices")
+	if err != nil {
+		t.Fatalf("GetTableInfo() error: %v", err)
+	}
+	if !tableInfo.IsVersioned {
+		t.Error("GetTableInfo() IsVersioned = false for a system-versioned table, expected true")
+	}
+
+	result, err := NewSchemaGeneratorFromSource(newMemorySource(tableInfo), nil).GenerateStructs(context.Background(), "models")
+	if err != nil {
+		t.Fatalf("GenerateStructs() error: %v", err)
+	}
+	expected := "// Prices represents the prices table\n//\n// The table is system-versioned; query past rows with FOR SYSTEM_TIME.\ntype Prices struct {"
+	if !strings.Contains(result, expected) {
+		t.Errorf("GenerateStructs() missing %q in:\n%s", expected, result)
+	}
+	if strings.Contains(result, "RowStart") {
+		t.Errorf("GenerateStructs() should skip the period columns:\n%s", result)
+	}
+}
+
+func TestSetMemberBit(t *testing.T) {
+	sg := &SchemaGenerator{}
+	col := ColumnInfo{Name: "flags", Type: "set('read','write','admin')", IsSet: true}
+	col.EnumValues = sg.parseEnumValues(col.Type)
+
+	if len(col.EnumValues) != 3 || col.EnumValues[0] != "read" || col.EnumValues[2] != "admin" {
+		t.Fatalf("parseEnumValues() = %v, expected [read write admin]", col.EnumValues)
+	}
+
+	tests := []struct {
+		value    string
+		expected int
+	}{
+		{"read", 1},
+		{"write", 2},
+		{"admin", 4},
+		{"missing", 0},
+	}
+	for _, test := range tests {
+		if bit := col.SetMemberBit(test.value); bit != test.expected {
+			t.Errorf("SetMemberBit(%q) = %d, expected %d", test.value, bit, test.expected)
+		}
+	}
+
+	comments := sg.columnComments(&TableInfo{Name: "users"}, col)
+	if len(comments) != 1 || comments[0] != "set bits: read=1, write=2, admin=4" {
+		t.Errorf("columnComments() = %v, expected [set bits: read=1, write=2, admin=4]", comments)
+	}
+}
+
+func TestMysqlTypeToGoType_Bit(t *testing.T) {
+	tests := []struct {
+		mysqlType string
+		nullable  bool
+		bitMode   string
+		expected  string
+	}{
+		{"bit(1)", false, "", "bool"},
+		{"bit(1)", true, "", "sql.NullBool"},
+		{"bit(8)", false, "", "types.Bits"},
+		{"bit(8)", true, "", "types.Bits"},
+		{"bit(64)", false, "", "types.Bits"},
+		{"bit(64)", false, BitModeUint, "types.Bits"},
+		{"bit(8)", false, BitModeBytes, "[]byte"},
+		{"bit(1)", false, BitModeBytes, "bool"},
+	}
+
+	for _, test := range tests {
+		sg := &SchemaGenerator{config: &Config{BitMode: test.bitMode}}
+		result := sg.mysqlTypeToGoType(test.mysqlType, test.nullable, false, "flags", "mask")
+		if result != test.expected {
+			t.Errorf("mysqlTypeToGoType(%q, nullable=%t, bit mode %q) = %q, expected %q",
+				test.mysqlType, test.nullable, test.bitMode, result, test.expected)
+		}
+	}
+}
+
+func TestNewSchemaGeneratorWithConfigContext_Deadline(t *testing.T) {
+	// A server that accepts connections but never sends the handshake
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("cannot listen: %v", err)
+	}
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err = NewSchemaGeneratorWithConfigContext(ctx, "user:pass@tcp("+listener.Addr().String()+")/app", nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("NewSchemaGeneratorWithConfigContext() error = %v, expected %v", err, context.DeadlineExceeded)
+	}
+	if elapsed := time.Since(start); elapsed > 5*time.Second {
+		t.Errorf("NewSchemaGeneratorWithConfigContext() returned after %v, expected the deadline to stop it", elapsed)
+	}
+}
+
+func TestGetTableInfo_JSONConstraint(t *testing.T) {
+	db := newFakeDB(map[string]fakeResult{
+		"SELECT VERSION()": fakeVersion,
+		"information_schema.COLUMNS": {
+			columns: []string{"COLUMN_NAME", "COLUMN_TYPE", "IS_NULLABLE", "COLUMN_DEFAULT", "COLUMN_COMMENT", "IS_GENERATED", "GENERATION_EXPRESSION", "EXTRA", "CHARACTER_MAXIMUM_LENGTH"},
+			rows: [][]driver.Value{
+				{"payload", "text", "YES", nil, "", "NO", nil, "", int64(65535)},
+				{"attributes", "longtext", "YES", nil, "", "NO", nil, "", nil},
+				{"id", "mediumtext", "YES", nil, "", "NO", nil, "", nil},
+			},
+		},
+		"CHECK_CLAUSE": {
+			columns: []string{"CHECK_CLAUSE"},
+			rows: [][]driver.Value{
+				{"json_valid(`payload`)"},
+				{"JSON_VALID(attributes)"},
+				{"json_valid(`payload_id`)"},
+			},
+		},
+	})
+	defer db.Close()
+	sg := NewSchemaGeneratorFromDB(db, nil)
+
+	tableInfo, err := sg.GetTableInfo(context.Background(), "events")
+	if err != nil {
+		t.Fatalf("GetTableInfo() error: %v", err)
+	}
+
+	expected := map[string]bool{"payload": true, "attributes": true, "id": false}
+	for _, col := range tableInfo.Columns {
+		if col.IsJSON != expected[col.Name] {
+			t.Errorf("IsJSON of %s %s = %t, expected %t", col.Type, col.Name, col.IsJSON, expected[col.Name])
+		}
+	}
+}
+
+func TestGenerateStructs_ScanRow(t *testing.T) {
+	table := &TableInfo{
+		Name: "users",
+		Columns: []ColumnInfo{
+			{Name: "id", Type: "int(11)"},
+			{Name: "name", Type: "varchar(255)"},
+			{Name: "email", Type: "varchar(255)", Nullable: true},
+		},
+		PrimaryKeys: []string{"id"},
+	}
+	sg := NewSchemaGeneratorFromSource(newMemorySource(table), nil)
+
+	result, err := sg.GenerateStructs(context.Background(), "main")
+	if err != nil {
+		t.Fatalf("GenerateStructs() error: %v", err)
+	}
+
+	signature := "func (u *Users) ScanRow(scanner interface{ Scan(...any) error }) error {"
+	if !strings.Contains(result, signature) {
+		t.Errorf("GenerateStructs() missing %q in:\n%s", signature, result)
+	}
+
+	output := runGenerated(t, map[string]string{"structs.go": result}, `package main
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// recordingScanner records the destinations and fills them like a driver
+type recordingScanner struct {
+	dest []any
+}
+
+func (r *recordingScanner) Scan(dest ...any) error {
+	r.dest = dest
+	*dest[0].(*int32) = 7
+	*dest[1].(*string) = "alice"
+	*dest[2].(*sql.NullString) = sql.NullString{String: "a@example.com", Valid: true}
+	return nil
+}
+
+func main() {
+	var user Users
+	scanner := &recordingScanner{}
+	if err := user.ScanRow(scanner); err != nil {
+		panic(err)
+	}
+	fmt.Println(len(scanner.dest), scanner.dest[0] == &user.Id, scanner.dest[1] == &user.Name, scanner.dest[2] == &user.Email)
+	fmt.Println(user.Id, user.Name, user.Email.String)
+}
+`)
+
+	if expected := "3 true true true\n7 alice a@example.com\n"; output != expected {
+		t.Errorf("ScanRow output = %q, expected %q", output, expected)
+	}
+}
+
+func TestGenerateStructs_WithoutPK(t *testing.T) {
+	tables := []*TableInfo{
+		{
+			Name: "users",
+			Columns: []ColumnInfo{
+				{Name: "id", Type: "int(11)", AutoIncrement: true},
+				{Name: "name", Type: "varchar(255)"},
+			},
+			PrimaryKeys: []string{"id"},
+		},
+		{
+			Name: "memberships",
+			Columns: []ColumnInfo{
+				{Name: "user_id", Type: "int(11)"},
+				{Name: "group_id", Type: "int(11)"},
+				{Name: "role", Type: "varchar(32)", Nullable: true},
+			},
+			PrimaryKeys: []string{"user_id", "group_id"},
+		},
+		{
+			Name:    "tags",
+			Columns: []ColumnInfo{{Name: "label", Type: "varchar(32)"}},
+		},
+	}
+	sg := NewSchemaGeneratorFromSource(newMemorySource(tables...), nil)
+
+	result, err := sg.GenerateStructs(context.Background(), "main")
+	if err != nil {
+		t.Fatalf("GenerateStructs() error: %v", err)
+	}
+
+	output := runGenerated(t, map[string]string{"structs.go": result}, `package main
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+func main() {
+	user := Users{Id: 7, Name: "alice"}
+	copied := user.WithoutPK()
+	fmt.Println(copied.Id, copied.Name, user.Id)
+
+	membership := Memberships{UserId: 1, GroupId: 2, Role: sql.NullString{String: "admin", Valid: true}}.WithoutPK()
+	fmt.Println(membership.UserId, membership.GroupId, membership.Role.String)
+
+	fmt.Println(Tags{Label: "go"}.WithoutPK().Label)
+}
+`)
+	expected := "0 alice 7\n0 0 admin\ngo\n"
+	if output != expected {
+		t.Errorf("WithoutPK() output = %q, expected %q", output, expected)
+	}
+}
+
+func TestGenerateColumnConstants_ColumnIndexes(t *testing.T) {
+	sg := NewSchemaGeneratorFromSource(newMemorySource(queriesTestTable()), nil)
+
+	constants, err := sg.GenerateColumnConstants(context.Background(), "main")
+	if err != nil {
+		t.Fatalf("GenerateColumnConstants() error: %v", err)
+	}
+	for i, name := range []string{"UsersColIdxId", "UsersColIdxName", "UsersColIdxEmail", "UsersColIdxNameUpper"} {
+		if exp := fmt.Sprintf("%s = %d", name, i); !strings.Contains(constants, exp) {
+			t.Errorf("GenerateColumnConstants() missing %q in:\n%s", exp, constants)
+		}
+	}
+
+	structs, err := sg.GenerateStructs(context.Background(), "main")
+	if err != nil {
+		t.Fatalf("GenerateStructs() error: %v", err)
+	}
+
+	// Each index must point at the scan target ScanRow passes for that column
+	output := runGenerated(t, map[string]string{"column_constants.go": constants, "structs.go": structs}, `package main
+
+import "fmt"
+
+type recorder struct{ dest []any }
+
+func (r *recorder) Scan(dest ...any) error {
+	r.dest = dest
+	return nil
+}
+
+func main() {
+	var u Users
+	var r recorder
+	u.ScanRow(&r)
+	fmt.Println(r.dest[UsersColIdxId] == any(&u.Id), r.dest[UsersColIdxName] == any(&u.Name),
+		r.dest[UsersColIdxEmail] == any(&u.Email), r.dest[UsersColIdxNameUpper] == any(&u.NameUpper), len(r.dest))
+}
+`)
+	if output != "true true true true 4\n" {
+		t.Errorf("column indexes against ScanRow = %q, expected all aligned", output)
+	}
+}
+
+func TestGenerateColumnConstants_UniqueKeys(t *testing.T) {
+	table := &TableInfo{
+		Name: "users",
+		Columns: []ColumnInfo{
+			{Name: "id", Type: "int(11)"},
+			{Name: "tenant_id", Type: "int(11)"},
+			{Name: "slug", Type: "varchar(64)"},
+			{Name: "email", Type: "varchar(255)"},
+		},
+		PrimaryKeys: []string{"id"},
+		Indexes: []IndexInfo{
+			{Name: "PRIMARY", Columns: []string{"id"}, Unique: true, Type: "BTREE"},
+			{Name: "email", Columns: []string{"email"}, Unique: true, Type: "BTREE"},
+			{Name: "slug", Columns: []string{"slug"}, Type: "BTREE"},
+			{Name: "tenant_slug", Columns: []string{"tenant_id", "slug"}, Unique: true, Type: "BTREE"},
+		},
+	}
+	sg := NewSchemaGeneratorFromSource(newMemorySource(table), nil)
+
+	result, err := sg.GenerateColumnConstants(context.Background(), "models")
+	if err != nil {
+		t.Fatalf("GenerateColumnConstants() error: %v", err)
+	}
+
+	expected := []string{
+		`var UsersEmailUniqueKey = []string{"email"}`,
+		`var UsersTenantSlugUniqueKey = []string{"tenant_id", "slug"}`,
+	}
+	for _, exp := range expected {
+		if !strings.Contains(result, exp) {
+			t.Errorf("GenerateColumnConstants() missing %q in:\n%s", exp, result)
+		}
+	}
+	for _, unexpected := range []string{"UsersPrimaryUniqueKey", "UsersSlugUniqueKey"} {
+		if strings.Contains(result, unexpected) {
+			t.Errorf("GenerateColumnConstants() has %s for a primary or non-unique index", unexpected)
+		}
+	}
+
+}
+
+func TestParseEnumValues_Whitespace(t *testing.T) {
+	sg := &SchemaGenerator{}
+
+	tests := []struct {
+		enumType string
+		expected []string
+	}{
+		{"enum('a', 'b')", []string{"a", "b"}},
+		{"enum(' a ')", []string{" a "}},
+		{"set( 'x' ,' y')", []string{"x", " y"}},
+	}
+
+	for _, test := range tests {
+		values := sg.parseEnumValues(test.enumType)
+		if !reflect.DeepEqual(values, test.expected) {
+			t.Errorf("parseEnumValues(%q) = %q, expected %q", test.enumType, values, test.expected)
+		}
+	}
+}
+
+func TestGenerateStructs_ScanAll(t *testing.T) {
+	sg := NewSchemaGeneratorFromSource(newMemorySource(&TableInfo{
+		Name: "users",
+		Columns: []ColumnInfo{
+			{Name: "id", Type: "int(11)"},
+			{Name: "name", Type: "varchar(255)"},
+		},
+		PrimaryKeys: []string{"id"},
+	}), nil)
+
+	result, err := sg.GenerateStructs(context.Background(), "main")
+	if err != nil {
+		t.Fatalf("GenerateStructs() error: %v", err)
+	}
+	if !strings.Contains(result, "func ScanUsers(rows *sql.Rows) ([]Users, error) {") {
+		t.Errorf("GenerateStructs() missing ScanUsers in:\n%s", result)
+	}
+
+	// A minimal driver serves fixed rows and records whether they were closed
+	output := runGenerated(t, map[string]string{"structs.go": result}, `package main
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+)
+
+type fakeDriver struct{}
+type fakeConn struct{}
+type fakeStmt struct{ columns []string }
+type fakeRows struct {
+	columns []string
+	values  [][]driver.Value
+}
+
+var closed bool
+
+func (fakeDriver) Open(string) (driver.Conn, error) { return fakeConn{}, nil }
+
+func (fakeConn) Prepare(query string) (driver.Stmt, error) {
+	if query == "bad" {
+		return fakeStmt{columns: []string{"id"}}, nil
+	}
+	return fakeStmt{columns: []string{"id", "name"}}, nil
+}
+func (fakeConn) Close() error              { return nil }
+func (fakeConn) Begin() (driver.Tx, error) { return nil, fmt.Errorf("no transactions") }
+
+func (fakeStmt) Close() error                               { return nil }
+func (fakeStmt) NumInput() int                              { return -1 }
+func (fakeStmt) Exec([]driver.Value) (driver.Result, error) { return nil, fmt.Errorf("no exec") }
+func (s fakeStmt) Query([]driver.Value) (driver.Rows, error) {
+	values := [][]driver.Value{{int64(1), "alice"}, {int64(2), "bob"}}
+	if len(s.columns) == 1 {
+		values = [][]driver.Value{{int64(1)}}
+	}
+	return &fakeRows{columns: s.columns, values: values}, nil
+}
+
+func (r *fakeRows) Columns() []string { return r.columns }
+func (r *fakeRows) Close() error      { closed = true; return nil }
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
+
+func main() {
+	sql.Register("fake", fakeDriver{})
+	db, _ := sql.Open("fake", "")
+
+	rows, _ := db.Query("SELECT id, name FROM users")
+	users, err := ScanUsers(rows)
+	fmt.Println(users, err, closed)
+
+	closed = false
+	rows, _ = db.Query("bad")
+	users, err = ScanUsers(rows)
+	fmt.Println(users == nil, err != nil, closed)
+}
+`)
+	expected := "[{1 alice} {2 bob}] <nil> true\ntrue true true\n"
+	if output != expected {
+		t.Errorf("ScanUsers() output = %q, expected %q", output, expected)
+	}
+
+	colliding := NewSchemaGeneratorFromSource(newMemorySource(&TableInfo{Name: "users"}, &TableInfo{Name: "scan_users"}), nil)
+	if _, err := colliding.GenerateStructs(context.Background(), "main"); err == nil || !strings.Contains(err.Error(), "ScanUsers") {
+		t.Errorf("GenerateStructs() error = %v, expected a collision on ScanUsers", err)
+	}
+}
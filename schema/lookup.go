@@ -0,0 +1,143 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// LookupValue is a row of a lookup table: the key a foreign key column
+// stores and the label naming it
+type LookupValue struct {
+	Key   string
+	Label string
+}
+
+// LookupSource is implemented by a Source that can provide the values of
+// lookup tables configured in Config.LookupEnums
+type LookupSource interface {
+	GetLookupValues(ctx context.Context, tableName, keyColumn, labelColumn string) ([]LookupValue, error)
+}
+
+// GetLookupValues retrieves the keys and labels of a lookup table's rows,
+// sorted by label. Rows with a NULL key or label are left out.
+func (sg *SchemaGenerator) GetLookupValues(ctx context.Context, tableName, keyColumn, labelColumn string) ([]LookupValue, error) {
+	if sg.source != nil {
+		lookupSource, ok := sg.source.(LookupSource)
+		if !ok {
+			return nil, fmt.Errorf("source does not provide lookup values for %s.%s", tableName, labelColumn)
+		}
+		return lookupSource.GetLookupValues(ctx, tableName, keyColumn, labelColumn)
+	}
+
+	query := fmt.Sprintf("SELECT %s, %s FROM %s WHERE %s IS NOT NULL AND %s IS NOT NULL ORDER BY 2, 1",
+		quoteIdentifier(keyColumn), quoteIdentifier(labelColumn), quoteIdentifier(tableName),
+		quoteIdentifier(keyColumn), quoteIdentifier(labelColumn))
+
+	rows, err := sg.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query lookup values of %s.%s: %w", tableName, labelColumn, err)
+	}
+	defer rows.Close()
+
+	var values []LookupValue
+	for rows.Next() {
+		var value LookupValue
+		if err := rows.Scan(&value.Key, &value.Label); err != nil {
+			return nil, fmt.Errorf("failed to scan lookup value: %w", err)
+		}
+		values = append(values, value)
+	}
+
+	return values, rows.Err()
+}
+
+// lookupEnum is a foreign key column configured in Config.LookupEnums with
+// the rows of its lookup table
+type lookupEnum struct {
+	TableName  string
+	ColumnName string
+	Lookup     LookupEnum
+	Values     []LookupValue
+}
+
+// lookupEnums returns the lookup enums of the selected tables, sorted by
+// table and column
+func (sg *SchemaGenerator) lookupEnums(ctx context.Context) ([]lookupEnum, error) {
+	if sg.config == nil || len(sg.config.LookupEnums) == 0 {
+		return nil, nil
+	}
+
+	tables, err := sg.selectedTables(ctx)
+	if err != nil {
+		return nil, err
+	}
+	selected := make(map[string]bool)
+	for _, tableName := range tables {
+		selected[tableName] = !sg.tableFailed(tableName)
+	}
+
+	keys := make([]string, 0, len(sg.config.LookupEnums))
+	for key := range sg.config.LookupEnums {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var enums []lookupEnum
+	for _, key := range keys {
+		tableName, columnName, _ := strings.Cut(key, ".")
+		if !selected[tableName] {
+			continue
+		}
+		lookup := sg.config.LookupEnums[key]
+
+		if lookup.Key == "" {
+			lookupTable, err := sg.cachedTableInfo(ctx, lookup.Table)
+			if err != nil {
+				return nil, fmt.Errorf("lookup enum %s: %w", key, err)
+			}
+			if len(lookupTable.PrimaryKeys) != 1 {
+				return nil, fmt.Errorf("lookup enum %s: %s has no single-column primary key, set its key column", key, lookup.Table)
+			}
+			lookup.Key = lookupTable.PrimaryKeys[0]
+		}
+
+		values, err := sg.GetLookupValues(ctx, lookup.Table, lookup.Key, lookup.Column)
+		if err != nil {
+			return nil, fmt.Errorf("lookup enum %s: %w", key, err)
+		}
+		enums = append(enums, lookupEnum{TableName: tableName, ColumnName: columnName, Lookup: lookup, Values: values})
+	}
+
+	return enums, nil
+}
+
+// generateLookupBlock generates a constant per row of a lookup table, named
+// after its label and holding its key, so they compare with the foreign key
+// column. Integer keys are untyped constants that fit any integer field.
+func (sg *SchemaGenerator) generateLookupBlock(enum lookupEnum) (string, error) {
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("// %s.%s keys of the %s lookup table, named by its %s column\n",
+		enum.TableName, enum.ColumnName, enum.Lookup.Table, enum.Lookup.Column))
+	builder.WriteString("const (\n")
+
+	labels := make(map[string]string)
+	for _, value := range enum.Values {
+		constName := sg.toEnumConstantName(enum.TableName, enum.ColumnName, value.Label)
+		if other, exists := labels[constName]; exists {
+			return "", fmt.Errorf("lookup enum %s.%s: labels %q and %q both map to %s", enum.TableName, enum.ColumnName, other, value.Label, constName)
+		}
+		labels[constName] = value.Label
+
+		key := strconv.Quote(value.Key)
+		if _, err := strconv.ParseInt(value.Key, 10, 64); err == nil {
+			key = value.Key
+		}
+		builder.WriteString(fmt.Sprintf("\t%s = %s\n", constName, key))
+	}
+
+	builder.WriteString(")\n\n")
+	return builder.String(), nil
+}
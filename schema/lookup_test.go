@@ -0,0 +1,127 @@
+package schema
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func lookupTestTables() (*TableInfo, *TableInfo) {
+	orders := &TableInfo{
+		Name: "orders",
+		Columns: []ColumnInfo{
+			{Name: "id", Type: "int(11)"},
+			{Name: "status_id", Type: "int(11)"},
+		},
+		PrimaryKeys: []string{"id"},
+	}
+	statuses := &TableInfo{
+		Name: "statuses",
+		Columns: []ColumnInfo{
+			{Name: "id", Type: "int(11)"},
+			{Name: "name", Type: "varchar(50)"},
+		},
+		PrimaryKeys: []string{"id"},
+	}
+	return orders, statuses
+}
+
+func TestGenerateEnumConstants_LookupEnums(t *testing.T) {
+	source := newMemorySource(lookupTestTables())
+	source.lookups["statuses.id.name"] = []LookupValue{{Key: "3", Label: "cancelled"}, {Key: "2", Label: "paid"}, {Key: "1", Label: "pending"}}
+
+	config := &Config{
+		EnumMode:    EnumModeTyped,
+		LookupEnums: map[string]LookupEnum{"orders.status_id": {Table: "statuses", Column: "name"}},
+	}
+	result, err := NewSchemaGeneratorFromSource(source, config).GenerateEnumConstants(context.Background(), "models")
+	if err != nil {
+		t.Fatalf("GenerateEnumConstants() error: %v", err)
+	}
+
+	expected := []string{
+		"// orders.status_id keys of the statuses lookup table, named by its name column\n",
+		"\tOrders_StatusId_Cancelled = 3\n",
+		"\tOrders_StatusId_Paid = 2\n",
+		"\tOrders_StatusId_Pending = 1\n",
+	}
+	for _, exp := range expected {
+		if !strings.Contains(result, exp) {
+			t.Errorf("GenerateEnumConstants() missing %q in:\n%s", exp, result)
+		}
+	}
+	for _, unexpected := range []string{"type OrdersStatusId", `"paid"`, "import ("} {
+		if strings.Contains(result, unexpected) {
+			t.Errorf("GenerateEnumConstants() contains %q in:\n%s", unexpected, result)
+		}
+	}
+
+	config.LookupEnums = map[string]LookupEnum{"orders.status_id": {Table: "states", Column: "name"}}
+	_, err = NewSchemaGeneratorFromSource(source, config).GenerateEnumConstants(context.Background(), "models")
+	if err == nil || !strings.Contains(err.Error(), "lookup enum orders.status_id") {
+		t.Errorf("GenerateEnumConstants() with a missing lookup table error = %v", err)
+	}
+}
+
+func TestGenerateAll_LookupEnumsCompile(t *testing.T) {
+	orders, statuses := lookupTestTables()
+	orders.Columns = append(orders.Columns, ColumnInfo{Name: "previous_status_id", Type: "bigint(20)", Nullable: true})
+	source := newMemorySource(orders, statuses)
+	source.lookups["statuses.id.name"] = []LookupValue{{Key: "2", Label: "paid"}, {Key: "1", Label: "pending"}}
+	source.lookups["statuses.code.name"] = []LookupValue{{Key: "P", Label: "paid"}}
+
+	config := &Config{LookupEnums: map[string]LookupEnum{
+		"orders.status_id":          {Table: "statuses", Column: "name"},
+		"orders.previous_status_id": {Table: "statuses", Column: "name"},
+		"statuses.name":             {Table: "statuses", Column: "name", Key: "code"},
+	}}
+	files, err := NewSchemaGeneratorFromSource(source, config).GenerateAll(context.Background(), "main")
+	if err != nil {
+		t.Fatalf("GenerateAll() error: %v", err)
+	}
+	if !strings.Contains(files["enum_constants.go"], `Statuses_Name_Paid = "P"`) {
+		t.Errorf("enum_constants.go lacks the string key constant:\n%s", files["enum_constants.go"])
+	}
+
+	// The constants compare with int32 and sql.NullInt64 foreign keys
+	output := runGenerated(t, files, `package main
+
+import "fmt"
+
+func main() {
+	order := NewOrders(1, Orders_StatusId_Paid)
+	order.PreviousStatusId.Int64 = Orders_PreviousStatusId_Pending
+	fmt.Println(order.StatusId == Orders_StatusId_Paid, order.PreviousStatusId.Int64, Statuses_Name_Paid)
+}
+`)
+	if output != "true 1 P\n" {
+		t.Errorf("generated code output = %q, expected %q", output, "true 1 P\n")
+	}
+}
+
+func TestConfigValidate_LookupEnums(t *testing.T) {
+	tests := []struct {
+		key     string
+		lookup  LookupEnum
+		wantErr string
+	}{
+		{"orders.status_id", LookupEnum{Table: "statuses", Column: "name"}, ""},
+		{"status_id", LookupEnum{Table: "statuses", Column: "name"}, "not of the form table.column"},
+		{"orders.", LookupEnum{Table: "statuses", Column: "name"}, "not of the form table.column"},
+		{"orders.status_id", LookupEnum{Table: "statuses"}, "needs a table and a column"},
+	}
+
+	for _, test := range tests {
+		config := &Config{LookupEnums: map[string]LookupEnum{test.key: test.lookup}}
+		err := config.Validate()
+		if test.wantErr == "" {
+			if err != nil {
+				t.Errorf("Validate(%s) unexpected error: %v", test.key, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+			t.Errorf("Validate(%s) error = %v, expected it to contain %q", test.key, err, test.wantErr)
+		}
+	}
+}
@@ -0,0 +1,226 @@
+package schema
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SingleFileName is the file GenerateAll writes when Config.SingleFile is set
+const SingleFileName = "models.go"
+
+// mergeSections combines generated files into a single file with one banner,
+// one package clause and an import block holding the imports the merged
+// declarations use
+func mergeSections(banner, packageName string, sections []string) (string, error) {
+	imports := make(map[string]bool)
+	var body strings.Builder
+
+	for _, section := range sections {
+		// Sections without a package clause, such as the enum placeholder
+		// for schemas without enums, hold no declarations
+		if !strings.Contains(section, "\npackage ") {
+			continue
+		}
+
+		fset := token.NewFileSet()
+		file, err := parser.ParseFile(fset, "", section, parser.ImportsOnly)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse generated section: %w", err)
+		}
+
+		start := file.Name.End()
+		for _, imp := range file.Imports {
+			importPath, err := strconv.Unquote(imp.Path.Value)
+			if err != nil {
+				return "", fmt.Errorf("failed to parse import %s: %w", imp.Path.Value, err)
+			}
+			imports[importPath] = true
+		}
+		for _, decl := range file.Decls {
+			if genDecl, ok := decl.(*ast.GenDecl); ok && genDecl.Tok == token.IMPORT {
+				start = genDecl.End()
+			}
+		}
+
+		body.WriteString(strings.TrimSpace(section[fset.Position(start).Offset:]))
+		body.WriteString("\n\n")
+	}
+
+	used, err := usedPackages(body.String())
+	if err != nil {
+		return "", err
+	}
+
+	usedImports := make(map[string]bool)
+	for importPath := range imports {
+		if used[path.Base(importPath)] {
+			usedImports[importPath] = true
+		}
+	}
+
+	var builder strings.Builder
+	builder.WriteString(banner)
+	builder.WriteString("package " + packageName + "\n\n")
+	writeImports(&builder, usedImports)
+
+	builder.WriteString(body.String())
+
+	return builder.String(), nil
+}
+
+// usedPackages returns the package names referenced by qualified identifiers in declarations
+func usedPackages(decls string) (map[string]bool, error) {
+	file, err := parser.ParseFile(token.NewFileSet(), "", "package merged\n\n"+decls, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse merged declarations: %w", err)
+	}
+
+	used := make(map[string]bool)
+	ast.Inspect(file, func(node ast.Node) bool {
+		if selector, ok := node.(*ast.SelectorExpr); ok {
+			if ident, ok := selector.X.(*ast.Ident); ok {
+				used[ident.Name] = true
+			}
+		}
+		return true
+	})
+
+	return used, nil
+}
+
+// checkDeclarationCollisions reports every identifier declared at package
+// level in more than one generated file, joined into one error. Each
+// generator keeps its own names unique, but a column constant and an enum
+// constant, for example, can still end up with the same name in the shared
+// package.
+func checkDeclarationCollisions(files map[string]string) error {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var collisions []error
+	declaredIn := make(map[string]string)
+	for _, name := range names {
+		// Files without a package clause, such as the enum placeholder for
+		// schemas without enums, hold no declarations
+		if !strings.Contains(files[name], "package ") {
+			continue
+		}
+
+		file, err := parser.ParseFile(token.NewFileSet(), name, files[name], parser.SkipObjectResolution)
+		if err != nil {
+			return fmt.Errorf("failed to parse generated %s: %w", name, err)
+		}
+
+		for _, ident := range declaredIdentifiers(file) {
+			if other, exists := declaredIn[ident]; exists {
+				if other != name {
+					collisions = append(collisions, fmt.Errorf("%s is declared in both %s and %s", ident, other, name))
+				}
+				continue
+			}
+			declaredIn[ident] = name
+		}
+	}
+
+	return errors.Join(collisions...)
+}
+
+// checkMethodCollisions reports every generated method named like a field
+// of its receiver struct, joined into one error. Go rejects a struct with a
+// field and a method of the same name, which columns such as scan_row or
+// string produce for ScanRow and String. code holds the declarations of one
+// table without a package clause.
+func checkMethodCollisions(tableName, code string) error {
+	file, err := parser.ParseFile(token.NewFileSet(), tableName+".go", "package p\n\n"+code, parser.SkipObjectResolution)
+	if err != nil {
+		return fmt.Errorf("failed to parse generated code of table %s: %w", tableName, err)
+	}
+
+	fields := make(map[string]map[string]bool)
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			typeSpec, ok := spec.(*ast.TypeSpec)
+			if !ok {
+				continue
+			}
+			structType, ok := typeSpec.Type.(*ast.StructType)
+			if !ok {
+				continue
+			}
+			names := make(map[string]bool)
+			for _, field := range structType.Fields.List {
+				for _, name := range field.Names {
+					names[name.Name] = true
+				}
+				// Embedded fields are named after their type
+				if ident, ok := field.Type.(*ast.Ident); ok && len(field.Names) == 0 {
+					names[ident.Name] = true
+				}
+			}
+			fields[typeSpec.Name.Name] = names
+		}
+	}
+
+	var collisions []error
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv == nil || len(fn.Recv.List) == 0 {
+			continue
+		}
+		receiver := fn.Recv.List[0].Type
+		if star, ok := receiver.(*ast.StarExpr); ok {
+			receiver = star.X
+		}
+		ident, ok := receiver.(*ast.Ident)
+		if !ok {
+			continue
+		}
+		if fields[ident.Name][fn.Name.Name] {
+			collisions = append(collisions, fmt.Errorf("table %s: method %s collides with field %s of %s, rename the field with field_renames", tableName, fn.Name.Name, fn.Name.Name, ident.Name))
+		}
+	}
+
+	return errors.Join(collisions...)
+}
+
+// declaredIdentifiers returns the package-level names a file declares,
+// leaving out methods, init functions and blank identifiers
+func declaredIdentifiers(file *ast.File) []string {
+	var idents []string
+	for _, decl := range file.Decls {
+		switch d := decl.(type) {
+		case *ast.FuncDecl:
+			if d.Recv == nil && d.Name.Name != "init" {
+				idents = append(idents, d.Name.Name)
+			}
+		case *ast.GenDecl:
+			for _, spec := range d.Specs {
+				switch s := spec.(type) {
+				case *ast.TypeSpec:
+					idents = append(idents, s.Name.Name)
+				case *ast.ValueSpec:
+					for _, name := range s.Names {
+						if name.Name != "_" {
+							idents = append(idents, name.Name)
+						}
+					}
+				}
+			}
+		}
+	}
+	return idents
+}
@@ -0,0 +1,79 @@
+package schema
+
+import (
+	"context"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+)
+
+func TestGenerateAll_SingleFile(t *testing.T) {
+	table := &TableInfo{
+		Name: "users",
+		Columns: []ColumnInfo{
+			{Name: "id", Type: "int(11)"},
+			{Name: "name", Type: "varchar(255)", Nullable: true},
+			{Name: "status", Type: "enum('active','inactive')", IsEnum: true, EnumValues: []string{"active", "inactive"}},
+		},
+		PrimaryKeys: []string{"id"},
+	}
+	config := &Config{SingleFile: true, EnumMode: EnumModeTyped}
+	sg := NewSchemaGeneratorFromSource(newMemorySource(table), config)
+
+	files, err := sg.GenerateAll(context.Background(), "main")
+	if err != nil {
+		t.Fatalf("GenerateAll() error: %v", err)
+	}
+	if len(files) != 1 || files[SingleFileName] == "" {
+		t.Fatalf("GenerateAll() files = %v, expected only %s", len(files), SingleFileName)
+	}
+
+	result := files[SingleFileName]
+	if count := strings.Count(result, "\npackage "); count != 1 {
+		t.Errorf("merged file has %d package clauses, expected 1", count)
+	}
+	if count := strings.Count(result, "Code generated"); count != 1 {
+		t.Errorf("merged file has %d headers, expected 1", count)
+	}
+
+	file, err := parser.ParseFile(token.NewFileSet(), "", result, parser.ImportsOnly)
+	if err != nil {
+		t.Fatalf("merged file does not parse: %v\n%s", err, result)
+	}
+	var imports []string
+	for _, imp := range file.Imports {
+		imports = append(imports, imp.Path.Value)
+	}
+	// time and the types package are not used by this table, strings is used by InClause
+	expected := `"database/sql" "database/sql/driver" "fmt" "strings"`
+	if got := strings.Join(imports, " "); got != expected {
+		t.Errorf("merged imports = %s, expected %s", got, expected)
+	}
+
+	output := runGenerated(t, map[string]string{SingleFileName: result}, `package main
+
+import "fmt"
+
+func main() {
+	user := Users{Id: 1, Status: Users_Status_Active}
+	fmt.Println(UsersSelectSQL != "", user.Status, Users_Status_Name)
+}
+`)
+	if output != "true active status\n" {
+		t.Errorf("merged file output = %q, expected %q", output, "true active status\n")
+	}
+}
+
+func TestMergeSections_SkipsEmptySections(t *testing.T) {
+	result, err := mergeSections("// Code generated by mariakit; DO NOT EDIT.\n\n", "models", []string{
+		"// Code generated by mariakit; DO NOT EDIT.\n\npackage models\n\nconst A = 1\n",
+		"// Code generated by mariakit; DO NOT EDIT.\n\n// No enum types found in the database\n",
+	})
+	if err != nil {
+		t.Fatalf("mergeSections() error: %v", err)
+	}
+	if strings.Contains(result, "No enum types") || !strings.Contains(result, "const A = 1") {
+		t.Errorf("mergeSections() = %q", result)
+	}
+}
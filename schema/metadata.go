@@ -0,0 +1,138 @@
+package schema
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/csv"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Checksum computes a hash of the selected tables' column signatures (table,
+// column, type and nullability). It matches the SchemaChecksum constant of
+// code generated from the same schema and configuration, so applications can
+// detect schema drift at startup.
+func (sg *SchemaGenerator) Checksum(ctx context.Context) (string, error) {
+	tableInfos, err := sg.loadTables(ctx)
+	if err != nil {
+		return "", err
+	}
+	return schemaChecksum(tableInfos), nil
+}
+
+// schemaChecksum hashes the sorted column signatures of the tables
+func schemaChecksum(tableInfos []*TableInfo) string {
+	var signatures []string
+	for _, tableInfo := range tableInfos {
+		for _, col := range tableInfo.Columns {
+			signatures = append(signatures, fmt.Sprintf("%s.%s %s null=%t", tableInfo.Name, col.Name, col.Type, col.Nullable))
+		}
+	}
+	sort.Strings(signatures)
+
+	sum := sha256.Sum256([]byte(strings.Join(signatures, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+// DatabaseNameSource is implemented by a Source that knows the name of the
+// database its schema was read from
+type DatabaseNameSource interface {
+	DatabaseName(ctx context.Context) (string, error)
+}
+
+// DatabaseName returns the name of the inspected database, which reflects
+// the Schema option. It is empty for sources that do not know their database.
+func (sg *SchemaGenerator) DatabaseName(ctx context.Context) (string, error) {
+	if sg.source != nil {
+		if nameSource, ok := sg.source.(DatabaseNameSource); ok {
+			return nameSource.DatabaseName(ctx)
+		}
+		return "", nil
+	}
+
+	var name sql.NullString
+	if err := sg.db.QueryRowContext(ctx, "SELECT DATABASE()").Scan(&name); err != nil {
+		return "", fmt.Errorf("failed to query database name: %w", err)
+	}
+	return name.String, nil
+}
+
+// GenerateMetadata generates declarations describing the generated schema:
+// the SchemaChecksum used for drift detection, the SourceDatabase name, the
+// AllTables list and a CSV header constant per table
+func (sg *SchemaGenerator) GenerateMetadata(ctx context.Context, packageName string) (string, error) {
+	tableInfos, err := sg.loadTables(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	databaseName, err := sg.DatabaseName(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	var builder strings.Builder
+	builder.WriteString(sg.bannerWith(sg.goGenerateDirective()))
+	builder.WriteString("package " + packageName + "\n\n")
+
+	builder.WriteString("// SchemaChecksum identifies the table and column signatures this code was generated from.\n")
+	builder.WriteString("// Compare it with schema.SchemaGenerator.Checksum to detect schema drift.\n")
+	builder.WriteString(fmt.Sprintf("const SchemaChecksum = %q\n", schemaChecksum(tableInfos)))
+
+	builder.WriteString("\n// SourceDatabase is the name of the database this code was generated from\n")
+	builder.WriteString(fmt.Sprintf("const SourceDatabase = %q\n", databaseName))
+
+	tableNames := make([]string, len(tableInfos))
+	for i, tableInfo := range tableInfos {
+		tableNames[i] = fmt.Sprintf("%q", tableInfo.Name)
+	}
+	sort.Strings(tableNames)
+
+	builder.WriteString("\n// AllTables lists the names of all generated tables in sorted order\n")
+	builder.WriteString(fmt.Sprintf("var AllTables = []string{%s}\n", strings.Join(tableNames, ", ")))
+
+	for _, tableInfo := range tableInfos {
+		if len(tableInfo.Columns) == 0 {
+			continue
+		}
+		header, err := csvHeader(tableInfo)
+		if err != nil {
+			return "", err
+		}
+		builder.WriteString(fmt.Sprintf("\n// %s is the CSV header row of the %s table in struct field order\n", sg.toCSVHeaderName(tableInfo.Name), tableInfo.Name))
+		builder.WriteString(fmt.Sprintf("const %s = %q\n", sg.toCSVHeaderName(tableInfo.Name), header))
+	}
+
+	return builder.String(), nil
+}
+
+// csvHeader joins the raw column names of a table into a CSV record, quoting
+// names that contain commas or quotes
+func csvHeader(tableInfo *TableInfo) (string, error) {
+	names := make([]string, len(tableInfo.Columns))
+	for i, col := range tableInfo.Columns {
+		names[i] = col.Name
+	}
+
+	var builder strings.Builder
+	writer := csv.NewWriter(&builder)
+	if err := writer.Write(names); err != nil {
+		return "", fmt.Errorf("failed to build CSV header of table %s: %w", tableInfo.Name, err)
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return "", fmt.Errorf("failed to build CSV header of table %s: %w", tableInfo.Name, err)
+	}
+	return strings.TrimSuffix(builder.String(), "\n"), nil
+}
+
+// goGenerateDirective returns the configured //go:generate directive, or ""
+func (sg *SchemaGenerator) goGenerateDirective() string {
+	if sg.config == nil || sg.config.GoGenerate == "" {
+		return ""
+	}
+	return "//go:generate " + sg.config.GoGenerate + "\n\n"
+}
@@ -0,0 +1,176 @@
+package schema
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+	"testing"
+)
+
+func metadataTestTable() *TableInfo {
+	return &TableInfo{
+		Name: "users",
+		Columns: []ColumnInfo{
+			{Name: "id", Type: "int(11)"},
+			{Name: "name", Type: "varchar(255)", Nullable: true},
+		},
+		PrimaryKeys: []string{"id"},
+	}
+}
+
+func TestChecksum(t *testing.T) {
+	ctx := context.Background()
+	table := metadataTestTable()
+
+	first, err := NewSchemaGeneratorFromSource(newMemorySource(table), nil).Checksum(ctx)
+	if err != nil {
+		t.Fatalf("Checksum() error: %v", err)
+	}
+	second, err := NewSchemaGeneratorFromSource(newMemorySource(metadataTestTable()), nil).Checksum(ctx)
+	if err != nil {
+		t.Fatalf("Checksum() error: %v", err)
+	}
+	if first != second {
+		t.Errorf("Checksum() = %q and %q for the same schema, expected equal", first, second)
+	}
+
+	table.Columns = append(table.Columns, ColumnInfo{Name: "email", Type: "varchar(255)"})
+	changed, err := NewSchemaGeneratorFromSource(newMemorySource(table), nil).Checksum(ctx)
+	if err != nil {
+		t.Fatalf("Checksum() error: %v", err)
+	}
+	if changed == first {
+		t.Errorf("Checksum() = %q after adding a column, expected a different checksum", changed)
+	}
+}
+
+func TestGenerateMetadata(t *testing.T) {
+	sg := NewSchemaGeneratorFromSource(newMemorySource(metadataTestTable()), nil)
+
+	checksum, err := sg.Checksum(context.Background())
+	if err != nil {
+		t.Fatalf("Checksum() error: %v", err)
+	}
+
+	result, err := sg.GenerateMetadata(context.Background(), "models")
+	if err != nil {
+		t.Fatalf("GenerateMetadata() error: %v", err)
+	}
+	if !strings.Contains(result, `const SchemaChecksum = "`+checksum+`"`) {
+		t.Errorf("GenerateMetadata() missing checksum %q in:\n%s", checksum, result)
+	}
+}
+
+func TestGenerateMetadata_AllTables(t *testing.T) {
+	sg := NewSchemaGeneratorFromSource(newMemorySource(
+		&TableInfo{Name: "users"},
+		&TableInfo{Name: "audit_log"},
+		&TableInfo{Name: "orders"},
+	), nil)
+
+	result, err := sg.GenerateMetadata(context.Background(), "models")
+	if err != nil {
+		t.Fatalf("GenerateMetadata() error: %v", err)
+	}
+
+	expected := `var AllTables = []string{"audit_log", "orders", "users"}`
+	if !strings.Contains(result, expected) {
+		t.Errorf("GenerateMetadata() missing %q in:\n%s", expected, result)
+	}
+}
+
+func TestGenerateMetadata_GoGenerate(t *testing.T) {
+	config := &Config{GoGenerate: "mariakit -conn=" + RedactDSN("root:secret@tcp(db:3306)/app") + " -output=."}
+	sg := NewSchemaGeneratorFromSource(newMemorySource(metadataTestTable()), config)
+
+	result, err := sg.GenerateMetadata(context.Background(), "models")
+	if err != nil {
+		t.Fatalf("GenerateMetadata() error: %v", err)
+	}
+
+	directive := "//go:generate mariakit -conn=root:xxxxx@tcp(db:3306)/app -output=.\n"
+	index := strings.Index(result, directive)
+	if index < 0 {
+		t.Fatalf("GenerateMetadata() missing %q in:\n%s", directive, result)
+	}
+	if index > strings.Index(result, "package models") {
+		t.Errorf("go:generate directive should precede the package clause:\n%s", result)
+	}
+	if strings.Contains(result, "secret") {
+		t.Errorf("GenerateMetadata() leaks the password:\n%s", result)
+	}
+
+	config.SingleFile = true
+	files, err := sg.GenerateAll(context.Background(), "models")
+	if err != nil {
+		t.Fatalf("GenerateAll() error: %v", err)
+	}
+	if count := strings.Count(files[SingleFileName], "//go:generate"); count != 1 {
+		t.Errorf("merged file has %d go:generate directives, expected 1", count)
+	}
+}
+
+func TestGenerateMetadata_SourceDatabase(t *testing.T) {
+	source := newMemorySource(metadataTestTable())
+	source.database = "shop"
+
+	result, err := NewSchemaGeneratorFromSource(source, nil).GenerateMetadata(context.Background(), "models")
+	if err != nil {
+		t.Fatalf("GenerateMetadata() error: %v", err)
+	}
+	if expected := `const SourceDatabase = "shop"`; !strings.Contains(result, expected) {
+		t.Errorf("GenerateMetadata() missing %q in:\n%s", expected, result)
+	}
+}
+
+func TestDatabaseName(t *testing.T) {
+	db := newFakeDB(map[string]fakeResult{
+		"SELECT DATABASE()": {columns: []string{"DATABASE()"}, rows: [][]driver.Value{{"reporting"}}},
+	})
+	defer db.Close()
+
+	name, err := NewSchemaGeneratorFromDB(db, nil).DatabaseName(context.Background())
+	if err != nil {
+		t.Fatalf("DatabaseName() error: %v", err)
+	}
+	if name != "reporting" {
+		t.Errorf("DatabaseName() = %q, expected %q", name, "reporting")
+	}
+
+	dump, err := ParseSQLDump("CREATE DATABASE `shop`;\nUSE `shop`;\nCREATE TABLE `t` (`a` int);")
+	if err != nil {
+		t.Fatalf("ParseSQLDump() error: %v", err)
+	}
+	if name, _ := NewSchemaGeneratorFromSource(dump, nil).DatabaseName(context.Background()); name != "shop" {
+		t.Errorf("DatabaseName() of a dump = %q, expected %q", name, "shop")
+	}
+}
+
+func TestGenerateMetadata_CSVHeader(t *testing.T) {
+	sg := NewSchemaGeneratorFromSource(newMemorySource(
+		queriesTestTable(),
+		&TableInfo{Name: "notes", Columns: []ColumnInfo{{Name: "id", Type: "int(11)"}, {Name: "a,b", Type: "text"}}},
+		&TableInfo{Name: "empty"},
+	), nil)
+
+	result, err := sg.GenerateMetadata(context.Background(), "models")
+	if err != nil {
+		t.Fatalf("GenerateMetadata() error: %v", err)
+	}
+
+	var names []string
+	for _, col := range queriesTestTable().Columns {
+		names = append(names, col.Name)
+	}
+	for _, expected := range []string{
+		`const UsersCSVHeader = "` + strings.Join(names, ",") + `"`,
+		`const NotesCSVHeader = "id,\"a,b\""`,
+	} {
+		if !strings.Contains(result, expected) {
+			t.Errorf("GenerateMetadata() missing %q in:\n%s", expected, result)
+		}
+	}
+	if strings.Contains(result, "EmptyCSVHeader") {
+		t.Errorf("GenerateMetadata() declared a header for a table without columns:\n%s", result)
+	}
+}
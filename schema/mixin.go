@@ -0,0 +1,128 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+)
+
+// mixinField is a struct field generated for a column of Config.EmbedMixin
+type mixinField struct {
+	column string
+	name   string
+	goType string
+	tag    string
+}
+
+// embedMixin is the Timestamps struct embedded in place of the
+// Config.EmbedMixin columns. Its fields come from the first table having all
+// of the columns; tables mapping them to different fields keep them inline.
+type embedMixin struct {
+	name    string
+	fields  []mixinField
+	imports []string
+}
+
+// buildMixin returns the mixin for the configured columns, or nil if none are
+// configured or no table has all of them
+func (sg *SchemaGenerator) buildMixin(tableInfos []*TableInfo) (*embedMixin, error) {
+	if sg.config == nil || len(sg.config.EmbedMixin) == 0 {
+		return nil, nil
+	}
+
+	for _, tableInfo := range tableInfos {
+		fieldNames, err := sg.fieldNames(tableInfo)
+		if err != nil {
+			return nil, err
+		}
+		fields := sg.mixinFields(tableInfo, fieldNames)
+		if fields == nil {
+			continue
+		}
+
+		var imports []string
+		for _, col := range tableInfo.Columns {
+			if sg.isMixinColumn(col.Name) {
+				_, typeImports := sg.goType(tableInfo, col)
+				imports = append(imports, typeImports...)
+			}
+		}
+		return &embedMixin{name: exportName("Timestamps", sg.config.exportStructs()), fields: fields, imports: imports}, nil
+	}
+	return nil, nil
+}
+
+// mixinFields returns the fields of a table's Config.EmbedMixin columns in
+// column order, or nil unless the table has all of them
+func (sg *SchemaGenerator) mixinFields(tableInfo *TableInfo, fieldNames []string) []mixinField {
+	var fields []mixinField
+	for i, col := range tableInfo.Columns {
+		if !sg.isMixinColumn(col.Name) {
+			continue
+		}
+		goType, _ := sg.goType(tableInfo, col)
+		fields = append(fields, mixinField{
+			column: col.Name,
+			name:   fieldNames[i],
+			goType: goType,
+			tag:    sg.structTag(tableInfo, col),
+		})
+	}
+	if len(fields) != len(sg.config.EmbedMixin) {
+		return nil
+	}
+	return fields
+}
+
+// isMixinColumn reports whether a column is one of Config.EmbedMixin
+func (sg *SchemaGenerator) isMixinColumn(columnName string) bool {
+	if sg.config == nil {
+		return false
+	}
+	for _, column := range sg.config.EmbedMixin {
+		if column == columnName {
+			return true
+		}
+	}
+	return false
+}
+
+// embeds reports whether the struct of a table embeds the mixin, which
+// requires its mixin columns to map to the same fields, in any order
+func (m *embedMixin) embeds(sg *SchemaGenerator, tableInfo *TableInfo, fieldNames []string) bool {
+	if m == nil {
+		return false
+	}
+	fields := sg.mixinFields(tableInfo, fieldNames)
+	if fields == nil {
+		return false
+	}
+
+	expected := make(map[string]mixinField)
+	for _, field := range m.fields {
+		expected[field.column] = field
+	}
+	for _, field := range fields {
+		if expected[field.column] != field {
+			return false
+		}
+	}
+	return true
+}
+
+// declaration generates the mixin struct
+func (m *embedMixin) declaration() string {
+	columns := make([]string, len(m.fields))
+	for i, field := range m.fields {
+		columns[i] = field.column
+	}
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("// %s holds the %s columns shared by several tables,\n", m.name, strings.Join(columns, ", ")))
+	builder.WriteString("// embedded in their structs\n")
+	builder.WriteString(fmt.Sprintf("type %s struct {\n", m.name))
+	for _, field := range m.fields {
+		builder.WriteString(fmt.Sprintf("\t%s %s `%s`\n", field.name, field.goType, field.tag))
+	}
+	builder.WriteString("}\n\n")
+	return builder.String()
+}
@@ -0,0 +1,118 @@
+package schema
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestGenerateStructs_EmbedMixin(t *testing.T) {
+	timestamps := []ColumnInfo{
+		{Name: "created_at", Type: "datetime"},
+		{Name: "updated_at", Type: "datetime", Nullable: true},
+	}
+	users := &TableInfo{
+		Name:        "users",
+		Columns:     append([]ColumnInfo{{Name: "id", Type: "int(11)", AutoIncrement: true}, {Name: "name", Type: "varchar(255)"}}, timestamps...),
+		PrimaryKeys: []string{"id"},
+	}
+	posts := &TableInfo{
+		Name:        "posts",
+		Columns:     append([]ColumnInfo{{Name: "id", Type: "int(11)"}}, timestamps...),
+		PrimaryKeys: []string{"id"},
+	}
+	tags := &TableInfo{
+		Name:    "tags",
+		Columns: []ColumnInfo{{Name: "label", Type: "varchar(32)"}, {Name: "created_at", Type: "datetime"}},
+	}
+	config := &Config{EmbedMixin: []string{"created_at", "updated_at"}}
+
+	result, err := NewSchemaGeneratorFromSource(newMemorySource(users, posts, tags), config).GenerateStructs(context.Background(), "main")
+	if err != nil {
+		t.Fatalf("GenerateStructs() error: %v", err)
+	}
+
+	if count := strings.Count(result, "type Timestamps struct {"); count != 1 {
+		t.Errorf("GenerateStructs() declares Timestamps %d times, expected once:\n%s", count, result)
+	}
+	for _, expected := range []string{
+		"type Users struct {\n\tId int32 `db:\"id\"`\n\tName string `db:\"name\"` // max length 255\n\tTimestamps\n}",
+		"type Posts struct {\n\tId int32 `db:\"id\"`\n\tTimestamps\n}",
+		"func NewUsers(name string, createdAt time.Time) Users {\n\treturn Users{\n\t\tName: name,\n\t\tTimestamps: Timestamps{CreatedAt: createdAt},\n\t}\n}",
+		"CreatedAt time.Time `db:\"created_at\"`\n}",
+	} {
+		if !strings.Contains(result, expected) {
+			t.Errorf("GenerateStructs() missing %q in:\n%s", expected, result)
+		}
+	}
+
+	// The db tags of embedded fields resolve like those of direct fields,
+	// which is how sqlx maps them
+	output := runGenerated(t, map[string]string{"structs.go": result}, `package main
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+)
+
+type scanner struct{}
+
+func (scanner) Scan(dest ...any) error {
+	*dest[1].(*time.Time) = time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)
+	return nil
+}
+
+func main() {
+	for _, field := range reflect.VisibleFields(reflect.TypeOf(Users{})) {
+		if !field.Anonymous {
+			fmt.Print(field.Tag.Get("db"), " ")
+		}
+	}
+	fmt.Println()
+
+	var post Posts
+	post.ScanRow(scanner{})
+	fmt.Println(post.CreatedAt.Year(), NewUsers("alice", post.CreatedAt).Timestamps.CreatedAt.Month())
+}
+`)
+	expected := "id name created_at updated_at \n2024 May\n"
+	if output != expected {
+		t.Errorf("generated code output = %q, expected %q", output, expected)
+	}
+}
+
+func TestGenerateStructs_EmbedMixinMismatch(t *testing.T) {
+	users := &TableInfo{
+		Name:    "users",
+		Columns: []ColumnInfo{{Name: "created_at", Type: "datetime"}, {Name: "updated_at", Type: "datetime"}},
+	}
+	// The mixin takes its fields from users, the first table in name order
+	visits := &TableInfo{
+		Name:    "visits",
+		Columns: []ColumnInfo{{Name: "created_at", Type: "int(11)"}, {Name: "updated_at", Type: "datetime"}},
+	}
+	config := &Config{EmbedMixin: []string{"created_at", "updated_at"}}
+
+	result, err := NewSchemaGeneratorFromSource(newMemorySource(users, visits), config).GenerateStructs(context.Background(), "models")
+	if err != nil {
+		t.Fatalf("GenerateStructs() error: %v", err)
+	}
+
+	expected := "type Visits struct {\n\tCreatedAt int32 `db:\"created_at\"`\n\tUpdatedAt time.Time `db:\"updated_at\"`\n}"
+	if !strings.Contains(result, expected) {
+		t.Errorf("GenerateStructs() missing inline fields %q in:\n%s", expected, result)
+	}
+}
+
+func TestConfigValidate_EmbedMixin(t *testing.T) {
+	if err := (&Config{EmbedMixin: []string{"created_at", "updated_at"}}).Validate(); err != nil {
+		t.Errorf("Validate() unexpected error: %v", err)
+	}
+	if err := (&Config{EmbedMixin: []string{"created_at", "created_at"}}).Validate(); err == nil || !strings.Contains(err.Error(), "twice") {
+		t.Errorf("Validate() error = %v, expected a duplicate column error", err)
+	}
+	if err := (&Config{EmbedMixin: []string{" "}}).Validate(); err == nil {
+		t.Errorf("Validate() accepted an empty embed_mixin entry")
+	}
+}
@@ -0,0 +1,110 @@
+package schema
+
+import (
+	"fmt"
+	"go/token"
+	"strings"
+	"unicode"
+)
+
+// fieldName returns the struct field name of a column: its entry in
+// FieldRenames, or the camel-cased name after column prefix stripping
+func (sg *SchemaGenerator) fieldName(tableName, columnName string) string {
+	if sg.config != nil {
+		if name, exists := sg.config.FieldRenames[tableName+"."+columnName]; exists {
+			return name
+		}
+	}
+	return sg.toFieldName(sg.stripColumnPrefix(tableName, columnName))
+}
+
+// stripColumnPrefix removes the configured prefix for the table, falling
+// back to the "*" entry, from a column name. Names that would become empty
+// are kept as they are.
+func (sg *SchemaGenerator) stripColumnPrefix(tableName, columnName string) string {
+	if sg.config == nil || len(sg.config.ColumnPrefixStrip) == 0 {
+		return columnName
+	}
+
+	prefix, exists := sg.config.ColumnPrefixStrip[tableName]
+	if !exists {
+		prefix = sg.config.ColumnPrefixStrip["*"]
+	}
+
+	stripped := strings.TrimPrefix(columnName, prefix)
+	if prefix == "" || strings.Trim(stripped, "_") == "" {
+		return columnName
+	}
+	return stripped
+}
+
+// fieldNames returns the struct field names of a table's columns in column
+// order, or an error if two columns map to the same field name
+func (sg *SchemaGenerator) fieldNames(tableInfo *TableInfo) ([]string, error) {
+	names := make([]string, len(tableInfo.Columns))
+	seen := make(map[string]string)
+
+	for i, col := range tableInfo.Columns {
+		name := sg.fieldName(tableInfo.Name, col.Name)
+		if other, exists := seen[name]; exists {
+			return nil, fmt.Errorf("table %s: columns %s and %s both map to field %s", tableInfo.Name, other, col.Name, name)
+		}
+		seen[name] = col.Name
+		names[i] = name
+	}
+
+	return names, nil
+}
+
+// initialisms are the column name parts written in upper case in typed
+// column name constants, following Go's naming conventions (UsersColumnID)
+var initialisms = map[string]bool{
+	"api": true, "ascii": true, "cpu": true, "css": true, "dns": true,
+	"guid": true, "html": true, "http": true, "https": true, "id": true,
+	"ip": true, "json": true, "sku": true, "sql": true, "ssh": true,
+	"tcp": true, "tls": true, "ttl": true, "udp": true, "ui": true,
+	"uid": true, "uri": true, "url": true, "utf8": true, "uuid": true,
+	"vat": true, "xml": true,
+}
+
+// toInitialismCamelCase camel-cases a name like toCamelCase, but upper-cases
+// parts that are common initialisms, so user_id becomes UserID
+func (sg *SchemaGenerator) toInitialismCamelCase(s string) string {
+	parts := strings.Split(s, "_")
+	for i, part := range parts {
+		if initialisms[strings.ToLower(part)] {
+			parts[i] = strings.ToUpper(part)
+		} else if len(part) > 0 {
+			parts[i] = strings.ToUpper(part[:1]) + part[1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+// toParamName returns the parameter name of a field: the field name in
+// lowerCamel case, with a leading initialism lowercased as a whole (ID
+// becomes id, URLPath urlPath, UserID userID) and a trailing underscore for
+// Go keywords
+func toParamName(fieldName string) string {
+	runes := []rune(fieldName)
+	upper := 0
+	for upper < len(runes) && unicode.IsUpper(runes[upper]) {
+		upper++
+	}
+	// In URLPath the P starts the next word, so it stays upper-case
+	if upper > 1 && upper < len(runes) && unicode.IsLower(runes[upper]) {
+		upper--
+	}
+	if upper == 0 {
+		upper = 1
+	}
+	for i := 0; i < upper && i < len(runes); i++ {
+		runes[i] = unicode.ToLower(runes[i])
+	}
+
+	param := string(runes)
+	if token.IsKeyword(param) {
+		param += "_"
+	}
+	return param
+}
@@ -0,0 +1,253 @@
+package schema
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestFieldName_ColumnPrefixStrip(t *testing.T) {
+	sg := &SchemaGenerator{config: &Config{ColumnPrefixStrip: map[string]string{
+		"users": "usr_",
+		"*":     "x_",
+	}}}
+
+	tests := []struct {
+		tableName  string
+		columnName string
+		expected   string
+	}{
+		{"users", "usr_id", "Id"},
+		{"users", "usr_first_name", "FirstName"},
+		{"users", "created_at", "CreatedAt"},
+		{"users", "usr_", "Usr"},
+		{"orders", "x_total", "Total"},
+		{"orders", "usr_id", "UsrId"},
+	}
+
+	for _, test := range tests {
+		result := sg.fieldName(test.tableName, test.columnName)
+		if result != test.expected {
+			t.Errorf("fieldName(%q, %q) = %q, expected %q", test.tableName, test.columnName, result, test.expected)
+		}
+	}
+}
+
+func TestGenerateStructs_ColumnPrefixStrip(t *testing.T) {
+	table := &TableInfo{
+		Name: "users",
+		Columns: []ColumnInfo{
+			{Name: "usr_id", Type: "int(11)"},
+			{Name: "usr_name", Type: "varchar(255)"},
+		},
+	}
+	config := &Config{ColumnPrefixStrip: map[string]string{"users": "usr_"}}
+	sg := NewSchemaGeneratorFromSource(newMemorySource(table), config)
+
+	result, err := sg.GenerateStructs(context.Background(), "models")
+	if err != nil {
+		t.Fatalf("GenerateStructs() error: %v", err)
+	}
+	for _, exp := range []string{"Id int32 `db:\"usr_id\"`", "Name string `db:\"usr_name\"`"} {
+		if !strings.Contains(result, exp) {
+			t.Errorf("GenerateStructs() missing %q in:\n%s", exp, result)
+		}
+	}
+
+	// Stripping usr_name collides with an existing name column
+	table.Columns = append(table.Columns, ColumnInfo{Name: "name", Type: "varchar(255)"})
+	_, err = sg.GenerateStructs(context.Background(), "models")
+	if err == nil || !strings.Contains(err.Error(), "columns usr_name and name both map to field Name") {
+		t.Errorf("GenerateStructs() error = %v, expected field collision error", err)
+	}
+}
+
+func TestGenerateStructs_FieldRenames(t *testing.T) {
+	table := &TableInfo{
+		Name: "users",
+		Columns: []ColumnInfo{
+			{Name: "id", Type: "int(11)"},
+			{Name: "legacy_nm", Type: "varchar(255)"},
+			{Name: "email", Type: "varchar(255)"},
+		},
+		PrimaryKeys: []string{"id"},
+	}
+	config := &Config{FieldRenames: map[string]string{"users.id": "ID", "users.legacy_nm": "Name"}}
+	if err := config.Validate(); err != nil {
+		t.Fatalf("Validate() error: %v", err)
+	}
+	sg := NewSchemaGeneratorFromSource(newMemorySource(table), config)
+
+	result, err := sg.GenerateStructs(context.Background(), "models")
+	if err != nil {
+		t.Fatalf("GenerateStructs() error: %v", err)
+	}
+	for _, exp := range []string{"ID int32 `db:\"id\"`", "Name string `db:\"legacy_nm\"`", "Email string `db:\"email\"`", "return scanner.Scan(&u.ID, &u.Name, &u.Email)"} {
+		if !strings.Contains(result, exp) {
+			t.Errorf("GenerateStructs() missing %q in:\n%s", exp, result)
+		}
+	}
+
+	// A rename onto another column's derived name collides
+	config.FieldRenames["users.legacy_nm"] = "Email"
+	_, err = sg.GenerateStructs(context.Background(), "models")
+	if err == nil || !strings.Contains(err.Error(), "columns legacy_nm and email both map to field Email") {
+		t.Errorf("GenerateStructs() error = %v, expected field collision error", err)
+	}
+}
+
+func TestConfig_ValidateFieldRenames(t *testing.T) {
+	tests := []struct {
+		renames map[string]string
+		valid   bool
+	}{
+		{map[string]string{"users.id": "ID"}, true},
+		{map[string]string{"users": "ID"}, false},
+		{map[string]string{"users.id": "id"}, false},
+		{map[string]string{"users.id": "User ID"}, false},
+	}
+
+	for _, test := range tests {
+		err := (&Config{FieldRenames: test.renames}).Validate()
+		if (err == nil) != test.valid {
+			t.Errorf("Validate() with %v = %v, expected valid: %t", test.renames, err, test.valid)
+		}
+	}
+}
+
+func TestNaming_ExportToggles(t *testing.T) {
+	no := false
+	tests := []struct {
+		config      *Config
+		structName  string
+		constructor string
+		constant    string
+		enumConst   string
+		query       string
+		enumType    string
+		columnType  string
+	}{
+		{nil, "OrderItems", "NewOrderItems", "OrderItems_Sku_Name", "OrderItems_State_Open", "OrderItemsSelectSQL", "OrderItemsState", "OrderItemsColumn"},
+		{&Config{ExportStructs: &no}, "orderItems", "newOrderItems", "OrderItems_Sku_Name", "OrderItems_State_Open", "OrderItemsSelectSQL", "OrderItemsState", "OrderItemsColumn"},
+		{&Config{ExportConstants: &no}, "OrderItems", "NewOrderItems", "orderItems_Sku_Name", "orderItems_State_Open", "orderItemsSelectSQL", "orderItemsState", "orderItemsColumn"},
+	}
+
+	for _, test := range tests {
+		sg := &SchemaGenerator{config: test.config}
+		got := []string{
+			sg.toStructName("order_items"),
+			sg.toConstructorName("order_items"),
+			sg.toConstantName("order_items", "sku"),
+			sg.toEnumConstantName("order_items", "state", "open"),
+			sg.toQueryConstantName("order_items", "Select"),
+			sg.toEnumTypeName("order_items", "state"),
+			sg.toColumnNameTypeName("order_items"),
+		}
+		expected := []string{test.structName, test.constructor, test.constant, test.enumConst, test.query, test.enumType, test.columnType}
+		if strings.Join(got, " ") != strings.Join(expected, " ") {
+			t.Errorf("names with %+v = %v, expected %v", test.config, got, expected)
+		}
+
+		// Fields stay exported so database/sql can scan into them
+		if field := sg.fieldName("order_items", "sku"); field != "Sku" {
+			t.Errorf("fieldName() with %+v = %q, expected %q", test.config, field, "Sku")
+		}
+	}
+}
+
+func TestGenerateStructs_Unexported(t *testing.T) {
+	no := false
+	table := &TableInfo{
+		Name:    "users",
+		Columns: []ColumnInfo{{Name: "name", Type: "varchar(255)"}},
+	}
+	sg := NewSchemaGeneratorFromSource(newMemorySource(table), &Config{ExportStructs: &no})
+
+	result, err := sg.GenerateStructs(context.Background(), "models")
+	if err != nil {
+		t.Fatalf("GenerateStructs() error: %v", err)
+	}
+	for _, exp := range []string{"type users struct {", "Name string `db:\"name\"`", "func newUsers(name string) users {"} {
+		if !strings.Contains(result, exp) {
+			t.Errorf("GenerateStructs() missing %q in:\n%s", exp, result)
+		}
+	}
+}
+
+func TestExportName_ReservedWords(t *testing.T) {
+	tests := []struct {
+		name     string
+		exported bool
+		expected string
+	}{
+		{"Type", false, "type_"},
+		{"String", false, "string_"},
+		{"Nil", false, "nil_"},
+		{"Users", false, "users"},
+		{"Type", true, "Type"},
+	}
+	for _, test := range tests {
+		if name := exportName(test.name, test.exported); name != test.expected {
+			t.Errorf("exportName(%q, %t) = %q, expected %q", test.name, test.exported, name, test.expected)
+		}
+	}
+}
+
+func TestGenerateAll_UnexportedReservedTables(t *testing.T) {
+	no := false
+	typeTable := &TableInfo{
+		Name: "type",
+		Columns: []ColumnInfo{
+			{Name: "id", Type: "int(11)", AutoIncrement: true},
+			{Name: "kind", Type: "enum('a','b')", IsEnum: true, EnumValues: []string{"a", "b"}},
+		},
+		PrimaryKeys: []string{"id"},
+	}
+	stringTable := &TableInfo{
+		Name:    "string",
+		Columns: []ColumnInfo{{Name: "value", Type: "varchar(255)"}},
+	}
+	config := &Config{ExportStructs: &no, ExportConstants: &no, EnumMode: EnumModeTyped}
+
+	files, err := NewSchemaGeneratorFromSource(newMemorySource(typeTable, stringTable), config).GenerateAll(context.Background(), "main")
+	if err != nil {
+		t.Fatalf("GenerateAll() error: %v", err)
+	}
+	for _, expected := range []string{"type type_ struct {", "type string_ struct {", "type typeKind string"} {
+		if !strings.Contains(files["structs.go"]+files["enum_constants.go"], expected) {
+			t.Errorf("generated code missing %q", expected)
+		}
+	}
+
+	// The builtin string type is not shadowed
+	output := runGenerated(t, files, `package main
+
+import "fmt"
+
+func main() {
+	var s string = "ok"
+	fmt.Println(s, newType(type_Kind_A).Kind, string_{Value: "v"}.Value)
+}
+`)
+	if output != "ok a v\n" {
+		t.Errorf("generated code output = %q, expected %q", output, "ok a v\n")
+	}
+}
+
+func TestToParamName(t *testing.T) {
+	tests := map[string]string{
+		"Name":    "name",
+		"ID":      "id",
+		"Id":      "id",
+		"URL":     "url",
+		"UserID":  "userID",
+		"URLPath": "urlPath",
+		"Type":    "type_",
+		"X":       "x",
+	}
+	for fieldName, expected := range tests {
+		if param := toParamName(fieldName); param != expected {
+			t.Errorf("toParamName(%q) = %q, expected %q", fieldName, param, expected)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// GenerationPlan summarizes what a generation run would produce: the
+// selected tables, their enums and the output files
+type GenerationPlan struct {
+	Tables []TablePlan
+	Enums  int
+	Files  []string
+}
+
+// TablePlan is a table of a GenerationPlan with the number of columns its
+// generated code covers
+type TablePlan struct {
+	Name    string
+	Columns int
+}
+
+// standaloneTypes maps the generation types that are only generated on their
+// own, never by GenerateAll, to their output file
+var standaloneTypes = map[string]string{
+	"enumtypes":    "enum_types.go",
+	"repositories": "repositories.go",
+	"schemajson":   "schema.json",
+}
+
+// Plan inspects the schema like a generation run for the given types would,
+// without generating code. Empty types plan GenerateAll, which writes
+// SingleFileName alone when Config.SingleFile is set.
+func (sg *SchemaGenerator) Plan(ctx context.Context, types []string) (*GenerationPlan, error) {
+	defer sg.enableCache()()
+
+	tableInfos, err := sg.loadTables(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	plan := &GenerationPlan{}
+	for _, tableInfo := range tableInfos {
+		plan.Tables = append(plan.Tables, TablePlan{Name: tableInfo.Name, Columns: len(tableInfo.Columns)})
+	}
+
+	_, tableEnums, err := sg.selectedEnums(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, enums := range tableEnums {
+		plan.Enums += len(enums)
+	}
+
+	switch {
+	case len(types) == 0 && sg.config != nil && sg.config.SingleFile:
+		plan.Files = []string{SingleFileName}
+	case len(types) == 0:
+		for name, generateType := range generateTypes {
+			if name == "schemainfo" && (sg.config == nil || !sg.config.GenerateSchemaInfo) {
+				continue
+			}
+			plan.Files = append(plan.Files, generateType.filename)
+		}
+	default:
+		for _, name := range types {
+			if filename, exists := standaloneTypes[name]; exists {
+				plan.Files = append(plan.Files, filename)
+				continue
+			}
+			generateType, exists := generateTypes[canonicalGenerateType(name)]
+			if !exists {
+				return nil, fmt.Errorf("unknown generation type: %s", name)
+			}
+			plan.Files = append(plan.Files, generateType.filename)
+		}
+	}
+
+	// The structs are split into a file per table
+	if sg.config != nil && sg.config.FilePerTable {
+		for i, filename := range plan.Files {
+			if filename != "structs.go" {
+				continue
+			}
+			structFiles, err := sg.GenerateStructFiles(ctx, "plan")
+			if err != nil {
+				return nil, err
+			}
+			plan.Files = append(plan.Files[:i], plan.Files[i+1:]...)
+			for structFile := range structFiles {
+				plan.Files = append(plan.Files, structFile)
+			}
+			break
+		}
+	}
+	sort.Strings(plan.Files)
+
+	return plan, nil
+}
@@ -0,0 +1,63 @@
+package schema
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func TestPlan(t *testing.T) {
+	tables := []*TableInfo{
+		enumsTestTable(),
+		{
+			Name:        "posts",
+			Columns:     []ColumnInfo{{Name: "id", Type: "int(11)"}, {Name: "title", Type: "varchar(255)"}, {Name: "body", Type: "text"}},
+			PrimaryKeys: []string{"id"},
+		},
+	}
+
+	sg := NewSchemaGeneratorFromSource(newMemorySource(tables...), nil)
+	plan, err := sg.Plan(context.Background(), nil)
+	if err != nil {
+		t.Fatalf("Plan() error: %v", err)
+	}
+
+	expectedTables := []TablePlan{{Name: "posts", Columns: 3}, {Name: "users", Columns: 2}}
+	if !reflect.DeepEqual(plan.Tables, expectedTables) {
+		t.Errorf("Plan().Tables = %+v, expected %+v", plan.Tables, expectedTables)
+	}
+	if plan.Enums != 1 {
+		t.Errorf("Plan().Enums = %d, expected 1", plan.Enums)
+	}
+
+	files, err := Generate(context.Background(), GenerateOptions{Source: newMemorySource(tables...), PackageName: "models"})
+	if err != nil {
+		t.Fatalf("Generate() error: %v", err)
+	}
+	if len(plan.Files) != len(files) {
+		t.Errorf("Plan().Files = %v, expected the %d files Generate writes", plan.Files, len(files))
+	}
+	for _, name := range plan.Files {
+		if _, exists := files[name]; !exists {
+			t.Errorf("Plan().Files has %s, which Generate does not write", name)
+		}
+	}
+
+	singleFile := NewSchemaGeneratorFromSource(newMemorySource(tables...), &Config{SingleFile: true})
+	if plan, err := singleFile.Plan(context.Background(), nil); err != nil || !reflect.DeepEqual(plan.Files, []string{SingleFileName}) {
+		t.Errorf("Plan() with SingleFile = %+v, %v, expected [%s]", plan, err, SingleFileName)
+	}
+
+	if plan, err := sg.Plan(context.Background(), []string{"structs", "enumtypes"}); err != nil || !reflect.DeepEqual(plan.Files, []string{"enum_types.go", "structs.go"}) {
+		t.Errorf("Plan(structs, enumtypes) = %+v, %v, expected [enum_types.go structs.go]", plan, err)
+	}
+	if plan, err := sg.Plan(context.Background(), []string{"repositories", "schemajson"}); err != nil || !reflect.DeepEqual(plan.Files, []string{"repositories.go", "schema.json"}) {
+		t.Errorf("Plan(repositories, schemajson) = %+v, %v, expected [repositories.go schema.json]", plan, err)
+	}
+	if plan, err := sg.Plan(context.Background(), []string{"types", "columnnames"}); err != nil || !reflect.DeepEqual(plan.Files, []string{"column_names.go", "column_types.go"}) {
+		t.Errorf("Plan(types, columnnames) = %+v, %v, expected [column_names.go column_types.go]", plan, err)
+	}
+	if _, err := sg.Plan(context.Background(), []string{"models"}); err == nil {
+		t.Error("Plan() with an unknown type expected error, got nil")
+	}
+}
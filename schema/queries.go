@@ -0,0 +1,299 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// GenerateQueries generates SQL statement constants for all tables
+func (sg *SchemaGenerator) GenerateQueries(ctx context.Context, packageName string) (string, error) {
+	tableInfos, err := sg.loadTables(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	var builder strings.Builder
+	builder.WriteString(sg.banner())
+	builder.WriteString("package " + packageName + "\n\n")
+
+	if len(tableInfos) > 0 {
+		imports := map[string]bool{"strings": true}
+		for _, tableInfo := range tableInfos {
+			if len(insertColumns(tableInfo)) > 0 {
+				imports["fmt"] = true
+			}
+		}
+		if sg.placeholderStyle() == PlaceholderDollar {
+			imports["strconv"] = true
+		}
+		writeImports(&builder, imports)
+		builder.WriteString(sg.generateInClause())
+	}
+
+	for _, tableInfo := range tableInfos {
+		builder.WriteString(fmt.Sprintf("// %s table SQL statements\n", sg.toCamelCase(tableInfo.Name)))
+		builder.WriteString("const (\n")
+		builder.WriteString(fmt.Sprintf("\t%s = %q\n", sg.toQueryConstantName(tableInfo.Name, "Select"), sg.selectSQL(tableInfo)))
+		builder.WriteString(fmt.Sprintf("\t%s = %q\n", sg.toQueryConstantName(tableInfo.Name, "Count"), sg.countSQL(tableInfo)))
+
+		if insert := sg.insertSQL(tableInfo); insert != "" {
+			builder.WriteString(fmt.Sprintf("\t%s = %q\n", sg.toQueryConstantName(tableInfo.Name, "Insert"), insert))
+		}
+
+		if update := sg.updateSQL(tableInfo); update != "" {
+			builder.WriteString(fmt.Sprintf("\t%s = %q\n", sg.toQueryConstantName(tableInfo.Name, "Update"), update))
+		}
+
+		builder.WriteString(")\n\n")
+
+		builder.WriteString(sg.generateBatchInsert(tableInfo))
+	}
+
+	return builder.String(), nil
+}
+
+// generateExistsSQL generates an ExistsSQL method on the table struct. It is
+// written to structs.go, next to its receiver type, so queries.go does not
+// depend on the struct package. It returns "" for tables without a primary key.
+func (sg *SchemaGenerator) generateExistsSQL(tableInfo *TableInfo, structName string) string {
+	exists := sg.existsSQL(tableInfo)
+	if exists == "" {
+		return ""
+	}
+
+	receiver := sg.toReceiverName(structName)
+	args := make([]string, len(tableInfo.PrimaryKeys))
+	for i, pk := range tableInfo.PrimaryKeys {
+		args[i] = receiver + "." + sg.fieldName(tableInfo.Name, pk)
+	}
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("// ExistsSQL returns a query and its arguments checking whether a %s row with the same primary key exists\n", tableInfo.Name))
+	builder.WriteString(fmt.Sprintf("func (%s %s) ExistsSQL() (string, []any) {\n", receiver, structName))
+	builder.WriteString(fmt.Sprintf("\treturn %q, []any{%s}\n", exists, strings.Join(args, ", ")))
+	builder.WriteString("}\n\n")
+	return builder.String()
+}
+
+// generateBatchInsert generates a function building a multi-row INSERT with n
+// value groups. Named placeholders cannot repeat per row, so that style uses ?
+// like InClause. It returns "" if there is nothing to insert.
+func (sg *SchemaGenerator) generateBatchInsert(tableInfo *TableInfo) string {
+	columns := insertColumns(tableInfo)
+	if len(columns) == 0 {
+		return ""
+	}
+
+	quoted := make([]string, len(columns))
+	for i, col := range columns {
+		quoted[i] = quoteIdentifier(col)
+	}
+	prefix := fmt.Sprintf("INSERT INTO %s (%s) VALUES ", quoteIdentifier(tableInfo.Name), strings.Join(quoted, ", "))
+
+	// Dollar placeholders keep counting across groups, from the stride base
+	// of each row
+	var base, group string
+	if sg.placeholderStyle() == PlaceholderDollar {
+		base = "i"
+		if len(columns) > 1 {
+			base = fmt.Sprintf("i * %d", len(columns))
+		}
+		parts := make([]string, len(columns))
+		for k := range columns {
+			parts[k] = fmt.Sprintf("strconv.Itoa(base+%d)", k+1)
+		}
+		group = `"($" + ` + strings.Join(parts, ` + ", $" + `) + ` + ")"`
+	} else {
+		parts := make([]string, len(columns))
+		for i := range columns {
+			parts[i] = sg.argPlaceholder(i + 1)
+		}
+		group = fmt.Sprintf("%q", "("+strings.Join(parts, ", ")+")")
+	}
+
+	name := sg.toQueryConstantName(tableInfo.Name, "BatchInsert")
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("// %s returns an INSERT of n %s rows in one statement, with the columns of\n", name, tableInfo.Name))
+	builder.WriteString("// each row bound in order. n must be at least 1.\n")
+	builder.WriteString(fmt.Sprintf("func %s(n int) (string, error) {\n", name))
+	builder.WriteString("\tif n < 1 {\n")
+	builder.WriteString(fmt.Sprintf("\t\treturn \"\", fmt.Errorf(\"%s: n must be at least 1, got %%d\", n)\n", name))
+	builder.WriteString("\t}\n")
+	builder.WriteString("\tgroups := make([]string, n)\n")
+	builder.WriteString("\tfor i := range groups {\n")
+	if base != "" {
+		builder.WriteString(fmt.Sprintf("\t\tbase := %s\n", base))
+	}
+	builder.WriteString(fmt.Sprintf("\t\tgroups[i] = %s\n", group))
+	builder.WriteString("\t}\n")
+	builder.WriteString(fmt.Sprintf("\treturn %q + strings.Join(groups, \", \"), nil\n", prefix))
+	builder.WriteString("}\n\n")
+
+	return builder.String()
+}
+
+// generateInClause generates the InClause helper building a column's IN
+// condition with n placeholders in the configured style. Named placeholders
+// cannot be expanded, so that style uses ? as sqlx.In expects.
+func (sg *SchemaGenerator) generateInClause() string {
+	placeholder := fmt.Sprintf("%q", sg.argPlaceholder(1))
+	if sg.placeholderStyle() == PlaceholderDollar {
+		placeholder = `"$" + strconv.Itoa(i+1)`
+	}
+
+	// Like the SQL constants, the helper follows ExportConstants
+	name := exportName("InClause", sg.config.exportConstants())
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("// %s returns the condition \"`column` IN (...)\" with n placeholders for batch\n", name))
+	builder.WriteString("// lookups. For n < 1 it returns \"`column` IN (NULL)\", which is valid SQL matching no rows.\n")
+	builder.WriteString(fmt.Sprintf("func %s(column string, n int) string {\n", name))
+	builder.WriteString("\tquoted := \"`\" + strings.ReplaceAll(column, \"`\", \"``\") + \"`\"\n")
+	builder.WriteString("\tif n < 1 {\n")
+	builder.WriteString("\t\treturn quoted + \" IN (NULL)\"\n")
+	builder.WriteString("\t}\n")
+	builder.WriteString("\tplaceholders := make([]string, n)\n")
+	builder.WriteString("\tfor i := range placeholders {\n")
+	builder.WriteString(fmt.Sprintf("\t\tplaceholders[i] = %s\n", placeholder))
+	builder.WriteString("\t}\n")
+	builder.WriteString("\treturn quoted + \" IN (\" + strings.Join(placeholders, \", \") + \")\"\n")
+	builder.WriteString("}\n\n")
+
+	return builder.String()
+}
+
+// countSQL builds a query counting all rows of a table
+func (sg *SchemaGenerator) countSQL(tableInfo *TableInfo) string {
+	return fmt.Sprintf("SELECT COUNT(*) FROM %s", quoteIdentifier(tableInfo.Name))
+}
+
+// existsSQL builds a query checking for a row by primary key. ExistsSQL
+// returns its arguments as a slice, so the placeholders are positional. It
+// returns "" for tables without a primary key.
+func (sg *SchemaGenerator) existsSQL(tableInfo *TableInfo) string {
+	if len(tableInfo.PrimaryKeys) == 0 {
+		return ""
+	}
+
+	conditions := make([]string, len(tableInfo.PrimaryKeys))
+	for i, pk := range tableInfo.PrimaryKeys {
+		conditions[i] = fmt.Sprintf("%s = %s", quoteIdentifier(pk), sg.argPlaceholder(i+1))
+	}
+
+	return fmt.Sprintf("SELECT EXISTS(SELECT 1 FROM %s WHERE %s)", quoteIdentifier(tableInfo.Name), strings.Join(conditions, " AND "))
+}
+
+// selectSQL builds a SELECT of all columns in column order
+func (sg *SchemaGenerator) selectSQL(tableInfo *TableInfo) string {
+	columns := make([]string, len(tableInfo.Columns))
+	for i, col := range tableInfo.Columns {
+		columns[i] = quoteIdentifier(col.Name)
+	}
+	return fmt.Sprintf("SELECT %s FROM %s", strings.Join(columns, ", "), quoteIdentifier(tableInfo.Name))
+}
+
+// insertSQL builds an INSERT for all writable columns, leaving out generated
+// and auto-increment columns. It returns "" if there is nothing to insert.
+func (sg *SchemaGenerator) insertSQL(tableInfo *TableInfo) string {
+	var columns, placeholders []string
+	for _, col := range insertColumns(tableInfo) {
+		columns = append(columns, quoteIdentifier(col))
+		placeholders = append(placeholders, sg.placeholder(len(placeholders)+1, col))
+	}
+
+	if len(columns) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)",
+		quoteIdentifier(tableInfo.Name), strings.Join(columns, ", "), strings.Join(placeholders, ", "))
+}
+
+// insertColumns returns the names of the columns an INSERT sets: all but
+// generated and auto-increment columns
+func insertColumns(tableInfo *TableInfo) []string {
+	var columns []string
+	for _, col := range tableInfo.Columns {
+		if col.IsGenerated || col.AutoIncrement {
+			continue
+		}
+		columns = append(columns, col.Name)
+	}
+	return columns
+}
+
+// updateSQL builds an UPDATE of all writable non-key columns by primary key.
+// Columns maintained by the database through ON UPDATE CURRENT_TIMESTAMP are
+// left out. It returns "" for tables without a primary key or without
+// updatable columns.
+func (sg *SchemaGenerator) updateSQL(tableInfo *TableInfo) string {
+	if len(tableInfo.PrimaryKeys) == 0 {
+		return ""
+	}
+
+	isPK := make(map[string]bool)
+	for _, pk := range tableInfo.PrimaryKeys {
+		isPK[pk] = true
+	}
+
+	n := 0
+	var assignments []string
+	for _, col := range tableInfo.Columns {
+		if col.IsGenerated || col.AutoUpdate || isPK[col.Name] {
+			continue
+		}
+		n++
+		assignments = append(assignments, fmt.Sprintf("%s = %s", quoteIdentifier(col.Name), sg.placeholder(n, col.Name)))
+	}
+
+	if len(assignments) == 0 {
+		return ""
+	}
+
+	conditions := make([]string, len(tableInfo.PrimaryKeys))
+	for i, pk := range tableInfo.PrimaryKeys {
+		n++
+		conditions[i] = fmt.Sprintf("%s = %s", quoteIdentifier(pk), sg.placeholder(n, pk))
+	}
+
+	return fmt.Sprintf("UPDATE %s SET %s WHERE %s",
+		quoteIdentifier(tableInfo.Name), strings.Join(assignments, ", "), strings.Join(conditions, " AND "))
+}
+
+// quoteIdentifier backtick-quotes a table or column name for MariaDB,
+// doubling embedded backticks
+func quoteIdentifier(name string) string {
+	return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+}
+
+// placeholderStyle returns the configured placeholder style
+func (sg *SchemaGenerator) placeholderStyle() string {
+	if sg.config == nil || sg.config.PlaceholderStyle == "" {
+		return PlaceholderQuestion
+	}
+	return sg.config.PlaceholderStyle
+}
+
+// argPlaceholder renders the n-th (1-based) bind parameter of a query whose
+// arguments are bound in order. Named placeholders cannot bind positional
+// arguments, so that style uses ? as sqlx.In and database/sql expect.
+func (sg *SchemaGenerator) argPlaceholder(n int) string {
+	if sg.placeholderStyle() == PlaceholderDollar {
+		return fmt.Sprintf("$%d", n)
+	}
+	return "?"
+}
+
+// placeholder renders the n-th (1-based) bind parameter for a column in the configured style
+func (sg *SchemaGenerator) placeholder(n int, columnName string) string {
+	switch sg.placeholderStyle() {
+	case PlaceholderDollar:
+		return fmt.Sprintf("$%d", n)
+	case PlaceholderNamed:
+		return ":" + columnName
+	default:
+		return "?"
+	}
+}
@@ -0,0 +1,342 @@
+package schema
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func queriesTestTable() *TableInfo {
+	return &TableInfo{
+		Name: "users",
+		Columns: []ColumnInfo{
+			{Name: "id", Type: "int(11)", AutoIncrement: true},
+			{Name: "name", Type: "varchar(255)"},
+			{Name: "email", Type: "varchar(255)"},
+			{Name: "name_upper", Type: "varchar(255)", IsGenerated: true},
+		},
+		PrimaryKeys: []string{"id"},
+	}
+}
+
+func TestInsertSQL_PlaceholderStyles(t *testing.T) {
+	tests := []struct {
+		style    string
+		expected string
+	}{
+		{"", "INSERT INTO `users` (`name`, `email`) VALUES (?, ?)"},
+		{PlaceholderQuestion, "INSERT INTO `users` (`name`, `email`) VALUES (?, ?)"},
+		{PlaceholderDollar, "INSERT INTO `users` (`name`, `email`) VALUES ($1, $2)"},
+		{PlaceholderNamed, "INSERT INTO `users` (`name`, `email`) VALUES (:name, :email)"},
+	}
+
+	for _, test := range tests {
+		sg := &SchemaGenerator{config: &Config{PlaceholderStyle: test.style}}
+		result := sg.insertSQL(queriesTestTable())
+		if result != test.expected {
+			t.Errorf("insertSQL() with style %q = %q, expected %q", test.style, result, test.expected)
+		}
+	}
+}
+
+func TestUpdateSQL_DollarNumbersContinueIntoWhere(t *testing.T) {
+	sg := &SchemaGenerator{config: &Config{PlaceholderStyle: PlaceholderDollar}}
+
+	expected := "UPDATE `users` SET `name` = $1, `email` = $2 WHERE `id` = $3"
+	if result := sg.updateSQL(queriesTestTable()); result != expected {
+		t.Errorf("updateSQL() = %q, expected %q", result, expected)
+	}
+}
+
+func TestGenerateQueries(t *testing.T) {
+	sg := NewSchemaGeneratorFromSource(newMemorySource(queriesTestTable()), nil)
+
+	result, err := sg.GenerateQueries(context.Background(), "models")
+	if err != nil {
+		t.Fatalf("GenerateQueries() error: %v", err)
+	}
+
+	expected := []string{
+		"UsersSelectSQL = \"SELECT `id`, `name`, `email`, `name_upper` FROM `users`\"",
+		"UsersInsertSQL = \"INSERT INTO `users` (`name`, `email`) VALUES (?, ?)\"",
+		"UsersUpdateSQL = \"UPDATE `users` SET `name` = ?, `email` = ? WHERE `id` = ?\"",
+	}
+	for _, exp := range expected {
+		if !strings.Contains(result, exp) {
+			t.Errorf("GenerateQueries() missing %q in:\n%s", exp, result)
+		}
+	}
+}
+
+func TestConfigValidate_PlaceholderStyle(t *testing.T) {
+	if err := (&Config{PlaceholderStyle: "percent"}).Validate(); err == nil {
+		t.Error("Validate() with unknown placeholder style expected error, got nil")
+	}
+	if err := (&Config{PlaceholderStyle: PlaceholderNamed}).Validate(); err != nil {
+		t.Errorf("Validate() with named placeholder style unexpected error: %v", err)
+	}
+}
+
+func TestGenerateQueries_CountAndExists(t *testing.T) {
+	orderItems := &TableInfo{
+		Name: "order_items",
+		Columns: []ColumnInfo{
+			{Name: "order_id", Type: "int(11)"},
+			{Name: "line_no", Type: "int(11)"},
+			{Name: "quantity", Type: "int(11)"},
+		},
+		PrimaryKeys: []string{"order_id", "line_no"},
+	}
+	auditLog := &TableInfo{
+		Name:    "audit_log",
+		Columns: []ColumnInfo{{Name: "message", Type: "text"}},
+	}
+	sg := NewSchemaGeneratorFromSource(newMemorySource(queriesTestTable(), orderItems, auditLog), nil)
+
+	result, err := sg.GenerateQueries(context.Background(), "models")
+	if err != nil {
+		t.Fatalf("GenerateQueries() error: %v", err)
+	}
+
+	for _, exp := range []string{
+		"UsersCountSQL = \"SELECT COUNT(*) FROM `users`\"",
+		"AuditLogCountSQL = \"SELECT COUNT(*) FROM `audit_log`\"",
+	} {
+		if !strings.Contains(result, exp) {
+			t.Errorf("GenerateQueries() missing %q in:\n%s", exp, result)
+		}
+	}
+	if strings.Contains(result, "ExistsSQL") {
+		t.Errorf("GenerateQueries() declares ExistsSQL, which belongs next to the structs:\n%s", result)
+	}
+
+	// ExistsSQL is a method, so it is generated next to its receiver type
+	structs, err := sg.GenerateStructs(context.Background(), "models")
+	if err != nil {
+		t.Fatalf("GenerateStructs() error: %v", err)
+	}
+	for _, exp := range []string{
+		"func (u Users) ExistsSQL() (string, []any) {",
+		"return \"SELECT EXISTS(SELECT 1 FROM `users` WHERE `id` = ?)\", []any{u.Id}",
+		"func (o OrderItems) ExistsSQL() (string, []any) {",
+		"return \"SELECT EXISTS(SELECT 1 FROM `order_items` WHERE `order_id` = ? AND `line_no` = ?)\", []any{o.OrderId, o.LineNo}",
+	} {
+		if !strings.Contains(structs, exp) {
+			t.Errorf("GenerateStructs() missing %q in:\n%s", exp, structs)
+		}
+	}
+	if strings.Contains(structs, "func (a AuditLog) ExistsSQL") {
+		t.Errorf("GenerateStructs() should skip ExistsSQL for table without primary key:\n%s", structs)
+	}
+}
+
+func TestExistsSQL_PlaceholderStyles(t *testing.T) {
+	tests := []struct {
+		style    string
+		expected string
+	}{
+		{PlaceholderQuestion, "return \"SELECT EXISTS(SELECT 1 FROM `users` WHERE `id` = ?)\", []any{u.Id}"},
+		{PlaceholderDollar, "return \"SELECT EXISTS(SELECT 1 FROM `users` WHERE `id` = $1)\", []any{u.Id}"},
+		// The arguments are positional, so the named style must not emit :id
+		{PlaceholderNamed, "return \"SELECT EXISTS(SELECT 1 FROM `users` WHERE `id` = ?)\", []any{u.Id}"},
+	}
+
+	for _, test := range tests {
+		sg := NewSchemaGeneratorFromSource(newMemorySource(queriesTestTable()), &Config{PlaceholderStyle: test.style})
+		structs, err := sg.GenerateStructs(context.Background(), "models")
+		if err != nil {
+			t.Fatalf("GenerateStructs() with style %q error: %v", test.style, err)
+		}
+		if !strings.Contains(structs, test.expected) {
+			t.Errorf("GenerateStructs() with style %q missing %q in:\n%s", test.style, test.expected, structs)
+		}
+	}
+}
+
+func TestUpdateSQL_ExcludesAutoUpdateColumns(t *testing.T) {
+	table := &TableInfo{
+		Name: "users",
+		Columns: []ColumnInfo{
+			{Name: "id", Type: "int(11)"},
+			{Name: "name", Type: "varchar(255)"},
+			{Name: "updated_at", Type: "timestamp", AutoUpdate: true},
+		},
+		PrimaryKeys: []string{"id"},
+	}
+	sg := &SchemaGenerator{}
+
+	expected := "UPDATE `users` SET `name` = ? WHERE `id` = ?"
+	if result := sg.updateSQL(table); result != expected {
+		t.Errorf("updateSQL() = %q, expected %q", result, expected)
+	}
+
+	comments := sg.columnComments(table, table.Columns[2])
+	if len(comments) != 1 || comments[0] != "auto-update" {
+		t.Errorf("columnComments() for auto-update column = %v, expected [auto-update]", comments)
+	}
+}
+
+func TestSelectSQL_QuotesIdentifiers(t *testing.T) {
+	table := &TableInfo{
+		Name: "orders",
+		Columns: []ColumnInfo{
+			{Name: "id", Type: "int(11)"},
+			{Name: "order", Type: "int(11)"},
+			{Name: "odd`name", Type: "varchar(255)"},
+		},
+	}
+	sg := &SchemaGenerator{}
+
+	expected := "SELECT `id`, `order`, `odd``name` FROM `orders`"
+	if result := sg.selectSQL(table); result != expected {
+		t.Errorf("selectSQL() = %q, expected %q", result, expected)
+	}
+}
+
+func TestGenerateQueries_InClause(t *testing.T) {
+	tests := []struct {
+		style    string
+		expected string
+	}{
+		{PlaceholderQuestion, "`id` IN (NULL)|`id` IN (?)|`na``me` IN (?, ?, ?)\n"},
+		{PlaceholderDollar, "`id` IN (NULL)|`id` IN ($1)|`na``me` IN ($1, $2, $3)\n"},
+		{PlaceholderNamed, "`id` IN (NULL)|`id` IN (?)|`na``me` IN (?, ?, ?)\n"},
+	}
+
+	table := queriesTestTable()
+
+	for _, test := range tests {
+		sg := NewSchemaGeneratorFromSource(newMemorySource(table), &Config{PlaceholderStyle: test.style})
+		result, err := sg.GenerateQueries(context.Background(), "main")
+		if err != nil {
+			t.Fatalf("GenerateQueries() error: %v", err)
+		}
+
+		output := runGenerated(t, map[string]string{"queries.go": result}, `package main
+
+import "fmt"
+
+func main() {
+	fmt.Printf("%s|%s|%s\n", InClause("id", 0), InClause("id", 1), InClause("na`+"`"+`me", 3))
+}
+`)
+		if output != test.expected {
+			t.Errorf("InClause() with style %q output = %q, expected %q", test.style, output, test.expected)
+		}
+	}
+}
+
+func TestGenerateQueries_InClauseUnexported(t *testing.T) {
+	no := false
+	sg := NewSchemaGeneratorFromSource(newMemorySource(queriesTestTable()), &Config{ExportConstants: &no})
+	result, err := sg.GenerateQueries(context.Background(), "main")
+	if err != nil {
+		t.Fatalf("GenerateQueries() error: %v", err)
+	}
+	if strings.Contains(result, "func InClause(") {
+		t.Errorf("GenerateQueries() with export_constants: false exports InClause:\n%s", result)
+	}
+
+	output := runGenerated(t, map[string]string{"queries.go": result}, `package main
+
+import "fmt"
+
+func main() {
+	fmt.Println(inClause("id", 2))
+}
+`)
+	if expected := "`id` IN (?, ?)\n"; output != expected {
+		t.Errorf("inClause() output = %q, expected %q", output, expected)
+	}
+}
+
+func TestGenerateQueries_BatchInsert(t *testing.T) {
+	tests := []struct {
+		style    string
+		expected string
+	}{
+		{PlaceholderQuestion, "INSERT INTO `users` (`name`, `email`) VALUES (?, ?)|" +
+			"INSERT INTO `users` (`name`, `email`) VALUES (?, ?), (?, ?), (?, ?)|true\n"},
+		{PlaceholderDollar, "INSERT INTO `users` (`name`, `email`) VALUES ($1, $2)|" +
+			"INSERT INTO `users` (`name`, `email`) VALUES ($1, $2), ($3, $4), ($5, $6)|true\n"},
+		{PlaceholderNamed, "INSERT INTO `users` (`name`, `email`) VALUES (?, ?)|" +
+			"INSERT INTO `users` (`name`, `email`) VALUES (?, ?), (?, ?), (?, ?)|true\n"},
+	}
+
+	table := queriesTestTable()
+
+	for _, test := range tests {
+		sg := NewSchemaGeneratorFromSource(newMemorySource(table), &Config{PlaceholderStyle: test.style})
+		result, err := sg.GenerateQueries(context.Background(), "main")
+		if err != nil {
+			t.Fatalf("GenerateQueries() error: %v", err)
+		}
+
+		output := runGenerated(t, map[string]string{"queries.go": result}, `package main
+
+import "fmt"
+
+func main() {
+	one, _ := UsersBatchInsertSQL(1)
+	three, _ := UsersBatchInsertSQL(3)
+	_, err := UsersBatchInsertSQL(0)
+	fmt.Printf("%s|%s|%t\n", one, three, err != nil)
+}
+`)
+		if output != test.expected {
+			t.Errorf("UsersBatchInsertSQL() with style %q output = %q, expected %q", test.style, output, test.expected)
+		}
+		parts := strings.Split(output, "|")
+		for i, n := range []int{1, 3} {
+			if groups := strings.Count(parts[i], "), (") + 1; groups != n {
+				t.Errorf("UsersBatchInsertSQL(%d) with style %q has %d value groups", n, test.style, groups)
+			}
+		}
+	}
+}
+
+func TestGenerateQueries_BatchInsertDollarStride(t *testing.T) {
+	tests := []struct {
+		table    *TableInfo
+		funcName string
+		stride   string
+		expected string
+	}{
+		{
+			&TableInfo{Name: "tags", Columns: []ColumnInfo{{Name: "label", Type: "varchar(32)"}}},
+			"TagsBatchInsertSQL",
+			"\t\tbase := i\n",
+			"INSERT INTO `tags` (`label`) VALUES ($1), ($2)\n",
+		},
+		{
+			&TableInfo{Name: "points", Columns: []ColumnInfo{{Name: "x", Type: "int(11)"}, {Name: "y", Type: "int(11)"}, {Name: "z", Type: "int(11)"}}},
+			"PointsBatchInsertSQL",
+			"\t\tbase := i * 3\n",
+			"INSERT INTO `points` (`x`, `y`, `z`) VALUES ($1, $2, $3), ($4, $5, $6)\n",
+		},
+	}
+
+	for _, test := range tests {
+		sg := NewSchemaGeneratorFromSource(newMemorySource(test.table), &Config{PlaceholderStyle: PlaceholderDollar})
+		result, err := sg.GenerateQueries(context.Background(), "main")
+		if err != nil {
+			t.Fatalf("GenerateQueries() error: %v", err)
+		}
+		if !strings.Contains(result, test.stride) {
+			t.Errorf("%s does not compute the stride as %q:\n%s", test.funcName, test.stride, result)
+		}
+
+		output := runGenerated(t, map[string]string{"queries.go": result}, `package main
+
+import "fmt"
+
+func main() {
+	query, _ := `+test.funcName+`(2)
+	fmt.Println(query)
+}
+`)
+		if output != test.expected {
+			t.Errorf("%s(2) output = %q, expected %q", test.funcName, output, test.expected)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// GenerateRepositories generates a repository interface per table with
+// methods to get, list, insert, update and delete rows of its struct. Only
+// the interfaces are generated; implementing them is left to the application.
+// Tables without a primary key get no GetByID, Update and Delete methods.
+func (sg *SchemaGenerator) GenerateRepositories(ctx context.Context, packageName string) (string, error) {
+	tableInfos, err := sg.loadTables(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	// Imports are collected from the primary key types while writing the body
+	imports := make(map[string]bool)
+	var builder strings.Builder
+
+	for _, tableInfo := range tableInfos {
+		imports["context"] = true
+
+		structName := sg.toStructName(tableInfo.Name)
+		interfaceName := sg.toRepositoryName(tableInfo.Name)
+
+		keyParams, keyImports, hasKey := sg.primaryKeyParams(tableInfo)
+		for _, imp := range keyImports {
+			imports[imp] = true
+		}
+
+		var methods []string
+		if hasKey {
+			methods = append(methods, fmt.Sprintf("GetByID(ctx context.Context, %s) (*%s, error)", keyParams, structName))
+		}
+		methods = append(methods, fmt.Sprintf("List(ctx context.Context) ([]%s, error)", structName))
+		if len(insertColumns(tableInfo)) > 0 {
+			methods = append(methods, fmt.Sprintf("Insert(ctx context.Context, row *%s) error", structName))
+		}
+		if sg.updateSQL(tableInfo) != "" {
+			methods = append(methods, fmt.Sprintf("Update(ctx context.Context, row *%s) error", structName))
+		}
+		if hasKey {
+			methods = append(methods, fmt.Sprintf("Delete(ctx context.Context, %s) error", keyParams))
+		}
+
+		builder.WriteString(fmt.Sprintf("// %s stores rows of the %s table\n", interfaceName, tableInfo.Name))
+		builder.WriteString(fmt.Sprintf("type %s interface {\n", interfaceName))
+		for _, method := range methods {
+			builder.WriteString("\t" + method + "\n")
+		}
+		builder.WriteString("}\n\n")
+	}
+
+	var header strings.Builder
+	header.WriteString(sg.banner())
+	header.WriteString("package " + packageName + "\n\n")
+	writeImports(&header, imports)
+
+	return header.String() + builder.String(), nil
+}
+
+// primaryKeyParams returns the parameter list of a table's primary key
+// columns, such as "userID int32, roleID int32", and the imports of their
+// types. ok is false if the table has no primary key or a key column is not
+// part of the generated struct.
+func (sg *SchemaGenerator) primaryKeyParams(tableInfo *TableInfo) (params string, imports []string, ok bool) {
+	if len(tableInfo.PrimaryKeys) == 0 {
+		return "", nil, false
+	}
+
+	columns := make(map[string]ColumnInfo)
+	for _, col := range tableInfo.Columns {
+		columns[col.Name] = col
+	}
+
+	list := make([]string, len(tableInfo.PrimaryKeys))
+	for i, pk := range tableInfo.PrimaryKeys {
+		col, exists := columns[pk]
+		if !exists {
+			return "", nil, false
+		}
+		goType, typeImports := sg.goType(tableInfo, col)
+		imports = append(imports, typeImports...)
+
+		fieldName := sg.fieldName(tableInfo.Name, pk)
+		param := toParamName(fieldName)
+		if param == "ctx" {
+			param += "_"
+		}
+		list[i] = fmt.Sprintf("%s %s", param, goType)
+	}
+
+	return strings.Join(list, ", "), imports, true
+}
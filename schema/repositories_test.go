@@ -0,0 +1,112 @@
+package schema
+
+import (
+	"context"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// interfaceMethods parses src and returns the methods of the named interface
+// mapped to their parameter types, without the leading context.Context
+func interfaceMethods(t *testing.T, src, name string) map[string]string {
+	t.Helper()
+
+	file, err := parser.ParseFile(token.NewFileSet(), "repositories.go", src, 0)
+	if err != nil {
+		t.Fatalf("generated code does not parse: %v\n%s", err, src)
+	}
+
+	obj := file.Scope.Lookup(name)
+	if obj == nil {
+		t.Fatalf("%s not declared in:\n%s", name, src)
+	}
+	iface := obj.Decl.(*ast.TypeSpec).Type.(*ast.InterfaceType)
+
+	methods := make(map[string]string)
+	for _, method := range iface.Methods.List {
+		var params []string
+		for _, field := range method.Type.(*ast.FuncType).Params.List[1:] {
+			for range field.Names {
+				params = append(params, src[field.Type.Pos()-1:field.Type.End()-1])
+			}
+		}
+		methods[method.Names[0].Name] = strings.Join(params, ", ")
+	}
+	return methods
+}
+
+func TestGenerateRepositories(t *testing.T) {
+	userRoles := &TableInfo{
+		Name: "user_roles",
+		Columns: []ColumnInfo{
+			{Name: "user_id", Type: "int(11)"},
+			{Name: "role_id", Type: "bigint(20)"},
+			{Name: "granted_at", Type: "datetime"},
+		},
+		PrimaryKeys: []string{"user_id", "role_id"},
+	}
+	auditLog := &TableInfo{
+		Name:    "audit_log",
+		Columns: []ColumnInfo{{Name: "message", Type: "text"}},
+	}
+
+	sg := NewSchemaGeneratorFromSource(newMemorySource(queriesTestTable(), userRoles, auditLog), nil)
+	result, err := sg.GenerateRepositories(context.Background(), "main")
+	if err != nil {
+		t.Fatalf("GenerateRepositories() error: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		expected map[string]string
+	}{
+		{"UsersRepository", map[string]string{
+			"GetByID": "int32",
+			"List":    "",
+			"Insert":  "*Users",
+			"Update":  "*Users",
+			"Delete":  "int32",
+		}},
+		{"UserRolesRepository", map[string]string{
+			"GetByID": "int32, int64",
+			"List":    "",
+			"Insert":  "*UserRoles",
+			"Update":  "*UserRoles",
+			"Delete":  "int32, int64",
+		}},
+		{"AuditLogRepository", map[string]string{
+			"List":   "",
+			"Insert": "*AuditLog",
+		}},
+	}
+
+	for _, test := range tests {
+		methods := interfaceMethods(t, result, test.name)
+		if !reflect.DeepEqual(methods, test.expected) {
+			t.Errorf("%s methods = %v, expected %v", test.name, methods, test.expected)
+		}
+	}
+
+	if !strings.Contains(result, "GetByID(ctx context.Context, userId int32, roleId int64) (*UserRoles, error)") {
+		t.Errorf("GenerateRepositories() missing composite key getter in:\n%s", result)
+	}
+
+	structs, err := sg.GenerateStructs(context.Background(), "main")
+	if err != nil {
+		t.Fatalf("GenerateStructs() error: %v", err)
+	}
+	runGenerated(t, map[string]string{"structs.go": structs, "repositories.go": result}, `package main
+
+var (
+	_ UsersRepository
+	_ UserRolesRepository
+	_ AuditLogRepository
+)
+
+func main() {}
+`)
+}
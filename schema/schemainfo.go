@@ -0,0 +1,64 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// GenerateSchemaInfo generates a Schema function returning the generated
+// tables, their columns and primary keys as plain data, so tools can
+// introspect the package without reflection
+func (sg *SchemaGenerator) GenerateSchemaInfo(ctx context.Context, packageName string) (string, error) {
+	tableInfos, err := sg.loadTables(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	var builder strings.Builder
+	builder.WriteString(sg.banner())
+	builder.WriteString("package " + packageName + "\n\n")
+
+	builder.WriteString("// TableDescriptor describes a table this package was generated from\n")
+	builder.WriteString("type TableDescriptor struct {\n")
+	builder.WriteString("\tName        string\n")
+	builder.WriteString("\tColumns     []ColumnDescriptor\n")
+	builder.WriteString("\tPrimaryKeys []string\n")
+	builder.WriteString("}\n\n")
+
+	builder.WriteString("// ColumnDescriptor describes a column of a generated table\n")
+	builder.WriteString("type ColumnDescriptor struct {\n")
+	builder.WriteString("\tName     string\n")
+	builder.WriteString("\tType     string // MariaDB column type\n")
+	builder.WriteString("\tGoType   string // Go type of the struct field\n")
+	builder.WriteString("\tNullable bool\n")
+	builder.WriteString("}\n\n")
+
+	builder.WriteString("// Schema returns the tables this package was generated from in generation order\n")
+	builder.WriteString("func Schema() []TableDescriptor {\n")
+	builder.WriteString("\treturn []TableDescriptor{\n")
+
+	for _, tableInfo := range tableInfos {
+		builder.WriteString("\t\t{\n")
+		builder.WriteString(fmt.Sprintf("\t\t\tName: %q,\n", tableInfo.Name))
+		builder.WriteString("\t\t\tColumns: []ColumnDescriptor{\n")
+		for _, col := range tableInfo.Columns {
+			goType, _ := sg.goType(tableInfo, col)
+			builder.WriteString(fmt.Sprintf("\t\t\t\t{Name: %q, Type: %q, GoType: %q, Nullable: %t},\n",
+				col.Name, col.Type, goType, col.Nullable))
+		}
+		builder.WriteString("\t\t\t},\n")
+
+		quoted := make([]string, len(tableInfo.PrimaryKeys))
+		for i, pk := range tableInfo.PrimaryKeys {
+			quoted[i] = fmt.Sprintf("%q", pk)
+		}
+		builder.WriteString(fmt.Sprintf("\t\t\tPrimaryKeys: []string{%s},\n", strings.Join(quoted, ", ")))
+		builder.WriteString("\t\t},\n")
+	}
+
+	builder.WriteString("\t}\n")
+	builder.WriteString("}\n")
+
+	return builder.String(), nil
+}
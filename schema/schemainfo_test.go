@@ -0,0 +1,70 @@
+package schema
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestGenerateSchemaInfo(t *testing.T) {
+	sg := NewSchemaGeneratorFromSource(newMemorySource(metadataTestTable()), nil)
+
+	result, err := sg.GenerateSchemaInfo(context.Background(), "main")
+	if err != nil {
+		t.Fatalf("GenerateSchemaInfo() error: %v", err)
+	}
+
+	expected := []string{
+		"func Schema() []TableDescriptor {",
+		`Name: "users",`,
+		`{Name: "id", Type: "int(11)", GoType: "int32", Nullable: false},`,
+		`{Name: "name", Type: "varchar(255)", GoType: "sql.NullString", Nullable: true},`,
+		`PrimaryKeys: []string{"id"},`,
+	}
+	for _, exp := range expected {
+		if !strings.Contains(result, exp) {
+			t.Errorf("GenerateSchemaInfo() missing %q in:\n%s", exp, result)
+		}
+	}
+
+	output := runGenerated(t, map[string]string{"schema_info.go": result}, `package main
+
+import "fmt"
+
+func main() {
+	for _, table := range Schema() {
+		fmt.Println(table.Name, len(table.Columns), table.Columns[1].GoType, table.PrimaryKeys)
+	}
+}
+`)
+	if expected := "users 2 sql.NullString [id]\n"; output != expected {
+		t.Errorf("Schema() output = %q, expected %q", output, expected)
+	}
+}
+
+func TestGenerateAll_SchemaInfoOptIn(t *testing.T) {
+	schemaTable := &TableInfo{Name: "schema", Columns: []ColumnInfo{{Name: "version", Type: "int(11)"}}}
+
+	files, err := NewSchemaGeneratorFromSource(newMemorySource(schemaTable), nil).GenerateAll(context.Background(), "main")
+	if err != nil {
+		t.Fatalf("GenerateAll() error: %v", err)
+	}
+	if _, exists := files["schema_info.go"]; exists {
+		t.Errorf("GenerateAll() generated schema_info.go without generate_schema_info")
+	}
+	if !strings.Contains(files["structs.go"], "type Schema struct {") {
+		t.Errorf("GenerateAll() structs.go lacks the schema table:\n%s", files["structs.go"])
+	}
+
+	files, err = NewSchemaGeneratorFromSource(newMemorySource(metadataTestTable()), &Config{GenerateSchemaInfo: true}).GenerateAll(context.Background(), "main")
+	if err != nil {
+		t.Fatalf("GenerateAll() with generate_schema_info error: %v", err)
+	}
+	if !strings.Contains(files["schema_info.go"], "func Schema() []TableDescriptor {") {
+		t.Errorf("GenerateAll() with generate_schema_info lacks the Schema function:\n%s", files["schema_info.go"])
+	}
+
+	if _, err := NewSchemaGeneratorFromSource(newMemorySource(schemaTable), &Config{GenerateSchemaInfo: true}).GenerateAll(context.Background(), "main"); err == nil {
+		t.Errorf("GenerateAll() with generate_schema_info accepted a table named schema")
+	}
+}
@@ -0,0 +1,63 @@
+package schema
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// SchemaDescriptor is the schema.json written by GenerateSchemaJSON, for
+// applications that embed it with go:embed and decode it at runtime
+type SchemaDescriptor struct {
+	Tables []TableDescriptor `json:"tables"`
+}
+
+// TableDescriptor describes a table of a SchemaDescriptor
+type TableDescriptor struct {
+	Name        string             `json:"name"`
+	Columns     []ColumnDescriptor `json:"columns"`
+	PrimaryKeys []string           `json:"primary_keys"`
+}
+
+// ColumnDescriptor describes a column of a TableDescriptor
+type ColumnDescriptor struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`    // MariaDB column type
+	GoType   string `json:"go_type"` // Go type of the struct field
+	Nullable bool   `json:"nullable"`
+}
+
+// GenerateSchemaJSON generates a minimized JSON SchemaDescriptor of the
+// selected tables. Tables are sorted by name, columns keep their ordinal
+// order and no timestamp is included, so the same schema always yields the
+// same bytes and an embedded copy only changes when the schema does.
+func (sg *SchemaGenerator) GenerateSchemaJSON(ctx context.Context) (string, error) {
+	tableInfos, err := sg.loadTables(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	descriptor := SchemaDescriptor{Tables: make([]TableDescriptor, 0, len(tableInfos))}
+	for _, tableInfo := range tableInfos {
+		table := TableDescriptor{
+			Name:        tableInfo.Name,
+			Columns:     make([]ColumnDescriptor, len(tableInfo.Columns)),
+			PrimaryKeys: append([]string{}, tableInfo.PrimaryKeys...),
+		}
+		for i, col := range tableInfo.Columns {
+			goType, _ := sg.goType(tableInfo, col)
+			table.Columns[i] = ColumnDescriptor{Name: col.Name, Type: col.Type, GoType: goType, Nullable: col.Nullable}
+		}
+		descriptor.Tables = append(descriptor.Tables, table)
+	}
+	sort.Slice(descriptor.Tables, func(i, j int) bool {
+		return descriptor.Tables[i].Name < descriptor.Tables[j].Name
+	})
+
+	data, err := json.Marshal(descriptor)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode schema descriptor: %w", err)
+	}
+	return string(data), nil
+}
@@ -0,0 +1,53 @@
+package schema
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestGenerateSchemaJSON(t *testing.T) {
+	orders := &TableInfo{
+		Name: "orders",
+		Columns: []ColumnInfo{
+			{Name: "id", Type: "bigint(20)"},
+			{Name: "note", Type: "varchar(255)", Nullable: true},
+		},
+		PrimaryKeys: []string{"id"},
+	}
+	audit := &TableInfo{Name: "audit", Columns: []ColumnInfo{{Name: "message", Type: "text"}}}
+
+	generate := func() string {
+		t.Helper()
+		sg := NewSchemaGeneratorFromSource(newMemorySource(orders, audit), nil)
+		result, err := sg.GenerateSchemaJSON(context.Background())
+		if err != nil {
+			t.Fatalf("GenerateSchemaJSON() error: %v", err)
+		}
+		return result
+	}
+
+	first := generate()
+	expected := `{"tables":[` +
+		`{"name":"audit","columns":[{"name":"message","type":"text","go_type":"string","nullable":false}],"primary_keys":[]},` +
+		`{"name":"orders","columns":[{"name":"id","type":"bigint(20)","go_type":"int64","nullable":false},` +
+		`{"name":"note","type":"varchar(255)","go_type":"sql.NullString","nullable":true}],"primary_keys":["id"]}]}`
+	if first != expected {
+		t.Errorf("GenerateSchemaJSON() = %s\nexpected %s", first, expected)
+	}
+
+	for i := 0; i < 3; i++ {
+		if again := generate(); again != first {
+			t.Fatalf("GenerateSchemaJSON() is not byte-stable:\n%s\n%s", first, again)
+		}
+	}
+
+	var descriptor SchemaDescriptor
+	if err := json.Unmarshal([]byte(first), &descriptor); err != nil {
+		t.Fatalf("json.Unmarshal() error: %v", err)
+	}
+	if !reflect.DeepEqual(descriptor.Tables[1].PrimaryKeys, []string{"id"}) || descriptor.Tables[1].Columns[1].GoType != "sql.NullString" {
+		t.Errorf("decoded descriptor = %+v", descriptor)
+	}
+}
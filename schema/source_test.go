@@ -0,0 +1,82 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// memorySource is an in-memory Source used to drive the generators in tests
+type memorySource struct {
+	tables   map[string]*TableInfo
+	errors   map[string]error
+	lookups  map[string][]LookupValue // lookup table rows by "table.key.label"
+	database string
+}
+
+func newMemorySource(tables ...*TableInfo) *memorySource {
+	source := &memorySource{
+		tables:  make(map[string]*TableInfo),
+		errors:  make(map[string]error),
+		lookups: make(map[string][]LookupValue),
+	}
+	for _, table := range tables {
+		source.tables[table.Name] = table
+	}
+	return source
+}
+
+func (m *memorySource) GetTables(ctx context.Context) ([]string, error) {
+	var names []string
+	for name := range m.tables {
+		names = append(names, name)
+	}
+	for name := range m.errors {
+		if _, exists := m.tables[name]; !exists {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (m *memorySource) GetTableInfo(ctx context.Context, tableName string) (*TableInfo, error) {
+	if err, exists := m.errors[tableName]; exists {
+		return nil, err
+	}
+	table, exists := m.tables[tableName]
+	if !exists {
+		return nil, fmt.Errorf("table %s does not exist", tableName)
+	}
+	return table, nil
+}
+
+func (m *memorySource) GetAllEnums(ctx context.Context) ([]EnumInfo, error) {
+	names, _ := m.GetTables(ctx)
+
+	var enums []EnumInfo
+	for _, name := range names {
+		table, exists := m.tables[name]
+		if !exists {
+			continue
+		}
+		for _, col := range table.Columns {
+			if col.IsEnum {
+				enums = append(enums, EnumInfo{TableName: name, ColumnName: col.Name, Values: col.EnumValues})
+			}
+		}
+	}
+	return enums, nil
+}
+
+func (m *memorySource) GetLookupValues(ctx context.Context, tableName, keyColumn, labelColumn string) ([]LookupValue, error) {
+	values, exists := m.lookups[tableName+"."+keyColumn+"."+labelColumn]
+	if !exists {
+		return nil, fmt.Errorf("lookup table %s.%s does not exist", tableName, labelColumn)
+	}
+	return values, nil
+}
+
+func (m *memorySource) DatabaseName(ctx context.Context) (string, error) {
+	return m.database, nil
+}
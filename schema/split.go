@@ -0,0 +1,150 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"sort"
+)
+
+// GeneratedPackage is a Go package generated files are written to
+type GeneratedPackage struct {
+	Name       string
+	ImportPath string
+}
+
+// SeparableTypes maps the generation types GenerateAllSplit can write to
+// their own package to their output file
+var SeparableTypes = map[string]string{
+	"constants": "column_constants.go",
+	"structs":   "structs.go",
+	"enums":     "enum_constants.go",
+}
+
+// structCompanions are the generation types outside GenerateAll that refer
+// to the table structs, so GenerateTypeSplit writes them to the package
+// holding structs.go
+var structCompanions = map[string]generateFunc{
+	"repositories": (*SchemaGenerator).GenerateRepositories,
+}
+
+// GenerateAllSplit generates the same code as GenerateAll, writing the types
+// of SeparableTypes listed in separate to their own package and everything
+// else to root. References between the packages, such as struct fields of
+// typed enum types, are qualified with the package name and imported. The
+// result maps each package's import path to its files.
+func (sg *SchemaGenerator) GenerateAllSplit(ctx context.Context, root GeneratedPackage, separate map[string]GeneratedPackage) (map[string]map[string]string, error) {
+	defer sg.enableCache()()
+	defer func() { sg.enumPackage = nil }()
+
+	if sg.config != nil && sg.config.SingleFile && len(separate) > 0 {
+		return nil, fmt.Errorf("a single file cannot be split into packages")
+	}
+
+	generateTypeNames := make([]string, 0, len(separate))
+	for generateType := range separate {
+		if _, exists := SeparableTypes[generateType]; !exists {
+			return nil, fmt.Errorf("%s cannot be written to a separate package", generateType)
+		}
+		generateTypeNames = append(generateTypeNames, generateType)
+	}
+	sort.Strings(generateTypeNames)
+
+	// Everything left in root refers to the enum types of the enums package
+	sg.enumPackage = sg.foreignPackage(root, packageOf(root, separate, "enums"))
+	files, err := sg.GenerateAll(ctx, root.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	result := map[string]map[string]string{root.ImportPath: files}
+	for _, generateType := range generateTypeNames {
+		pkg := separate[generateType]
+		if result[pkg.ImportPath] == nil {
+			result[pkg.ImportPath] = make(map[string]string)
+		}
+
+		if generateType == "structs" && sg.config != nil && sg.config.FilePerTable {
+			structFiles, err := sg.GenerateStructFilesSplit(ctx, root, separate)
+			if err != nil {
+				return nil, fmt.Errorf("failed to generate %s: %w", generateType, err)
+			}
+			for filename, content := range structFiles {
+				delete(files, filename)
+				result[pkg.ImportPath][filename] = content
+			}
+			continue
+		}
+
+		filename := SeparableTypes[generateType]
+		delete(files, filename)
+
+		content, err := sg.GenerateTypeSplit(ctx, generateType, root, separate)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate %s: %w", generateType, err)
+		}
+		result[pkg.ImportPath][filename] = content
+	}
+
+	return result, nil
+}
+
+// GenerateTypeSplit generates a single generation type, such as "structs" or
+// "types", for the package GenerateAllSplit would write it to, qualifying
+// references to typed enums declared in another package. The types of
+// structCompanions, such as "repositories", go to the structs package.
+func (sg *SchemaGenerator) GenerateTypeSplit(ctx context.Context, generateType string, root GeneratedPackage, separate map[string]GeneratedPackage) (string, error) {
+	pkg := packageOf(root, separate, generateType)
+	generate, exists := structCompanions[generateType]
+	if exists {
+		pkg = packageOf(root, separate, "structs")
+	} else if generateFile, known := generateTypes[canonicalGenerateType(generateType)]; known {
+		generate = generateFile.generate
+	} else {
+		return "", fmt.Errorf("unknown generation type: %s", generateType)
+	}
+
+	sg.enumPackage = sg.foreignPackage(pkg, packageOf(root, separate, "enums"))
+	defer func() { sg.enumPackage = nil }()
+
+	return generate(sg, ctx, pkg.Name)
+}
+
+// GenerateStructFilesSplit generates the files of GenerateStructFiles for the
+// package GenerateAllSplit writes the structs to
+func (sg *SchemaGenerator) GenerateStructFilesSplit(ctx context.Context, root GeneratedPackage, separate map[string]GeneratedPackage) (map[string]string, error) {
+	pkg := packageOf(root, separate, "structs")
+	sg.enumPackage = sg.foreignPackage(pkg, packageOf(root, separate, "enums"))
+	defer func() { sg.enumPackage = nil }()
+
+	return sg.GenerateStructFiles(ctx, pkg.Name)
+}
+
+// packageOf returns the package a generation type is written to
+func packageOf(root GeneratedPackage, separate map[string]GeneratedPackage, generateType string) GeneratedPackage {
+	if pkg, exists := separate[generateType]; exists {
+		return pkg
+	}
+	return root
+}
+
+// foreignPackage returns enums if code generated for pkg refers to typed
+// enums declared in that other package, or nil
+func (sg *SchemaGenerator) foreignPackage(pkg, enums GeneratedPackage) *GeneratedPackage {
+	if sg.enumMode() == EnumModeConstants || pkg.ImportPath == enums.ImportPath {
+		return nil
+	}
+	return &enums
+}
+
+// qualifyEnumType qualifies the typed enum type of a column with the package
+// set by GenerateAllSplit, returning the import the qualified type needs
+func (sg *SchemaGenerator) qualifyEnumType(tableName string, col ColumnInfo, goType string) (string, string, bool) {
+	if sg.enumPackage == nil {
+		return goType, "", false
+	}
+	switch goType {
+	case sg.toEnumTypeName(tableName, col.Name), sg.toNullEnumTypeName(tableName, col.Name):
+		return sg.enumPackage.Name + "." + goType, sg.enumPackage.ImportPath, true
+	}
+	return goType, "", false
+}
@@ -0,0 +1,137 @@
+package schema
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestGenerateAllSplit(t *testing.T) {
+	root := GeneratedPackage{Name: "db", ImportPath: "generatedtest/db"}
+	models := GeneratedPackage{Name: "models", ImportPath: "generatedtest/db/models"}
+	enums := GeneratedPackage{Name: "enums", ImportPath: "generatedtest/db/enums"}
+
+	tests := []struct {
+		name         string
+		separate     map[string]GeneratedPackage
+		filePerTable bool
+	}{
+		{"structs", map[string]GeneratedPackage{"structs": models}, false},
+		{"structs and enums", map[string]GeneratedPackage{"structs": models, "enums": enums}, false},
+		{"enums and constants", map[string]GeneratedPackage{"enums": enums, "constants": models}, false},
+		{"structs per table", map[string]GeneratedPackage{"structs": models, "enums": enums}, true},
+	}
+
+	dirs := map[string]string{root.ImportPath: "db", models.ImportPath: "db/models", enums.ImportPath: "db/enums"}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sg := NewSchemaGeneratorFromSource(newMemorySource(enumsTestTable()), &Config{EnumMode: EnumModeTyped, FilePerTable: test.filePerTable})
+			packages, err := sg.GenerateAllSplit(context.Background(), root, test.separate)
+			if err != nil {
+				t.Fatalf("GenerateAllSplit() error: %v", err)
+			}
+
+			files := make(map[string]string)
+			for importPath, packageFiles := range packages {
+				for filename, content := range packageFiles {
+					files[dirs[importPath]+"/"+filename] = content
+				}
+			}
+
+			structsPackage, enumsPackage := root, root
+			if pkg, exists := test.separate["structs"]; exists {
+				structsPackage = pkg
+			}
+			if pkg, exists := test.separate["enums"]; exists {
+				enumsPackage = pkg
+			}
+			imports := map[string]bool{root.ImportPath: true, structsPackage.ImportPath: true, enumsPackage.ImportPath: true}
+			var importBlock []string
+			for importPath := range imports {
+				importBlock = append(importBlock, "\t"+`"`+importPath+`"`)
+			}
+
+			structsFile := "/structs.go"
+			if test.filePerTable {
+				structsFile = "/users.go"
+			}
+			structs := files[dirs[structsPackage.ImportPath]+structsFile]
+			if _, exists := files["db"+structsFile]; exists && structsPackage != root {
+				t.Errorf("db%s is left in the root package", structsFile)
+			}
+			if structsPackage != enumsPackage && !strings.Contains(structs, "Status "+enumsPackage.Name+".UsersStatus") {
+				t.Errorf("structs.go does not qualify the enum type:\n%s", structs)
+			}
+
+			// Every package compiles and the types work across packages
+			output := runGenerated(t, files, `package main
+
+import (
+	"fmt"
+
+`+strings.Join(importBlock, "\n")+`
+)
+
+var _ = db.AllTables
+
+func main() {
+	user := `+structsPackage.Name+`.NewUsers(7, `+enumsPackage.Name+`.Users_Status_Active)
+	query, args := user.ExistsSQL()
+	fmt.Println(user.Status.Valid(), query, args)
+}
+`)
+			expected := "true SELECT EXISTS(SELECT 1 FROM `users` WHERE `id` = ?) [7]\n"
+			if output != expected {
+				t.Errorf("split generated code output = %q, expected %q", output, expected)
+			}
+		})
+	}
+}
+
+func TestGenerateTypeSplit_Repositories(t *testing.T) {
+	root := GeneratedPackage{Name: "db", ImportPath: "generatedtest/db"}
+	separate := map[string]GeneratedPackage{
+		"structs": {Name: "models", ImportPath: "generatedtest/db/models"},
+		"enums":   {Name: "enums", ImportPath: "generatedtest/db/enums"},
+	}
+	sg := NewSchemaGeneratorFromSource(newMemorySource(enumsTestTable()), &Config{EnumMode: EnumModeTyped})
+
+	files := make(map[string]string)
+	for filename, generateType := range map[string]string{
+		"db/models/structs.go":       "structs",
+		"db/models/repositories.go":  "repositories",
+		"db/enums/enum_constants.go": "enums",
+	} {
+		content, err := sg.GenerateTypeSplit(context.Background(), generateType, root, separate)
+		if err != nil {
+			t.Fatalf("GenerateTypeSplit(%s) error: %v", generateType, err)
+		}
+		files[filename] = content
+	}
+
+	if !strings.HasPrefix(files["db/models/repositories.go"], sg.banner()+"package models\n") {
+		t.Errorf("repositories.go is not in the structs package:\n%s", files["db/models/repositories.go"])
+	}
+
+	output := runGenerated(t, files, `package main
+
+import (
+	"fmt"
+
+	"generatedtest/db/models"
+)
+
+var _ models.UsersRepository
+
+func main() {
+	fmt.Println("ok")
+}
+`)
+	if output != "ok\n" {
+		t.Errorf("split generated code output = %q, expected %q", output, "ok\n")
+	}
+
+	if _, err := sg.GenerateTypeSplit(context.Background(), "unknown", root, separate); err == nil {
+		t.Errorf("GenerateTypeSplit() accepted an unknown generation type")
+	}
+}
@@ -0,0 +1,675 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// SQLDumpSource is a Source reading the CREATE TABLE statements of a schema
+// dump, such as the output of mysqldump --no-data, so code can be generated
+// without a running database. It understands the subset of DDL the dump
+// tools emit: column types and attributes, primary keys, indexes, CHECK
+// constraints and system versioning. Other statements are ignored.
+type SQLDumpSource struct {
+	tables   map[string]*TableInfo
+	database string // from the last USE statement
+}
+
+// LoadSQLDump reads and parses a schema dump file
+func LoadSQLDump(path string) (*SQLDumpSource, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema dump %s: %w", path, err)
+	}
+
+	source, err := ParseSQLDump(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse schema dump %s: %w", path, err)
+	}
+	return source, nil
+}
+
+// ParseSQLDump parses the CREATE TABLE statements of a schema dump
+func ParseSQLDump(dump string) (*SQLDumpSource, error) {
+	tokens, err := tokenizeSQL(dump)
+	if err != nil {
+		return nil, err
+	}
+
+	source := &SQLDumpSource{tables: make(map[string]*TableInfo)}
+	for _, statement := range splitStatements(tokens) {
+		if len(statement) == 2 && statement[0].is("USE") {
+			source.database = statement[1].text
+			continue
+		}
+		if !isCreateTable(statement) {
+			continue
+		}
+
+		tableInfo, err := parseCreateTable(dump, statement)
+		if err != nil {
+			return nil, err
+		}
+		if _, exists := source.tables[tableInfo.Name]; exists {
+			return nil, fmt.Errorf("table %s is created twice", tableInfo.Name)
+		}
+		source.tables[tableInfo.Name] = tableInfo
+	}
+
+	return source, nil
+}
+
+// GetTables returns the names of the tables in the dump in sorted order
+func (s *SQLDumpSource) GetTables(ctx context.Context) ([]string, error) {
+	names := make([]string, 0, len(s.tables))
+	for name := range s.tables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// GetTableInfo returns a table of the dump
+func (s *SQLDumpSource) GetTableInfo(ctx context.Context, tableName string) (*TableInfo, error) {
+	tableInfo, exists := s.tables[tableName]
+	if !exists {
+		return nil, fmt.Errorf("table %s does not exist in the schema dump", tableName)
+	}
+	return tableInfo, nil
+}
+
+// GetAllEnums returns the enum columns of all tables ordered by table and column name
+func (s *SQLDumpSource) GetAllEnums(ctx context.Context) ([]EnumInfo, error) {
+	names, _ := s.GetTables(ctx)
+
+	var enums []EnumInfo
+	for _, name := range names {
+		var tableEnums []EnumInfo
+		for _, col := range s.tables[name].Columns {
+			if col.IsEnum {
+				tableEnums = append(tableEnums, EnumInfo{TableName: name, ColumnName: col.Name, Values: col.EnumValues})
+			}
+		}
+		sort.Slice(tableEnums, func(i, j int) bool {
+			return tableEnums[i].ColumnName < tableEnums[j].ColumnName
+		})
+		enums = append(enums, tableEnums...)
+	}
+
+	return enums, nil
+}
+
+// DatabaseName returns the database selected by a USE statement in the dump,
+// which mysqldump emits with --databases. It is empty otherwise.
+func (s *SQLDumpSource) DatabaseName(ctx context.Context) (string, error) {
+	return s.database, nil
+}
+
+// sqlTokenKind classifies the tokens of a schema dump
+type sqlTokenKind int
+
+const (
+	sqlWord       sqlTokenKind = iota // keyword, unquoted identifier or number
+	sqlIdentifier                     // backtick-quoted identifier
+	sqlString                         // quoted string literal
+	sqlPunct                          // any other single character
+)
+
+// sqlToken is a token of a schema dump. start and end are byte offsets into
+// the dump, so expressions can be recovered verbatim.
+type sqlToken struct {
+	kind  sqlTokenKind
+	text  string // unquoted text of identifiers and strings
+	start int
+	end   int
+}
+
+// is reports whether the token is the keyword or punctuation s
+func (t sqlToken) is(s string) bool {
+	return (t.kind == sqlWord || t.kind == sqlPunct) && strings.EqualFold(t.text, s)
+}
+
+// tokenizeSQL splits a dump into tokens, dropping whitespace and comments.
+// Versioned comments such as /*!40101 SET ... */ are dropped as well; the
+// dump tools only use them for session settings and views.
+func tokenizeSQL(dump string) ([]sqlToken, error) {
+	var tokens []sqlToken
+	for i := 0; i < len(dump); {
+		c := dump[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+		case c == '#' || strings.HasPrefix(dump[i:], "-- ") || strings.HasPrefix(dump[i:], "--\n"):
+			if end := strings.IndexByte(dump[i:], '\n'); end >= 0 {
+				i += end + 1
+			} else {
+				i = len(dump)
+			}
+		case strings.HasPrefix(dump[i:], "/*"):
+			end := strings.Index(dump[i+2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("unterminated comment at offset %d", i)
+			}
+			i += end + 4
+		case c == '`' || c == '\'' || c == '"':
+			text, end, err := scanQuoted(dump, i)
+			if err != nil {
+				return nil, err
+			}
+			kind := sqlString
+			if c == '`' {
+				kind = sqlIdentifier
+			}
+			tokens = append(tokens, sqlToken{kind: kind, text: text, start: i, end: end})
+			i = end
+		case isSQLWordChar(c):
+			end := i
+			for end < len(dump) && isSQLWordChar(dump[end]) {
+				end++
+			}
+			tokens = append(tokens, sqlToken{kind: sqlWord, text: dump[i:end], start: i, end: end})
+			i = end
+		default:
+			tokens = append(tokens, sqlToken{kind: sqlPunct, text: string(c), start: i, end: i + 1})
+			i++
+		}
+	}
+	return tokens, nil
+}
+
+// scanQuoted reads the quoted string or identifier starting at start and
+// returns its unquoted text and the offset following the closing quote.
+// Quotes are escaped by doubling them or, except for identifiers, with a
+// backslash.
+func scanQuoted(dump string, start int) (string, int, error) {
+	quote := dump[start]
+	var text strings.Builder
+	for i := start + 1; i < len(dump); i++ {
+		c := dump[i]
+		switch {
+		case c == '\\' && quote != '`' && i+1 < len(dump):
+			i++
+			text.WriteByte(unescapeSQL(dump[i]))
+		case c == quote && i+1 < len(dump) && dump[i+1] == quote:
+			i++
+			text.WriteByte(quote)
+		case c == quote:
+			return text.String(), i + 1, nil
+		default:
+			text.WriteByte(c)
+		}
+	}
+	return "", 0, fmt.Errorf("unterminated quoted string at offset %d", start)
+}
+
+// unescapeSQL resolves the character following a backslash in a string literal
+func unescapeSQL(c byte) byte {
+	switch c {
+	case 'n':
+		return '\n'
+	case 't':
+		return '\t'
+	case 'r':
+		return '\r'
+	case '0':
+		return 0
+	default:
+		return c
+	}
+}
+
+func isSQLWordChar(c byte) bool {
+	return c == '_' || c == '$' || c == '.' || c >= '0' && c <= '9' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= 0x80
+}
+
+// splitStatements splits tokens at semicolons, dropping empty statements
+func splitStatements(tokens []sqlToken) [][]sqlToken {
+	var statements [][]sqlToken
+	start := 0
+	for i, token := range tokens {
+		if token.is(";") {
+			if i > start {
+				statements = append(statements, tokens[start:i])
+			}
+			start = i + 1
+		}
+	}
+	if start < len(tokens) {
+		statements = append(statements, tokens[start:])
+	}
+	return statements
+}
+
+// isCreateTable reports whether a statement is CREATE [OR REPLACE] [TEMPORARY] TABLE
+func isCreateTable(statement []sqlToken) bool {
+	if len(statement) < 2 || !statement[0].is("CREATE") {
+		return false
+	}
+	for _, token := range statement[1:] {
+		switch {
+		case token.is("TABLE"):
+			return true
+		case token.is("OR"), token.is("REPLACE"), token.is("TEMPORARY"):
+		default:
+			return false
+		}
+	}
+	return false
+}
+
+// matchingParen returns the index of the parenthesis closing the one at open
+func matchingParen(tokens []sqlToken, open int) (int, error) {
+	depth := 0
+	for i := open; i < len(tokens); i++ {
+		switch {
+		case tokens[i].is("("):
+			depth++
+		case tokens[i].is(")"):
+			depth--
+			if depth == 0 {
+				return i, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("unbalanced parentheses at offset %d", tokens[open].start)
+}
+
+// splitDefinitions splits the body of a CREATE TABLE at top-level commas
+func splitDefinitions(tokens []sqlToken) [][]sqlToken {
+	var definitions [][]sqlToken
+	depth, start := 0, 0
+	for i, token := range tokens {
+		switch {
+		case token.is("("):
+			depth++
+		case token.is(")"):
+			depth--
+		case token.is(",") && depth == 0:
+			definitions = append(definitions, tokens[start:i])
+			start = i + 1
+		}
+	}
+	if start < len(tokens) {
+		definitions = append(definitions, tokens[start:])
+	}
+	return definitions
+}
+
+// parseCreateTable parses a CREATE TABLE statement into a TableInfo
+func parseCreateTable(dump string, statement []sqlToken) (*TableInfo, error) {
+	i := 0
+	for !statement[i].is("TABLE") {
+		i++
+	}
+	i++
+	if i+2 < len(statement) && statement[i].is("IF") && statement[i+1].is("NOT") && statement[i+2].is("EXISTS") {
+		i += 3
+	}
+	if i >= len(statement) || statement[i].kind == sqlPunct || statement[i].kind == sqlString {
+		return nil, fmt.Errorf("missing table name in CREATE TABLE at offset %d", statement[0].start)
+	}
+
+	// Schema-qualified names keep only the table name
+	name := statement[i].text
+	i++
+	if i+1 < len(statement) && statement[i].is(".") {
+		name = statement[i+1].text
+		i += 2
+	} else if statement[i-1].kind == sqlWord {
+		if dot := strings.LastIndexByte(name, '.'); dot >= 0 {
+			name = name[dot+1:]
+		}
+	}
+
+	if i >= len(statement) || !statement[i].is("(") {
+		return nil, fmt.Errorf("table %s: only CREATE TABLE with column definitions is supported", name)
+	}
+	closing, err := matchingParen(statement, i)
+	if err != nil {
+		return nil, fmt.Errorf("table %s: %w", name, err)
+	}
+
+	tableInfo := &TableInfo{Name: name}
+	var checks []string
+	for _, definition := range splitDefinitions(statement[i+1 : closing]) {
+		if len(definition) == 0 {
+			continue
+		}
+		if err := parseDefinition(dump, tableInfo, definition, &checks); err != nil {
+			return nil, fmt.Errorf("table %s: %w", name, err)
+		}
+	}
+	// Like the server, reject tables without columns, which no struct can represent
+	if len(tableInfo.Columns) == 0 {
+		return nil, fmt.Errorf("table %s has no columns", name)
+	}
+
+	// Table options follow the definitions
+	options := statement[closing+1:]
+	for j := 0; j+2 < len(options); j++ {
+		if options[j].is("WITH") && options[j+1].is("SYSTEM") && options[j+2].is("VERSIONING") {
+			tableInfo.IsVersioned = true
+		}
+	}
+
+	for k, col := range tableInfo.Columns {
+		if isTextType(col.Type) {
+			tableInfo.Columns[k].IsJSON = hasJSONCheck(checks, col.Name)
+		}
+	}
+
+	// information_schema lists indexes by name
+	sort.SliceStable(tableInfo.Indexes, func(a, b int) bool {
+		return tableInfo.Indexes[a].Name < tableInfo.Indexes[b].Name
+	})
+
+	return tableInfo, nil
+}
+
+// parseDefinition parses a column, key or constraint definition of a table.
+// CHECK clauses are collected in checks, as they can reference any column.
+func parseDefinition(dump string, tableInfo *TableInfo, definition []sqlToken, checks *[]string) error {
+	first := definition[0]
+
+	if first.is("CONSTRAINT") {
+		// CONSTRAINT [name] followed by the constraint itself
+		rest := definition[1:]
+		if len(rest) > 0 && !rest[0].is("CHECK") && !rest[0].is("PRIMARY") && !rest[0].is("UNIQUE") && !rest[0].is("FOREIGN") {
+			rest = rest[1:]
+		}
+		if len(rest) == 0 {
+			return fmt.Errorf("incomplete constraint at offset %d", first.start)
+		}
+		return parseDefinition(dump, tableInfo, rest, checks)
+	}
+
+	switch {
+	case first.is("CHECK"):
+		*checks = append(*checks, dump[first.start:definition[len(definition)-1].end])
+		return nil
+	case first.is("FOREIGN"), first.is("PERIOD"):
+		return nil
+	case first.is("PRIMARY"):
+		columns, _, err := indexColumns(definition)
+		if err != nil {
+			return err
+		}
+		tableInfo.PrimaryKeys = columns
+		tableInfo.Indexes = append(tableInfo.Indexes, IndexInfo{Name: "PRIMARY", Columns: columns, Unique: true, Type: "BTREE"})
+		return nil
+	case first.is("UNIQUE"), first.is("KEY"), first.is("INDEX"), first.is("FULLTEXT"), first.is("SPATIAL"):
+		columns, indexName, err := indexColumns(definition)
+		if err != nil {
+			return err
+		}
+		if indexName == "" {
+			indexName = columns[0]
+		}
+		index := IndexInfo{Name: indexName, Columns: columns, Unique: first.is("UNIQUE"), Type: "BTREE"}
+		if first.is("FULLTEXT") || first.is("SPATIAL") {
+			index.Type = strings.ToUpper(first.text)
+		}
+		for j := 0; j+1 < len(definition); j++ {
+			if definition[j].is("USING") {
+				index.Type = strings.ToUpper(definition[j+1].text)
+			}
+		}
+		tableInfo.Indexes = append(tableInfo.Indexes, index)
+		return nil
+	}
+
+	col, err := parseColumn(dump, tableInfo.Name, definition, checks)
+	if err != nil {
+		return err
+	}
+	tableInfo.Columns = append(tableInfo.Columns, col)
+	return nil
+}
+
+// indexColumns returns the column list and the name of a key definition like
+// UNIQUE KEY `name` (`a`,`b`(10)). The name is empty when it was omitted.
+func indexColumns(definition []sqlToken) ([]string, string, error) {
+	open := -1
+	var name string
+	for j, token := range definition {
+		if token.is("(") {
+			open = j
+			break
+		}
+		if token.kind == sqlIdentifier || token.kind == sqlWord && !isKeyKeyword(token) {
+			name = token.text
+		}
+	}
+	if open < 0 {
+		return nil, "", fmt.Errorf("missing column list in key at offset %d", definition[0].start)
+	}
+
+	closing, err := matchingParen(definition, open)
+	if err != nil {
+		return nil, "", err
+	}
+
+	// Each part starts with the column name, optionally followed by a
+	// prefix length or ASC/DESC
+	var columns []string
+	for _, part := range splitDefinitions(definition[open+1 : closing]) {
+		if len(part) > 0 {
+			columns = append(columns, part[0].text)
+		}
+	}
+	if len(columns) == 0 {
+		return nil, "", fmt.Errorf("empty column list in key at offset %d", definition[0].start)
+	}
+	return columns, name, nil
+}
+
+// isKeyKeyword reports whether a token is one of the keywords starting a key definition
+func isKeyKeyword(token sqlToken) bool {
+	for _, keyword := range []string{"PRIMARY", "UNIQUE", "KEY", "INDEX", "FULLTEXT", "SPATIAL", "IF", "NOT", "EXISTS"} {
+		if token.is(keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseColumn parses a column definition such as
+// `status` enum('a','b') NOT NULL DEFAULT 'a' COMMENT 'state'
+func parseColumn(dump, tableName string, definition []sqlToken, checks *[]string) (ColumnInfo, error) {
+	if len(definition) < 2 || definition[0].kind == sqlPunct || definition[0].kind == sqlString {
+		return ColumnInfo{}, fmt.Errorf("invalid column definition at offset %d", definition[0].start)
+	}
+
+	col := ColumnInfo{Name: definition[0].text, Table: tableName, Nullable: true}
+
+	// The type is a word, optionally followed by its arguments and sign
+	// modifiers. Like COLUMN_TYPE, the name is lowercased and the arguments
+	// are kept verbatim.
+	i := 1
+	col.Type = strings.ToLower(definition[i].text)
+	i++
+	if i < len(definition) && definition[i].is("(") {
+		closing, err := matchingParen(definition, i)
+		if err != nil {
+			return ColumnInfo{}, err
+		}
+		col.Type += dump[definition[i].start:definition[closing].end]
+		i = closing + 1
+	}
+	for i < len(definition) && (definition[i].is("UNSIGNED") || definition[i].is("ZEROFILL") || definition[i].is("SIGNED")) {
+		if !definition[i].is("SIGNED") {
+			col.Type += " " + strings.ToLower(definition[i].text)
+		}
+		i++
+	}
+
+	switch {
+	case strings.HasPrefix(col.Type, "enum("):
+		col.IsEnum = true
+		col.EnumValues = enumValues(col.Type)
+	case strings.HasPrefix(col.Type, "set("):
+		col.IsSet = true
+		col.EnumValues = enumValues(col.Type)
+	}
+	col.VectorDimension = vectorDimension(col.Type)
+	if length, ok := declaredLength(col.Type); ok {
+		col.MaxLength.Int64, col.MaxLength.Valid = length, true
+	}
+
+	for i < len(definition) {
+		token := definition[i]
+		switch {
+		case token.is("NOT") && i+1 < len(definition) && definition[i+1].is("NULL"):
+			col.Nullable = false
+			i += 2
+		case token.is("NULL"):
+			col.Nullable = true
+			i++
+		case token.is("DEFAULT"):
+			end, err := expressionEnd(definition, i+1)
+			if err != nil {
+				return ColumnInfo{}, err
+			}
+			col.DefaultValue.String = dump[definition[i+1].start:definition[end-1].end]
+			col.DefaultValue.Valid = true
+			i = end
+		case token.is("ON") && i+1 < len(definition) && definition[i+1].is("UPDATE"):
+			col.AutoUpdate = true
+			end, err := expressionEnd(definition, i+2)
+			if err != nil {
+				return ColumnInfo{}, err
+			}
+			i = end
+		case token.is("AUTO_INCREMENT"):
+			col.AutoIncrement = true
+			i++
+		case token.is("INVISIBLE"):
+			col.Invisible = true
+			i++
+		case token.is("COMMENT") && i+1 < len(definition):
+			col.Comment.String = definition[i+1].text
+			col.Comment.Valid = true
+			i += 2
+		case token.is("AS"):
+			end, err := parseGeneration(dump, &col, definition, i+1)
+			if err != nil {
+				return ColumnInfo{}, err
+			}
+			i = end
+		case token.is("VIRTUAL"):
+			col.GenerationType.String, col.GenerationType.Valid = "VIRTUAL", true
+			i++
+		case token.is("STORED"), token.is("PERSISTENT"):
+			col.GenerationType.String, col.GenerationType.Valid = "STORED", true
+			i++
+		case token.is("CHECK") && i+1 < len(definition) && definition[i+1].is("("):
+			closing, err := matchingParen(definition, i+1)
+			if err != nil {
+				return ColumnInfo{}, err
+			}
+			*checks = append(*checks, dump[token.start:definition[closing].end])
+			i = closing + 1
+		case token.is("CHARACTER") || token.is("COLLATE") || token.is("CHARSET"):
+			// CHARACTER SET name, CHARSET name or COLLATE name
+			i += 2
+			if token.is("CHARACTER") {
+				i++
+			}
+		default:
+			i++
+		}
+	}
+
+	return col, nil
+}
+
+// parseGeneration parses the expression following AS in a generated column
+// definition and returns the index of the token after it. System-versioning
+// period columns are generated AS ROW START or AS ROW END.
+func parseGeneration(dump string, col *ColumnInfo, definition []sqlToken, i int) (int, error) {
+	col.IsGenerated = true
+	if i+1 < len(definition) && definition[i].is("ROW") {
+		col.GenerationExpression.String = "ROW " + strings.ToUpper(definition[i+1].text)
+		col.GenerationExpression.Valid = true
+		col.Invisible = true
+		return i + 2, nil
+	}
+	if i >= len(definition) || !definition[i].is("(") {
+		return 0, fmt.Errorf("column %s: missing generation expression", col.Name)
+	}
+
+	closing, err := matchingParen(definition, i)
+	if err != nil {
+		return 0, err
+	}
+	col.GenerationExpression.String = strings.TrimSpace(dump[definition[i].end:definition[closing].start])
+	col.GenerationExpression.Valid = true
+	return closing + 1, nil
+}
+
+// expressionEnd returns the index following a default or ON UPDATE value:
+// a literal, a signed number, a function call or a parenthesized expression
+func expressionEnd(definition []sqlToken, i int) (int, error) {
+	if i >= len(definition) {
+		return 0, fmt.Errorf("missing value at offset %d", definition[len(definition)-1].end)
+	}
+	if (definition[i].is("-") || definition[i].is("+")) && i+1 < len(definition) {
+		i++
+	}
+	if definition[i].is("(") {
+		closing, err := matchingParen(definition, i)
+		return closing + 1, err
+	}
+	if definition[i].kind == sqlWord && i+1 < len(definition) && definition[i+1].is("(") {
+		closing, err := matchingParen(definition, i+1)
+		return closing + 1, err
+	}
+	return i + 1, nil
+}
+
+// declaredLength returns the length of char, varchar, binary and varbinary
+// types, which is what CHARACTER_MAXIMUM_LENGTH reports for them
+func declaredLength(columnType string) (int64, bool) {
+	name, args, found := strings.Cut(columnType, "(")
+	if !found {
+		return 0, false
+	}
+	switch name {
+	case "char", "varchar", "binary", "varbinary":
+		length, err := strconv.ParseInt(strings.TrimSuffix(args, ")"), 10, 64)
+		return length, err == nil
+	}
+	return 0, false
+}
+
+// isTextType reports whether a column type is one of the TEXT types that
+// can hold JSON
+func isTextType(columnType string) bool {
+	switch strings.ToLower(columnType) {
+	case "text", "tinytext", "mediumtext", "longtext":
+		return true
+	}
+	return false
+}
+
+// hasJSONCheck reports whether a CHECK clause validates the column with json_valid()
+func hasJSONCheck(checks []string, columnName string) bool {
+	pattern := jsonValidPattern(columnName)
+	for _, check := range checks {
+		if pattern.MatchString(check) {
+			return true
+		}
+	}
+	return false
+}
+
+// jsonValidPattern matches json_valid() applied to a column, quoted with
+// backticks or not
+func jsonValidPattern(columnName string) *regexp.Regexp {
+	return regexp.MustCompile("(?i)json_valid\\(\\s*`?" + regexp.QuoteMeta(columnName) + "`?\\s*\\)")
+}
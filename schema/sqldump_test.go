@@ -0,0 +1,191 @@
+package schema
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// testSQLDump resembles mysqldump --no-data output of a MariaDB schema
+const testSQLDump = `-- MariaDB dump 10.19  Distrib 10.11.6-MariaDB, for Linux (x86_64)
+--
+-- Host: localhost    Database: shop
+-- ------------------------------------------------------
+/*!40101 SET @OLD_CHARACTER_SET_CLIENT=@@CHARACTER_SET_CLIENT */;
+/*!40101 SET NAMES utf8mb4 */;
+
+--
+-- Table structure for table ` + "`orders`" + `
+--
+
+DROP TABLE IF EXISTS ` + "`orders`" + `;
+/*!40101 SET @saved_cs_client     = @@character_set_client */;
+/*!40101 SET character_set_client = utf8 */;
+CREATE TABLE ` + "`orders`" + ` (
+  ` + "`id`" + ` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  ` + "`user_id`" + ` int(11) NOT NULL,
+  ` + "`status`" + ` enum('pending','paid','shipped') NOT NULL DEFAULT 'pending' COMMENT 'order state; see docs',
+  ` + "`note`" + ` varchar(255) CHARACTER SET utf8mb4 COLLATE utf8mb4_bin DEFAULT NULL,
+  ` + "`total`" + ` decimal(10,2) NOT NULL DEFAULT 0.00,
+  ` + "`attributes`" + ` longtext CHARACTER SET utf8mb4 COLLATE utf8mb4_bin DEFAULT NULL CHECK (json_valid(` + "`attributes`" + `)),
+  ` + "`settings`" + ` text DEFAULT NULL,
+  ` + "`note_upper`" + ` varchar(255) GENERATED ALWAYS AS (upper(` + "`note`" + `)) VIRTUAL,
+  ` + "`created_at`" + ` timestamp NOT NULL DEFAULT current_timestamp(),
+  ` + "`updated_at`" + ` timestamp NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp(),
+  PRIMARY KEY (` + "`id`" + `),
+  UNIQUE KEY ` + "`user_status`" + ` (` + "`user_id`,`status`" + `),
+  KEY ` + "`note_prefix`" + ` (` + "`note`" + `(10)),
+  CONSTRAINT ` + "`orders_user`" + ` FOREIGN KEY (` + "`user_id`" + `) REFERENCES ` + "`users`" + ` (` + "`id`" + `),
+  CONSTRAINT ` + "`CONSTRAINT_1`" + ` CHECK (json_valid(` + "`settings`" + `))
+) ENGINE=InnoDB AUTO_INCREMENT=42 DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
+/*!40101 SET character_set_client = @saved_cs_client */;
+
+CREATE TABLE ` + "`prices`" + ` (
+  ` + "`sku`" + ` char(8) NOT NULL,
+  ` + "`amount`" + ` double NOT NULL,
+  ` + "`row_start`" + ` timestamp(6) GENERATED ALWAYS AS ROW START INVISIBLE,
+  ` + "`row_end`" + ` timestamp(6) GENERATED ALWAYS AS ROW END INVISIBLE,
+  PRIMARY KEY (` + "`sku`,`row_end`" + `),
+  PERIOD FOR SYSTEM_TIME (` + "`row_start`, `row_end`" + `)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 WITH SYSTEM VERSIONING;
+
+/*!50001 CREATE VIEW ` + "`paid_orders`" + ` AS select 1 AS ` + "`id`" + ` */;
+`
+
+func TestParseSQLDump(t *testing.T) {
+	ctx := context.Background()
+
+	source, err := ParseSQLDump(testSQLDump)
+	if err != nil {
+		t.Fatalf("ParseSQLDump() error: %v", err)
+	}
+
+	tables, _ := source.GetTables(ctx)
+	if !reflect.DeepEqual(tables, []string{"orders", "prices"}) {
+		t.Fatalf("GetTables() = %v, expected [orders prices]", tables)
+	}
+
+	orders, err := source.GetTableInfo(ctx, "orders")
+	if err != nil {
+		t.Fatalf("GetTableInfo(orders) error: %v", err)
+	}
+	if !reflect.DeepEqual(orders.PrimaryKeys, []string{"id"}) {
+		t.Errorf("PrimaryKeys = %v, expected [id]", orders.PrimaryKeys)
+	}
+
+	columns := make(map[string]ColumnInfo)
+	var names []string
+	for _, col := range orders.Columns {
+		columns[col.Name] = col
+		names = append(names, col.Name)
+	}
+	expectedNames := []string{"id", "user_id", "status", "note", "total", "attributes", "settings", "note_upper", "created_at", "updated_at"}
+	if !reflect.DeepEqual(names, expectedNames) {
+		t.Fatalf("columns = %v, expected %v", names, expectedNames)
+	}
+
+	if id := columns["id"]; id.Type != "bigint(20) unsigned" || id.Nullable || !id.AutoIncrement || id.Table != "orders" {
+		t.Errorf("id = %+v, expected a NOT NULL auto-increment bigint(20) unsigned", id)
+	}
+
+	status := columns["status"]
+	if status.Type != "enum('pending','paid','shipped')" || !status.IsEnum || !reflect.DeepEqual(status.EnumValues, []string{"pending", "paid", "shipped"}) {
+		t.Errorf("status = %+v, expected an enum of pending, paid and shipped", status)
+	}
+	if status.DefaultValue.String != "'pending'" || status.Comment.String != "order state; see docs" {
+		t.Errorf("status default = %q, comment = %q", status.DefaultValue.String, status.Comment.String)
+	}
+
+	if note := columns["note"]; !note.Nullable || note.Type != "varchar(255)" || note.MaxLength.Int64 != 255 || note.DefaultValue.String != "NULL" {
+		t.Errorf("note = %+v, expected a nullable varchar(255)", note)
+	}
+	if total := columns["total"]; total.Type != "decimal(10,2)" || total.DefaultValue.String != "0.00" {
+		t.Errorf("total = %+v, expected decimal(10,2) defaulting to 0.00", total)
+	}
+	if !columns["attributes"].IsJSON || !columns["settings"].IsJSON {
+		t.Error("attributes and settings should be detected as JSON by their json_valid() checks")
+	}
+	if columns["note"].IsJSON {
+		t.Error("note has no json_valid() check and should not be JSON")
+	}
+
+	noteUpper := columns["note_upper"]
+	if !noteUpper.IsGenerated || noteUpper.GenerationExpression.String != "upper(`note`)" || noteUpper.GenerationType.String != "VIRTUAL" {
+		t.Errorf("note_upper = %+v, expected a virtual column generated from upper(`note`)", noteUpper)
+	}
+	if created := columns["created_at"]; created.DefaultValue.String != "current_timestamp()" || created.AutoUpdate {
+		t.Errorf("created_at = %+v, expected a current_timestamp() default", created)
+	}
+	if !columns["updated_at"].AutoUpdate {
+		t.Error("updated_at should be ON UPDATE current_timestamp()")
+	}
+
+	expectedIndexes := []IndexInfo{
+		{Name: "PRIMARY", Columns: []string{"id"}, Unique: true, Type: "BTREE"},
+		{Name: "note_prefix", Columns: []string{"note"}, Type: "BTREE"},
+		{Name: "user_status", Columns: []string{"user_id", "status"}, Unique: true, Type: "BTREE"},
+	}
+	if !reflect.DeepEqual(orders.Indexes, expectedIndexes) {
+		t.Errorf("Indexes = %+v, expected %+v", orders.Indexes, expectedIndexes)
+	}
+
+	prices, err := source.GetTableInfo(ctx, "prices")
+	if err != nil {
+		t.Fatalf("GetTableInfo(prices) error: %v", err)
+	}
+	if !prices.IsVersioned {
+		t.Error("prices should be system-versioned")
+	}
+	if rowEnd := prices.Columns[3]; !rowEnd.IsGenerated || !rowEnd.Invisible || rowEnd.GenerationExpression.String != "ROW END" {
+		t.Errorf("row_end = %+v, expected an invisible ROW END period column", rowEnd)
+	}
+
+	enums, _ := source.GetAllEnums(ctx)
+	if len(enums) != 1 || enums[0].TableName != "orders" || enums[0].ColumnName != "status" {
+		t.Errorf("GetAllEnums() = %+v, expected orders.status", enums)
+	}
+
+	if _, err := source.GetTableInfo(ctx, "paid_orders"); err == nil {
+		t.Error("GetTableInfo(paid_orders) expected error for a view, got nil")
+	}
+}
+
+func TestParseSQLDump_Generate(t *testing.T) {
+	source, err := ParseSQLDump(testSQLDump)
+	if err != nil {
+		t.Fatalf("ParseSQLDump() error: %v", err)
+	}
+
+	files, err := Generate(context.Background(), GenerateOptions{Source: source, PackageName: "models"})
+	if err != nil {
+		t.Fatalf("Generate() error: %v", err)
+	}
+
+	for _, expected := range []string{"Status string `db:\"status\"`", "Attributes types.JSON[any]", "Total float64"} {
+		if !strings.Contains(files["structs.go"], expected) {
+			t.Errorf("structs.go missing %q in:\n%s", expected, files["structs.go"])
+		}
+	}
+}
+
+func TestParseSQLDump_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		dump string
+	}{
+		{"unterminated string", "CREATE TABLE `t` (`a` int DEFAULT 'x);"},
+		{"unterminated comment", "/* CREATE TABLE `t` (`a` int);"},
+		{"unbalanced parentheses", "CREATE TABLE `t` (`a` int;"},
+		{"create like", "CREATE TABLE `t` LIKE `u`;"},
+		{"duplicate table", "CREATE TABLE `t` (`a` int); CREATE TABLE `t` (`b` int);"},
+		{"no columns", "CREATE TABLE `t` ();"},
+		{"only keys", "CREATE TABLE `t` (PRIMARY KEY (`a`));"},
+	}
+
+	for _, test := range tests {
+		if _, err := ParseSQLDump(test.dump); err == nil {
+			t.Errorf("ParseSQLDump(%s) expected error, got nil", test.name)
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package schema
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+)
+
+// GenerationState records the schema signature of every table of the last
+// complete generation run and a signature of the inputs it ran with, so the
+// next run can tell which tables changed since
+type GenerationState struct {
+	Inputs string            `json:"inputs"`
+	Tables map[string]string `json:"tables"`
+}
+
+// LoadState reads a state file written by GenerationState.Save. A missing
+// file yields an empty state, in which every table counts as changed.
+func LoadState(path string) (*GenerationState, error) {
+	state := &GenerationState{Tables: make(map[string]string)}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read state file %s: %w", path, err)
+	}
+
+	if err := json.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("failed to parse state file %s: %w", path, err)
+	}
+	if state.Tables == nil {
+		state.Tables = make(map[string]string)
+	}
+
+	return state, nil
+}
+
+// Save writes the state to path
+func (s *GenerationState) Save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode state: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write state file %s: %w", path, err)
+	}
+	return nil
+}
+
+// ChangedTables returns the sorted names of the tables whose signature
+// differs from the recorded one, including tables that were added or removed.
+// When the inputs signature differs, every table counts as changed.
+func (s *GenerationState) ChangedTables(inputs string, signatures map[string]string) []string {
+	var changed []string
+	for table, signature := range signatures {
+		if s.Inputs != inputs || s.Tables[table] != signature {
+			changed = append(changed, table)
+		}
+	}
+	for table := range s.Tables {
+		if _, exists := signatures[table]; !exists {
+			changed = append(changed, table)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// InputsSignature returns a signature of everything besides the schema that
+// shapes the generated code: the effective configuration and the caller's
+// other inputs, such as command-line flags and output directories
+func InputsSignature(config *Config, inputs ...string) (string, error) {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode configuration: %w", err)
+	}
+
+	hash := sha256.New()
+	hash.Write(data)
+	for _, input := range inputs {
+		hash.Write([]byte{0})
+		hash.Write([]byte(input))
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// TableSignatures returns a signature of every selected table's inspected
+// schema: columns, primary keys and indexes. Unlike UPDATE_TIME it changes
+// only with the table definition, not with the data. Call CacheInspection
+// first to reuse the inspected tables for the generation that follows.
+func (sg *SchemaGenerator) TableSignatures(ctx context.Context) (map[string]string, error) {
+	tableInfos, err := sg.loadTables(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	signatures := make(map[string]string, len(tableInfos))
+	for _, tableInfo := range tableInfos {
+		signatures[tableInfo.Name] = tableSignature(tableInfo)
+	}
+	return signatures, nil
+}
+
+// tableSignature hashes everything inspected about a table
+func tableSignature(tableInfo *TableInfo) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%#v", *tableInfo)))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,139 @@
+package schema
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func stateTestTables() []*TableInfo {
+	return []*TableInfo{
+		metadataTestTable(),
+		{
+			Name:        "posts",
+			Columns:     []ColumnInfo{{Name: "id", Type: "int(11)"}, {Name: "title", Type: "varchar(255)"}},
+			PrimaryKeys: []string{"id"},
+			Indexes:     []IndexInfo{{Name: "title", Columns: []string{"title"}, Type: "BTREE"}},
+		},
+		{
+			Name:    "tags",
+			Columns: []ColumnInfo{{Name: "label", Type: "varchar(32)"}},
+		},
+	}
+}
+
+func TestGenerationState_ChangedTables(t *testing.T) {
+	ctx := context.Background()
+
+	recorded, err := NewSchemaGeneratorFromSource(newMemorySource(stateTestTables()...), nil).TableSignatures(ctx)
+	if err != nil {
+		t.Fatalf("TableSignatures() error: %v", err)
+	}
+	state := &GenerationState{Inputs: "inputs", Tables: recorded}
+
+	unchanged, err := NewSchemaGeneratorFromSource(newMemorySource(stateTestTables()...), nil).TableSignatures(ctx)
+	if err != nil {
+		t.Fatalf("TableSignatures() error: %v", err)
+	}
+	if changed := state.ChangedTables("inputs", unchanged); len(changed) != 0 {
+		t.Errorf("ChangedTables() = %v for the same schema, expected none", changed)
+	}
+
+	tables := stateTestTables()
+	tables[1].Indexes[0].Unique = true
+	signatures, err := NewSchemaGeneratorFromSource(newMemorySource(tables...), nil).TableSignatures(ctx)
+	if err != nil {
+		t.Fatalf("TableSignatures() error: %v", err)
+	}
+	if changed := state.ChangedTables("inputs", signatures); !reflect.DeepEqual(changed, []string{"posts"}) {
+		t.Errorf("ChangedTables() = %v, expected [posts]", changed)
+	}
+
+	// Changed inputs change every table
+	if changed := state.ChangedTables("other inputs", unchanged); !reflect.DeepEqual(changed, []string{"posts", "tags", "users"}) {
+		t.Errorf("ChangedTables() with other inputs = %v, expected every table", changed)
+	}
+
+	// Added and removed tables count as changed
+	tables = stateTestTables()
+	tables[2] = &TableInfo{Name: "labels", Columns: []ColumnInfo{{Name: "label", Type: "varchar(32)"}}}
+	signatures, err = NewSchemaGeneratorFromSource(newMemorySource(tables...), nil).TableSignatures(ctx)
+	if err != nil {
+		t.Fatalf("TableSignatures() error: %v", err)
+	}
+	if changed := state.ChangedTables("inputs", signatures); !reflect.DeepEqual(changed, []string{"labels", "tags"}) {
+		t.Errorf("ChangedTables() = %v, expected [labels tags]", changed)
+	}
+}
+
+func TestGenerationState_SaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "mariakit.state.json")
+
+	state, err := LoadState(path)
+	if err != nil {
+		t.Fatalf("LoadState() of a missing file error: %v", err)
+	}
+	if changed := state.ChangedTables("inputs", map[string]string{"users": "abc"}); !reflect.DeepEqual(changed, []string{"users"}) {
+		t.Errorf("ChangedTables() of an empty state = %v, expected [users]", changed)
+	}
+
+	state.Inputs = "inputs"
+	state.Tables["users"] = "abc"
+	if err := state.Save(path); err != nil {
+		t.Fatalf("Save() error: %v", err)
+	}
+
+	loaded, err := LoadState(path)
+	if err != nil {
+		t.Fatalf("LoadState() error: %v", err)
+	}
+	if loaded.Inputs != state.Inputs || !reflect.DeepEqual(loaded.Tables, state.Tables) {
+		t.Errorf("LoadState() = %v, expected %v", loaded.Tables, state.Tables)
+	}
+}
+
+func TestInputsSignature(t *testing.T) {
+	base, err := InputsSignature(&Config{EnumMode: EnumModeTyped}, "type=all", "output=./generated")
+	if err != nil {
+		t.Fatalf("InputsSignature() error: %v", err)
+	}
+
+	same, _ := InputsSignature(&Config{EnumMode: EnumModeTyped}, "type=all", "output=./generated")
+	if same != base {
+		t.Errorf("InputsSignature() differs for the same inputs")
+	}
+	for name, inputs := range map[string]func() (string, error){
+		"config": func() (string, error) {
+			return InputsSignature(&Config{EnumMode: EnumModeInt}, "type=all", "output=./generated")
+		},
+		"flags": func() (string, error) {
+			return InputsSignature(&Config{EnumMode: EnumModeTyped}, "type=structs", "output=./generated")
+		},
+		"target": func() (string, error) {
+			return InputsSignature(&Config{EnumMode: EnumModeTyped}, "type=all", "output=./models")
+		},
+	} {
+		if signature, _ := inputs(); signature == base {
+			t.Errorf("InputsSignature() ignores a change of the %s", name)
+		}
+	}
+}
+
+func TestTableSignatures_ReusesInspection(t *testing.T) {
+	source := &countingSource{memorySource: newMemorySource(stateTestTables()...), tableInfoCalls: make(map[string]int)}
+	sg := NewSchemaGeneratorFromSource(source, nil)
+	defer sg.CacheInspection()()
+
+	if _, err := sg.TableSignatures(context.Background()); err != nil {
+		t.Fatalf("TableSignatures() error: %v", err)
+	}
+	if _, err := sg.GenerateAll(context.Background(), "models"); err != nil {
+		t.Fatalf("GenerateAll() error: %v", err)
+	}
+	for _, table := range []string{"posts", "tags", "users"} {
+		if calls := source.tableInfoCalls[table]; calls != 1 {
+			t.Errorf("GetTableInfo(%s) called %d times, expected 1", table, calls)
+		}
+	}
+}
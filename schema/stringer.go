@@ -0,0 +1,76 @@
+package schema
+
+import (
+	"fmt"
+	"strings"
+)
+
+// stringerHelper is the function the generated String methods format column
+// values with. NULLs and nil byte slices print as NULL, other byte slices
+// as hex, and other values through fmt.Stringer, driver.Valuer or fmt.
+const stringerHelper = `// formatColumnValue formats a column value for the generated String methods
+func formatColumnValue(v any) string {
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Pointer {
+		if rv.IsNil() {
+			return "NULL"
+		}
+		return formatColumnValue(rv.Elem().Interface())
+	}
+
+	switch v := v.(type) {
+	case []byte:
+		if v == nil {
+			return "NULL"
+		}
+		return fmt.Sprintf("0x%x", v)
+	case fmt.Stringer:
+		return v.String()
+	case driver.Valuer:
+		value, err := v.Value()
+		if err != nil {
+			return fmt.Sprintf("!ERR(%v)", err)
+		}
+		if value == nil {
+			return "NULL"
+		}
+		if b, ok := value.([]byte); ok && utf8.Valid(b) {
+			return string(b)
+		}
+		return formatColumnValue(value)
+	}
+	return fmt.Sprint(v)
+}
+
+`
+
+// stringerImports are the imports stringerHelper needs
+var stringerImports = []string{"database/sql/driver", "fmt", "reflect", "unicode/utf8"}
+
+// generateStringer generates a String method printing the struct as
+// Users{id=1, name=alice}, with sensitive columns masked as ***
+func (sg *SchemaGenerator) generateStringer(tableInfo *TableInfo, structName string, fieldNames []string) string {
+	receiver := sg.toReceiverName(structName)
+
+	parts := make([]string, len(tableInfo.Columns))
+	for i, col := range tableInfo.Columns {
+		if sg.config.isSensitive(tableInfo.Name, col.Name) {
+			parts[i] = fmt.Sprintf("%q", col.Name+"=***")
+			continue
+		}
+		parts[i] = fmt.Sprintf("%q + formatColumnValue(%s.%s)", col.Name+"=", receiver, fieldNames[i])
+	}
+
+	var builder strings.Builder
+	builder.WriteString(fmt.Sprintf("// String implements fmt.Stringer for logging %s rows, printing sensitive\n", tableInfo.Name))
+	builder.WriteString("// columns as ***\n")
+	builder.WriteString(fmt.Sprintf("func (%s %s) String() string {\n", receiver, structName))
+	if len(parts) == 0 {
+		builder.WriteString(fmt.Sprintf("\treturn %q\n", structName+"{}"))
+	} else {
+		builder.WriteString(fmt.Sprintf("\treturn %q +\n", structName+"{"))
+		builder.WriteString("\t\t" + strings.Join(parts, " + \", \" +\n\t\t") + " + \"}\"\n")
+	}
+	builder.WriteString("}\n\n")
+
+	return builder.String()
+}
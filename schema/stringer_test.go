@@ -0,0 +1,69 @@
+package schema
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func stringerTestTable() *TableInfo {
+	return &TableInfo{
+		Name: "users",
+		Columns: []ColumnInfo{
+			{Name: "id", Type: "int(11)"},
+			{Name: "name", Type: "varchar(64)"},
+			{Name: "nickname", Type: "varchar(64)", Nullable: true},
+			{Name: "avatar", Type: "varbinary(16)", Nullable: true},
+			{Name: "password_hash", Type: "varchar(255)"},
+		},
+		PrimaryKeys: []string{"id"},
+	}
+}
+
+func TestGenerateStructs_Stringer(t *testing.T) {
+	config := &Config{GenerateStringers: true, SensitiveColumns: []string{"*.password_hash"}}
+	sg := NewSchemaGeneratorFromSource(newMemorySource(stringerTestTable()), config)
+
+	result, err := sg.GenerateStructs(context.Background(), "main")
+	if err != nil {
+		t.Fatalf("GenerateStructs() error: %v", err)
+	}
+	if !strings.Contains(result, `"password_hash=***" + "}"`) {
+		t.Errorf("GenerateStructs() should mask password_hash in:\n%s", result)
+	}
+
+	output := runGenerated(t, map[string]string{"structs.go": result}, `package main
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+func main() {
+	u := Users{Id: 1, Name: "alice", Avatar: []byte{0xca, 0xfe}, PasswordHash: "secret"}
+	fmt.Println(u)
+
+	u.Nickname = sql.NullString{String: "al", Valid: true}
+	u.Avatar = nil
+	fmt.Println(u.String())
+}
+`)
+
+	expected := "Users{id=1, name=alice, nickname=NULL, avatar=0xcafe, password_hash=***}\n" +
+		"Users{id=1, name=alice, nickname=al, avatar=NULL, password_hash=***}\n"
+	if output != expected {
+		t.Errorf("String() output = %q, expected %q", output, expected)
+	}
+}
+
+func TestGenerateStructs_StringerDisabled(t *testing.T) {
+	sg := NewSchemaGeneratorFromSource(newMemorySource(stringerTestTable()), &Config{SensitiveColumns: []string{"*.password_hash"}})
+
+	result, err := sg.GenerateStructs(context.Background(), "models")
+	if err != nil {
+		t.Fatalf("GenerateStructs() error: %v", err)
+	}
+	if strings.Contains(result, "String() string") || strings.Contains(result, "formatColumnValue") {
+		t.Errorf("GenerateStructs() without generate_stringers should not emit String methods:\n%s", result)
+	}
+}
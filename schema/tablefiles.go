@@ -0,0 +1,97 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// GenerateStructFiles generates the structs like GenerateStructs, writing
+// each table to its own <table>.go. The declarations the tables share, such
+// as the embedded mixin, go to structs.go, which is left out when there are
+// none. Config.FilePerTable makes GenerateAll use this layout.
+func (sg *SchemaGenerator) GenerateStructFiles(ctx context.Context, packageName string) (map[string]string, error) {
+	decls, err := sg.structDecls(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string]string)
+	if decls.prelude != "" || decls.helpers != "" {
+		files["structs.go"] = sg.structFile(packageName, decls.prelude+decls.helpers, decls.imports)
+	}
+	for _, table := range decls.tables {
+		filename := TableFileName(table.table)
+		if _, exists := files[filename]; exists {
+			return nil, fmt.Errorf("table %s maps to the file %s of another table", table.table, filename)
+		}
+		files[filename] = sg.structFile(packageName, table.code, table.imports)
+	}
+
+	return files, nil
+}
+
+// structFile returns a generated file with the given declarations
+func (sg *SchemaGenerator) structFile(packageName, code string, imports map[string]bool) string {
+	var builder strings.Builder
+	builder.WriteString(sg.banner())
+	builder.WriteString("package " + packageName + "\n\n")
+	writeImports(&builder, imports)
+	builder.WriteString(code)
+	return builder.String()
+}
+
+// withStructFiles replaces structs.go in the files of GenerateAll with the
+// files of GenerateStructFiles, rejecting tables whose file would replace
+// another generated file
+func (sg *SchemaGenerator) withStructFiles(ctx context.Context, files map[string]string, packageName string) (map[string]string, error) {
+	structFiles, err := sg.GenerateStructFiles(ctx, packageName)
+	if err != nil {
+		return nil, err
+	}
+
+	delete(files, "structs.go")
+	for filename, content := range structFiles {
+		if _, exists := files[filename]; exists {
+			return nil, fmt.Errorf("the struct file %s collides with a generated file of the same name", filename)
+		}
+		files[filename] = content
+	}
+	return files, nil
+}
+
+// TableFileName returns the file Config.FilePerTable writes a table's struct
+// to. Characters that do not belong in file names become underscores. Names
+// the go command would ignore or only build for tests or some platforms, such
+// as _tmp, users_test or users_linux, and names ending like an extension file
+// get a table affix.
+func TableFileName(tableName string) string {
+	name := strings.Map(func(r rune) rune {
+		if r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return '_'
+	}, tableName)
+
+	if name == "" || strings.HasPrefix(name, "_") {
+		name = "table" + name
+	}
+	parts := strings.Split(name, "_")
+	if len(parts) > 1 && (parts[len(parts)-1] == "test" || parts[len(parts)-1] == "ext" || buildSuffixes[parts[len(parts)-1]]) {
+		name += "_table"
+	}
+	return name + ".go"
+}
+
+// buildSuffixes are the GOOS and GOARCH values the go command treats as a
+// build constraint at the end of a file name
+var buildSuffixes = map[string]bool{
+	"aix": true, "android": true, "darwin": true, "dragonfly": true, "freebsd": true, "hurd": true,
+	"illumos": true, "ios": true, "js": true, "linux": true, "nacl": true, "netbsd": true,
+	"openbsd": true, "plan9": true, "solaris": true, "wasip1": true, "windows": true, "zos": true,
+	"386": true, "amd64": true, "amd64p32": true, "arm": true, "armbe": true, "arm64": true,
+	"arm64be": true, "loong64": true, "mips": true, "mipsle": true, "mips64": true, "mips64le": true,
+	"mips64p32": true, "mips64p32le": true, "ppc": true, "ppc64": true, "ppc64le": true, "riscv": true,
+	"riscv64": true, "s390": true, "s390x": true, "sparc": true, "sparc64": true, "wasm": true,
+}
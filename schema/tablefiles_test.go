@@ -0,0 +1,151 @@
+package schema
+
+import (
+	"context"
+	"database/sql"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestGenerateAll_FilePerTable(t *testing.T) {
+	timestamps := []ColumnInfo{{Name: "created_at", Type: "datetime"}}
+	users := &TableInfo{
+		Name:        "users",
+		Columns:     append([]ColumnInfo{{Name: "id", Type: "int(11)"}, {Name: "name", Type: "varchar(255)"}}, timestamps...),
+		PrimaryKeys: []string{"id"},
+	}
+	posts := &TableInfo{
+		Name:        "posts",
+		Columns:     append([]ColumnInfo{{Name: "id", Type: "int(11)"}}, timestamps...),
+		PrimaryKeys: []string{"id"},
+	}
+
+	tests := []struct {
+		name        string
+		config      *Config
+		structFiles []string
+	}{
+		{"without shared declarations", &Config{FilePerTable: true}, []string{"posts.go", "users.go"}},
+		{"with the mixin", &Config{FilePerTable: true, EmbedMixin: []string{"created_at"}}, []string{"posts.go", "structs.go", "users.go"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			files, err := NewSchemaGeneratorFromSource(newMemorySource(users, posts), test.config).GenerateAll(context.Background(), "main")
+			if err != nil {
+				t.Fatalf("GenerateAll() error: %v", err)
+			}
+
+			var structFiles []string
+			for filename := range files {
+				if filename != "structs.go" && strings.Contains(files[filename], "ScanRow(scanner") {
+					structFiles = append(structFiles, filename)
+				}
+			}
+			if _, exists := files["structs.go"]; exists {
+				structFiles = append(structFiles, "structs.go")
+			}
+			sort.Strings(structFiles)
+			if strings.Join(structFiles, " ") != strings.Join(test.structFiles, " ") {
+				t.Errorf("struct files = %v, expected %v", structFiles, test.structFiles)
+			}
+			if !strings.Contains(files["users.go"], "type Users struct {") || strings.Contains(files["users.go"], "type Posts struct {") {
+				t.Errorf("users.go does not hold exactly the users struct:\n%s", files["users.go"])
+			}
+
+			delete(files, "enum_constants.go")
+			output := runGenerated(t, files, `package main
+
+import (
+	"fmt"
+	"time"
+)
+
+var _ = ScanPosts
+
+func main() {
+	fmt.Println(NewUsers(1, "alice", time.Time{}).Name)
+}
+`)
+			if output != "alice\n" {
+				t.Errorf("generated code output = %q, expected %q", output, "alice\n")
+			}
+		})
+	}
+}
+
+func TestGenerateAll_FilePerTableEmbedMixin(t *testing.T) {
+	// Neither timestamp is a constructor parameter, so users.go does not use
+	// time once the mixin declares the fields in structs.go
+	timestamps := []ColumnInfo{
+		{Name: "created_at", Type: "datetime", DefaultValue: sql.NullString{String: "current_timestamp()", Valid: true}},
+		{Name: "updated_at", Type: "datetime", Nullable: true},
+	}
+	users := &TableInfo{
+		Name:        "users",
+		Columns:     append([]ColumnInfo{{Name: "id", Type: "int(11)", AutoIncrement: true}, {Name: "name", Type: "varchar(255)"}}, timestamps...),
+		PrimaryKeys: []string{"id"},
+	}
+	config := &Config{FilePerTable: true, EmbedMixin: []string{"created_at", "updated_at"}}
+
+	files, err := NewSchemaGeneratorFromSource(newMemorySource(users), config).GenerateAll(context.Background(), "main")
+	if err != nil {
+		t.Fatalf("GenerateAll() error: %v", err)
+	}
+	if strings.Contains(files["users.go"], `"time"`) {
+		t.Errorf("users.go imports time without using it:\n%s", files["users.go"])
+	}
+	if !strings.Contains(files["structs.go"], `"time"`) {
+		t.Errorf("structs.go does not import time for the mixin:\n%s", files["structs.go"])
+	}
+
+	delete(files, "enum_constants.go")
+	output := runGenerated(t, files, `package main
+
+import "fmt"
+
+func main() {
+	user := NewUsers("alice")
+	fmt.Println(user.Name, user.CreatedAt.IsZero())
+}
+`)
+	if output != "alice true\n" {
+		t.Errorf("generated code output = %q, expected %q", output, "alice true\n")
+	}
+}
+
+func TestGenerateAll_FilePerTableCollision(t *testing.T) {
+	queries := &TableInfo{Name: "queries", Columns: []ColumnInfo{{Name: "sql_text", Type: "text"}}}
+
+	_, err := NewSchemaGeneratorFromSource(newMemorySource(queries), &Config{FilePerTable: true}).GenerateAll(context.Background(), "main")
+	if err == nil || !strings.Contains(err.Error(), "queries.go") {
+		t.Errorf("GenerateAll() error = %v, expected a collision with queries.go", err)
+	}
+}
+
+func TestTableFileName(t *testing.T) {
+	tests := map[string]string{
+		"users":       "users.go",
+		"order-items": "order_items.go",
+		"_tmp":        "table_tmp.go",
+		"users_test":  "users_test_table.go",
+		"users_linux": "users_linux_table.go",
+		"users_ext":   "users_ext_table.go",
+		"linux":       "linux.go",
+	}
+	for tableName, expected := range tests {
+		if filename := TableFileName(tableName); filename != expected {
+			t.Errorf("TableFileName(%q) = %q, expected %q", tableName, filename, expected)
+		}
+	}
+}
+
+func TestConfigValidate_FilePerTable(t *testing.T) {
+	if err := (&Config{FilePerTable: true}).Validate(); err != nil {
+		t.Errorf("Validate() unexpected error: %v", err)
+	}
+	if err := (&Config{FilePerTable: true, SingleFile: true}).Validate(); err == nil {
+		t.Errorf("Validate() accepted file_per_table with single_file")
+	}
+}
@@ -0,0 +1,153 @@
+package schema
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// structTagNames returns the struct tag names to emit, from StructTags or the ORM preset
+func (sg *SchemaGenerator) structTagNames() []string {
+	if sg.config == nil {
+		return []string{"db"}
+	}
+	if len(sg.config.StructTags) > 0 {
+		return sg.config.StructTags
+	}
+
+	switch sg.config.ORMPreset {
+	case ORMBun:
+		return []string{"bun"}
+	case ORMGorm:
+		return []string{"gorm"}
+	default:
+		return []string{"db"}
+	}
+}
+
+// structTag builds the struct tag of a column field, without backquotes
+func (sg *SchemaGenerator) structTag(tableInfo *TableInfo, col ColumnInfo) string {
+	isPK := false
+	for _, pk := range tableInfo.PrimaryKeys {
+		if pk == col.Name {
+			isPK = true
+			break
+		}
+	}
+
+	var tags []string
+	for _, name := range sg.structTagNames() {
+		value := col.Name
+
+		switch name {
+		case "bun":
+			if isPK {
+				value += ",pk"
+			}
+			if col.AutoIncrement {
+				value += ",autoincrement"
+			}
+		case "gorm":
+			value = "column:" + col.Name
+			if isPK {
+				value += ";primaryKey"
+			}
+			if col.AutoIncrement {
+				value += ";autoIncrement"
+			}
+		}
+
+		tags = append(tags, fmt.Sprintf("%s:%q", name, value))
+	}
+
+	// Int enums are valid from their zero value on, which required and oneof would reject
+	if sg.config != nil && sg.config.GenerateValidatorTags && !(col.IsEnum && sg.enumMode() == EnumModeInt) {
+		goType, _ := sg.goType(tableInfo, col)
+		if rules := validatorRules(col, goType); rules != "" {
+			tags = append(tags, fmt.Sprintf("validate:%q", rules))
+		}
+	}
+
+	return strings.Join(tags, " ")
+}
+
+// validatorRules builds go-playground/validator rules for a NOT NULL column
+// mapped to goType. Nullable columns map to sql.Null* structs that validator
+// cannot check, so they get no rules.
+func validatorRules(col ColumnInfo, goType string) string {
+	if col.Nullable {
+		return ""
+	}
+
+	var rules []string
+	if isRequired(col) && requiredApplies(col, goType) {
+		rules = append(rules, "required")
+	}
+
+	if col.IsEnum {
+		if values, ok := oneofValues(col.EnumValues); ok {
+			rules = append(rules, "oneof="+values)
+		}
+	}
+
+	if length, ok := stringLength(col); ok {
+		rules = append(rules, fmt.Sprintf("max=%d", length))
+	}
+
+	return strings.Join(rules, ",")
+}
+
+// requiredApplies reports whether validator's required rule fits a Go type.
+// It rejects zero values, which mean "not set" for strings, enums, pointers
+// and nullable types, but are valid for numbers and booleans.
+func requiredApplies(col ColumnInfo, goType string) bool {
+	switch {
+	case goType == "string", col.IsEnum:
+		return true
+	case strings.HasPrefix(goType, "*"):
+		return true
+	case strings.HasPrefix(goType, "sql.Null"), strings.HasPrefix(goType, "types.Null"):
+		return true
+	default:
+		return false
+	}
+}
+
+// oneofValues formats enum values as the parameter of validator's oneof rule.
+// Values are separated by spaces, so values containing whitespace or empty
+// values are single-quoted. Commas and pipes would end the rule and are
+// written as the 0x2C and 0x7C escapes validator replaces. validator strips
+// every single quote and the tag cannot hold a backquote, so values
+// containing either cannot be matched and the rule is left out.
+func oneofValues(enumValues []string) (string, bool) {
+	values := make([]string, len(enumValues))
+	for i, value := range enumValues {
+		if strings.ContainsAny(value, "'`") || strings.Contains(value, "0x2C") || strings.Contains(value, "0x7C") {
+			return "", false
+		}
+		value = strings.NewReplacer(",", "0x2C", "|", "0x7C").Replace(value)
+		if value == "" || strings.IndexFunc(value, unicode.IsSpace) >= 0 {
+			value = "'" + value + "'"
+		}
+		values[i] = value
+	}
+	return strings.Join(values, " "), true
+}
+
+// stringLength returns the maximum length of a char or varchar column, from
+// MaxLength when it was captured and from the declared column type otherwise
+func stringLength(col ColumnInfo) (int64, bool) {
+	lower := strings.ToLower(col.Type)
+	for _, prefix := range []string{"char(", "varchar("} {
+		if !strings.HasPrefix(lower, prefix) || !strings.HasSuffix(lower, ")") {
+			continue
+		}
+		if col.MaxLength.Valid {
+			return col.MaxLength.Int64, true
+		}
+		length, err := strconv.ParseInt(lower[len(prefix):len(lower)-1], 10, 64)
+		return length, err == nil
+	}
+	return 0, false
+}
@@ -0,0 +1,75 @@
+package schema
+
+import "testing"
+
+func TestStructTag(t *testing.T) {
+	table := &TableInfo{
+		Name: "users",
+		Columns: []ColumnInfo{
+			{Name: "id", Type: "int(11)", AutoIncrement: true},
+			{Name: "name", Type: "varchar(255)"},
+		},
+		PrimaryKeys: []string{"id"},
+	}
+
+	tests := []struct {
+		config   *Config
+		column   int
+		expected string
+	}{
+		{nil, 0, `db:"id"`},
+		{&Config{ORMPreset: ORMSqlx}, 0, `db:"id"`},
+		{&Config{ORMPreset: ORMSqlx}, 1, `db:"name"`},
+		{&Config{ORMPreset: ORMBun}, 0, `bun:"id,pk,autoincrement"`},
+		{&Config{ORMPreset: ORMBun}, 1, `bun:"name"`},
+		{&Config{ORMPreset: ORMGorm}, 0, `gorm:"column:id;primaryKey;autoIncrement"`},
+		{&Config{ORMPreset: ORMGorm}, 1, `gorm:"column:name"`},
+		{&Config{ORMPreset: ORMBun, StructTags: []string{"bun", "json"}}, 0, `bun:"id,pk,autoincrement" json:"id"`},
+		{&Config{ORMPreset: ORMBun, StructTags: []string{"db"}}, 0, `db:"id"`},
+	}
+
+	for _, test := range tests {
+		sg := &SchemaGenerator{config: test.config}
+		col := table.Columns[test.column]
+		if result := sg.structTag(table, col); result != test.expected {
+			t.Errorf("structTag(%s) with %+v = %q, expected %q", col.Name, test.config, result, test.expected)
+		}
+	}
+}
+
+func TestStructTag_ValidatorTags(t *testing.T) {
+	table := &TableInfo{
+		Name: "users",
+		Columns: []ColumnInfo{
+			{Name: "id", Type: "int(11)", AutoIncrement: true},
+			{Name: "status", Type: "enum('active','inactive','on hold')", IsEnum: true, EnumValues: []string{"active", "inactive", "on hold"}},
+			{Name: "name", Type: "varchar(255)"},
+			{Name: "nickname", Type: "varchar(50)", Nullable: true},
+			{Name: "age", Type: "int(11)"},
+			{Name: "active", Type: "tinyint(1)"},
+			{Name: "size", Type: "enum('s','m,l','a|b','')", IsEnum: true, EnumValues: []string{"s", "m,l", "a|b", ""}},
+			{Name: "quote", Type: "enum('it''s','no')", IsEnum: true, EnumValues: []string{"it's", "no"}},
+		},
+		PrimaryKeys: []string{"id"},
+	}
+	sg := &SchemaGenerator{config: &Config{GenerateValidatorTags: true}}
+
+	expected := []string{
+		`db:"id"`,
+		`db:"status" validate:"required,oneof=active inactive 'on hold'"`,
+		`db:"name" validate:"required,max=255"`,
+		`db:"nickname"`,
+		// 0 and false are valid values, so numbers and booleans are not required
+		`db:"age"`,
+		`db:"active"`,
+		// Commas and pipes would end the rule, so they use validator's escapes
+		`db:"size" validate:"required,oneof=s m0x2Cl a0x7Cb ''"`,
+		// validator strips single quotes from oneof values, so it cannot match it's
+		`db:"quote" validate:"required"`,
+	}
+	for i, col := range table.Columns {
+		if result := sg.structTag(table, col); result != expected[i] {
+			t.Errorf("structTag(%s) = %q, expected %q", col.Name, result, expected[i])
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"sort"
+	"strings"
+)
+
+// TypeMapper maps a column to the Go type used in generated code and the
+// import paths that type needs
+type TypeMapper interface {
+	GoType(col ColumnInfo, cfg *Config) (goType string, imports []string)
+}
+
+// DefaultTypeMapper is the built-in MariaDB to Go type mapping
+type DefaultTypeMapper struct{}
+
+// wellKnownImports maps package qualifiers used by the default mapping to their import paths
+var wellKnownImports = map[string]string{
+	"sql":   "database/sql",
+	"time":  "time",
+	"types": "github.com/louis77/mariakit/types",
+}
+
+// MapColumnType returns the Go type the built-in mapping uses for a column,
+// without the imports DefaultTypeMapper reports. col.Table selects
+// table-scoped settings such as JSON mappings and typed enum names.
+func MapColumnType(col ColumnInfo, cfg *Config) string {
+	return mapMySQLType(cfg, col.Type, col.Nullable, col.IsJSON, col.Table, col.Name)
+}
+
+// GoType implements TypeMapper
+func (DefaultTypeMapper) GoType(col ColumnInfo, cfg *Config) (string, []string) {
+	goType := MapColumnType(col, cfg)
+
+	var imports []string
+	if col.IsJSON && cfg != nil {
+		if mapping, exists := cfg.GetJSONMapping(col.Table, col.Name); exists && mapping.Import != "" {
+			imports = append(imports, mapping.Import)
+		}
+	}
+	for _, qualifier := range typeQualifiers(goType) {
+		if importPath, exists := wellKnownImports[qualifier]; exists {
+			imports = append(imports, importPath)
+		}
+	}
+
+	return goType, imports
+}
+
+// SetTypeMapper replaces the type mapping used for struct fields and column
+// type aliases. A nil mapper restores DefaultTypeMapper.
+func (sg *SchemaGenerator) SetTypeMapper(mapper TypeMapper) {
+	sg.typeMapper = mapper
+}
+
+// goType maps a column of a table through the configured TypeMapper
+func (sg *SchemaGenerator) goType(tableInfo *TableInfo, col ColumnInfo) (string, []string) {
+	col.Table = tableInfo.Name
+
+	var mapper TypeMapper = DefaultTypeMapper{}
+	if sg.typeMapper != nil {
+		mapper = sg.typeMapper
+	}
+	goType, imports := mapper.GoType(col, sg.config)
+	if qualified, importPath, ok := sg.qualifyEnumType(tableInfo.Name, col, goType); ok {
+		return qualified, append(imports, importPath)
+	}
+	return goType, imports
+}
+
+// ColumnTypeMapping is a column with the Go type generated code uses for it
+type ColumnTypeMapping struct {
+	Table     string
+	Column    string
+	MySQLType string
+	GoType    string
+}
+
+// TypeMap maps every column of the selected tables through the configured
+// TypeMapper without generating code, in table and column order, so type
+// mapping settings can be reviewed before a run
+func (sg *SchemaGenerator) TypeMap(ctx context.Context) ([]ColumnTypeMapping, error) {
+	tableInfos, err := sg.loadTables(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var mappings []ColumnTypeMapping
+	for _, tableInfo := range tableInfos {
+		for _, col := range tableInfo.Columns {
+			goType, _ := sg.goType(tableInfo, col)
+			mappings = append(mappings, ColumnTypeMapping{
+				Table:     tableInfo.Name,
+				Column:    col.Name,
+				MySQLType: col.Type,
+				GoType:    goType,
+			})
+		}
+	}
+	return mappings, nil
+}
+
+// typeQualifiers returns the package qualifiers referenced by a Go type expression
+func typeQualifiers(goType string) []string {
+	expr, err := parser.ParseExpr(goType)
+	if err != nil {
+		return nil
+	}
+
+	var qualifiers []string
+	ast.Inspect(expr, func(node ast.Node) bool {
+		if selector, ok := node.(*ast.SelectorExpr); ok {
+			if ident, ok := selector.X.(*ast.Ident); ok {
+				qualifiers = append(qualifiers, ident.Name)
+			}
+		}
+		return true
+	})
+	return qualifiers
+}
+
+// writeImports writes an import block with standard library imports first,
+// followed by all other imports. Nothing is written without imports.
+func writeImports(builder *strings.Builder, imports map[string]bool) {
+	var stdImports, otherImports []string
+	for importPath := range imports {
+		if strings.Contains(strings.Split(importPath, "/")[0], ".") {
+			otherImports = append(otherImports, importPath)
+		} else {
+			stdImports = append(stdImports, importPath)
+		}
+	}
+	if len(stdImports)+len(otherImports) == 0 {
+		return
+	}
+	sort.Strings(stdImports)
+	sort.Strings(otherImports)
+
+	builder.WriteString("import (\n")
+	for _, imp := range stdImports {
+		builder.WriteString(fmt.Sprintf("\t%q\n", imp))
+	}
+	if len(stdImports) > 0 && len(otherImports) > 0 {
+		builder.WriteString("\n")
+	}
+	for _, imp := range otherImports {
+		builder.WriteString(fmt.Sprintf("\t%q\n", imp))
+	}
+	builder.WriteString(")\n\n")
+}
@@ -0,0 +1,151 @@
+package schema
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// uuidMapper maps binary(16) columns to uuid.UUID and defers everything else to the default mapping
+type uuidMapper struct{}
+
+func (uuidMapper) GoType(col ColumnInfo, cfg *Config) (string, []string) {
+	if col.Type == "binary(16)" {
+		return "uuid.UUID", []string{"github.com/google/uuid"}
+	}
+	return DefaultTypeMapper{}.GoType(col, cfg)
+}
+
+func TestDefaultTypeMapper(t *testing.T) {
+	config := &Config{JSONMappings: map[string]JSONMapping{
+		"users.profile": {Type: "types.JSON[models.Profile]", Import: "example.com/models"},
+	}}
+
+	tests := []struct {
+		col     ColumnInfo
+		goType  string
+		imports string
+	}{
+		{ColumnInfo{Table: "users", Name: "id", Type: "int(11)"}, "int32", ""},
+		{ColumnInfo{Table: "users", Name: "name", Type: "varchar(255)", Nullable: true}, "sql.NullString", "database/sql"},
+		{ColumnInfo{Table: "users", Name: "created_at", Type: "datetime"}, "time.Time", "time"},
+		{ColumnInfo{Table: "users", Name: "profile", Type: "longtext", IsJSON: true}, "types.JSON[models.Profile]", "example.com/models,github.com/louis77/mariakit/types"},
+	}
+
+	for _, test := range tests {
+		goType, imports := DefaultTypeMapper{}.GoType(test.col, config)
+		if goType != test.goType || strings.Join(imports, ",") != test.imports {
+			t.Errorf("GoType(%s) = %q, %v, expected %q, %q", test.col.Name, goType, imports, test.goType, test.imports)
+		}
+	}
+}
+
+func TestMysqlTypeToGoType_UUID(t *testing.T) {
+	tests := []struct {
+		mode     string
+		nullable bool
+		expected string
+	}{
+		{"", false, "types.UUID"},
+		{"", true, "types.UUID"},
+		{UUIDModeUUID, false, "types.UUID"},
+		{UUIDModeString, false, "string"},
+		{UUIDModeString, true, "sql.NullString"},
+	}
+
+	for _, test := range tests {
+		sg := &SchemaGenerator{config: &Config{UUIDMode: test.mode}}
+		result := sg.mysqlTypeToGoType("uuid", test.nullable, false, "users", "id")
+		if result != test.expected {
+			t.Errorf("mysqlTypeToGoType(uuid, mode=%q, nullable=%t) = %q, expected %q",
+				test.mode, test.nullable, result, test.expected)
+		}
+	}
+
+	goType, imports := DefaultTypeMapper{}.GoType(ColumnInfo{Table: "users", Name: "id", Type: "uuid"}, nil)
+	if goType != "types.UUID" || len(imports) != 1 || imports[0] != "github.com/louis77/mariakit/types" {
+		t.Errorf("GoType(uuid) = %q, %v, expected types.UUID with the types import", goType, imports)
+	}
+}
+
+func TestGenerateStructs_CustomTypeMapper(t *testing.T) {
+	sg := NewSchemaGeneratorFromSource(newMemorySource(&TableInfo{
+		Name: "users",
+		Columns: []ColumnInfo{
+			{Name: "id", Type: "binary(16)"},
+			{Name: "name", Type: "varchar(255)"},
+		},
+	}), nil)
+	sg.SetTypeMapper(uuidMapper{})
+
+	result, err := sg.GenerateStructs(context.Background(), "models")
+	if err != nil {
+		t.Fatalf("GenerateStructs() error: %v", err)
+	}
+
+	expected := []string{
+		"import (\n\t\"database/sql\"\n\n\t\"github.com/google/uuid\"\n)",
+		"Id uuid.UUID `db:\"id\"`",
+		"Name string `db:\"name\"`",
+	}
+	for _, exp := range expected {
+		if !strings.Contains(result, exp) {
+			t.Errorf("GenerateStructs() missing %q in:\n%s", exp, result)
+		}
+	}
+
+	// Imports of unused packages are no longer emitted; database/sql is used
+	// by the generated ScanUsers
+	for _, unexpected := range []string{`"time"`, `"github.com/louis77/mariakit/types"`} {
+		if strings.Contains(result, unexpected) {
+			t.Errorf("GenerateStructs() imports unused package %s:\n%s", unexpected, result)
+		}
+	}
+}
+
+func TestMapColumnType(t *testing.T) {
+	typed := &Config{EnumMode: EnumModeTyped}
+
+	tests := []struct {
+		col      ColumnInfo
+		config   *Config
+		expected string
+	}{
+		{ColumnInfo{Table: "users", Name: "nickname", Type: "varchar(50)", Nullable: true}, nil, "sql.NullString"},
+		{ColumnInfo{Table: "users", Name: "age", Type: "int(11)", Nullable: true}, nil, "sql.NullInt32"},
+		{ColumnInfo{Table: "users", Name: "status", Type: "enum('active','inactive')", IsEnum: true}, nil, "string"},
+		{ColumnInfo{Table: "users", Name: "status", Type: "enum('active','inactive')", IsEnum: true}, typed, "UsersStatus"},
+		{ColumnInfo{Table: "users", Name: "status", Type: "enum('active','inactive')", IsEnum: true, Nullable: true}, typed, "NullUsersStatus"},
+	}
+
+	for _, test := range tests {
+		if result := MapColumnType(test.col, test.config); result != test.expected {
+			t.Errorf("MapColumnType(%s %s) = %q, expected %q", test.col.Name, test.col.Type, result, test.expected)
+		}
+	}
+}
+
+func TestTypeMap(t *testing.T) {
+	table := &TableInfo{
+		Name: "users",
+		Columns: []ColumnInfo{
+			{Name: "id", Type: "int(11)"},
+			{Name: "settings", Type: "longtext", Nullable: true, IsJSON: true},
+		},
+	}
+	config := &Config{JSONMappings: map[string]JSONMapping{"users.settings": {Type: "types.JSON[Settings]"}}}
+
+	mappings, err := NewSchemaGeneratorFromSource(newMemorySource(table), config).TypeMap(context.Background())
+	if err != nil {
+		t.Fatalf("TypeMap() error: %v", err)
+	}
+
+	expected := []ColumnTypeMapping{
+		{Table: "users", Column: "id", MySQLType: "int(11)", GoType: "int32"},
+		{Table: "users", Column: "settings", MySQLType: "longtext", GoType: "types.JSON[Settings]"},
+	}
+	if !reflect.DeepEqual(mappings, expected) {
+		t.Errorf("TypeMap() = %+v, expected %+v", mappings, expected)
+	}
+}
@@ -0,0 +1,132 @@
+package schema
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ServerVersion is the parsed version of a MariaDB or MySQL server
+type ServerVersion struct {
+	Major   int
+	Minor   int
+	Patch   int
+	MariaDB bool
+}
+
+// ParseServerVersion parses the result of SELECT VERSION(), such as
+// "10.11.6-MariaDB-1:10.11.6+maria~ubu2204" or "8.0.36-0ubuntu0.22.04.1".
+// The "5.5.5-" prefix MariaDB reports to old replication clients is skipped.
+func ParseServerVersion(version string) (ServerVersion, error) {
+	v := ServerVersion{MariaDB: strings.Contains(strings.ToLower(version), "mariadb")}
+
+	number, _, _ := strings.Cut(version, "-")
+	if v.MariaDB && number == "5.5.5" {
+		number, _, _ = strings.Cut(strings.TrimPrefix(version, "5.5.5-"), "-")
+	}
+
+	parts := strings.Split(number, ".")
+	if len(parts) < 2 || len(parts) > 3 {
+		return ServerVersion{}, fmt.Errorf("invalid server version %q", version)
+	}
+
+	fields := []*int{&v.Major, &v.Minor, &v.Patch}
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return ServerVersion{}, fmt.Errorf("invalid server version %q", version)
+		}
+		*fields[i] = n
+	}
+
+	return v, nil
+}
+
+// String formats the version as major.minor.patch with a -MariaDB suffix for MariaDB servers
+func (v ServerVersion) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.MariaDB {
+		s += "-MariaDB"
+	}
+	return s
+}
+
+// IsZero reports whether the version is unknown, as for generators reading from a Source
+func (v ServerVersion) IsZero() bool {
+	return v == ServerVersion{}
+}
+
+// AtLeast reports whether the version is major.minor.patch or later
+func (v ServerVersion) AtLeast(major, minor, patch int) bool {
+	if v.Major != major {
+		return v.Major > major
+	}
+	if v.Minor != minor {
+		return v.Minor > minor
+	}
+	return v.Patch >= patch
+}
+
+// SupportsCheckConstraints reports whether the server has
+// information_schema.CHECK_CONSTRAINTS, which JSON detection queries.
+// An unknown version is assumed to support it.
+func (v ServerVersion) SupportsCheckConstraints() bool {
+	switch {
+	case v.IsZero():
+		return true
+	case v.MariaDB:
+		return v.AtLeast(10, 2, 22)
+	default:
+		return v.AtLeast(8, 0, 16)
+	}
+}
+
+// SupportsSystemVersioning reports whether the server can create tables WITH
+// SYSTEM VERSIONING, which only MariaDB 10.3.4 and later can. An unknown
+// version is assumed to support it.
+func (v ServerVersion) SupportsSystemVersioning() bool {
+	return v.IsZero() || (v.MariaDB && v.AtLeast(10, 3, 4))
+}
+
+// ServerVersion returns the version of the connected server. It is queried
+// with SELECT VERSION() when connecting, or on first use for pools passed to
+// NewSchemaGeneratorFromDB. Generators reading from a Source, and generators
+// whose server did not report a parseable version, return the zero
+// ServerVersion.
+func (sg *SchemaGenerator) ServerVersion(ctx context.Context) (ServerVersion, error) {
+	if sg.source != nil {
+		return ServerVersion{}, nil
+	}
+	if sg.version != nil {
+		return *sg.version, nil
+	}
+
+	var raw string
+	if err := sg.db.QueryRowContext(ctx, "SELECT VERSION()").Scan(&raw); err != nil {
+		return ServerVersion{}, fmt.Errorf("failed to query server version: %w", err)
+	}
+
+	version, err := ParseServerVersion(raw)
+	if err != nil {
+		return ServerVersion{}, err
+	}
+	sg.version = &version
+	return version, nil
+}
+
+// detectServerVersion returns the server version like ServerVersion, but
+// falls back to the zero ServerVersion when it cannot be queried or parsed,
+// such as behind proxies reporting their own version. The zero version
+// assumes every feature is supported, so generation still works; the failure
+// is recorded as a warning and the fallback is kept for later calls.
+func (sg *SchemaGenerator) detectServerVersion(ctx context.Context) ServerVersion {
+	version, err := sg.ServerVersion(ctx)
+	if err != nil {
+		sg.warn("cannot determine server version, assuming a current server: %v", err)
+		if ctx.Err() == nil {
+			sg.version = &ServerVersion{}
+		}
+	}
+	return version
+}
@@ -0,0 +1,139 @@
+package schema
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+	"testing"
+)
+
+func TestParseServerVersion(t *testing.T) {
+	tests := []struct {
+		version  string
+		expected ServerVersion
+	}{
+		{"10.11.6-MariaDB", ServerVersion{10, 11, 6, true}},
+		{"11.4.2-MariaDB-ubu2404", ServerVersion{11, 4, 2, true}},
+		{"10.6.16-MariaDB-1:10.6.16+maria~ubu2004-log", ServerVersion{10, 6, 16, true}},
+		{"5.5.5-10.3.39-MariaDB", ServerVersion{10, 3, 39, true}},
+		{"8.0.36", ServerVersion{8, 0, 36, false}},
+		{"8.0.36-0ubuntu0.22.04.1", ServerVersion{8, 0, 36, false}},
+		{"5.7.44-log", ServerVersion{5, 7, 44, false}},
+		{"9.1", ServerVersion{9, 1, 0, false}},
+	}
+
+	for _, test := range tests {
+		result, err := ParseServerVersion(test.version)
+		if err != nil {
+			t.Errorf("ParseServerVersion(%q) error: %v", test.version, err)
+			continue
+		}
+		if result != test.expected {
+			t.Errorf("ParseServerVersion(%q) = %+v, expected %+v", test.version, result, test.expected)
+		}
+	}
+
+	for _, invalid := range []string{"", "MariaDB", "10", "10.x.1", "1.2.3.4"} {
+		if _, err := ParseServerVersion(invalid); err == nil {
+			t.Errorf("ParseServerVersion(%q) expected error, got nil", invalid)
+		}
+	}
+}
+
+func TestServerVersion_Supports(t *testing.T) {
+	tests := []struct {
+		version          ServerVersion
+		checkConstraints bool
+		systemVersioning bool
+	}{
+		{ServerVersion{}, true, true},
+		{ServerVersion{10, 1, 48, true}, false, false},
+		{ServerVersion{10, 2, 22, true}, true, false},
+		{ServerVersion{10, 3, 4, true}, true, true},
+		{ServerVersion{5, 7, 44, false}, false, false},
+		{ServerVersion{8, 0, 16, false}, true, false},
+	}
+
+	for _, test := range tests {
+		if result := test.version.SupportsCheckConstraints(); result != test.checkConstraints {
+			t.Errorf("%s SupportsCheckConstraints() = %t, expected %t", test.version, result, test.checkConstraints)
+		}
+		if result := test.version.SupportsSystemVersioning(); result != test.systemVersioning {
+			t.Errorf("%s SupportsSystemVersioning() = %t, expected %t", test.version, result, test.systemVersioning)
+		}
+	}
+}
+
+func TestGetTableInfo_OldServerSkipsJSONConstraint(t *testing.T) {
+	db := newFakeDB(map[string]fakeResult{
+		"SELECT VERSION()": {columns: []string{"VERSION()"}, rows: [][]driver.Value{{"10.1.48-MariaDB"}}},
+		"information_schema.COLUMNS": {
+			columns: []string{"COLUMN_NAME", "COLUMN_TYPE", "IS_NULLABLE", "COLUMN_DEFAULT", "COLUMN_COMMENT", "IS_GENERATED", "GENERATION_EXPRESSION", "EXTRA", "CHARACTER_MAXIMUM_LENGTH"},
+			rows: [][]driver.Value{
+				{"payload", "text", "YES", nil, "", "NO", nil, "", int64(65535)},
+			},
+		},
+		"CHECK_CLAUSE": {columns: []string{"CHECK_CLAUSE"}, rows: [][]driver.Value{{"json_valid(`payload`)"}}},
+		"TABLE_TYPE":   {columns: []string{"TABLE_TYPE"}, rows: [][]driver.Value{{"SYSTEM VERSIONED"}}},
+	})
+	defer db.Close()
+	sg := NewSchemaGeneratorFromDB(db, nil)
+
+	tableInfo, err := sg.GetTableInfo(context.Background(), "events")
+	if err != nil {
+		t.Fatalf("GetTableInfo() error: %v", err)
+	}
+	if tableInfo.Columns[0].IsJSON || tableInfo.IsVersioned {
+		t.Errorf("GetTableInfo() on MariaDB 10.1 = %+v, expected no JSON or system-versioning detection", tableInfo)
+	}
+
+	version, err := sg.ServerVersion(context.Background())
+	if err != nil || version != (ServerVersion{10, 1, 48, true}) {
+		t.Errorf("ServerVersion() = %+v, %v, expected 10.1.48-MariaDB", version, err)
+	}
+}
+
+func TestGetTableInfo_UnknownServerVersionFallsBack(t *testing.T) {
+	columns := fakeResult{
+		columns: []string{"COLUMN_NAME", "COLUMN_TYPE", "IS_NULLABLE", "COLUMN_DEFAULT", "COLUMN_COMMENT", "IS_GENERATED", "GENERATION_EXPRESSION", "EXTRA", "CHARACTER_MAXIMUM_LENGTH"},
+		rows: [][]driver.Value{
+			{"id", "int(11)", "NO", nil, "", "NO", nil, "", nil},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		results map[string]fakeResult
+	}{
+		{"unparseable", map[string]fakeResult{
+			"SELECT VERSION()":           {columns: []string{"VERSION()"}, rows: [][]driver.Value{{"proxy"}}},
+			"information_schema.COLUMNS": columns,
+		}},
+		// The fake database returns no rows for SELECT VERSION(), so Scan fails
+		{"query error", map[string]fakeResult{
+			"information_schema.COLUMNS": columns,
+		}},
+	}
+
+	for _, test := range tests {
+		db := newFakeDB(test.results)
+		sg := NewSchemaGeneratorFromDB(db, nil)
+
+		tableInfo, err := sg.GetTableInfo(context.Background(), "users")
+		if err != nil {
+			t.Fatalf("%s: GetTableInfo() error: %v", test.name, err)
+		}
+		if len(tableInfo.Columns) != 1 {
+			t.Errorf("%s: GetTableInfo() columns = %+v, expected id", test.name, tableInfo.Columns)
+		}
+
+		version, err := sg.ServerVersion(context.Background())
+		if err != nil || !version.IsZero() {
+			t.Errorf("%s: ServerVersion() = %+v, %v, expected the zero version", test.name, version, err)
+		}
+		if warnings := sg.Warnings(); len(warnings) != 1 || !strings.Contains(warnings[0], "cannot determine server version") {
+			t.Errorf("%s: Warnings() = %q, expected one server version warning", test.name, warnings)
+		}
+		db.Close()
+	}
+}
@@ -0,0 +1,54 @@
+package types
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// Bits holds the value of a BIT(n) column with n > 1. MariaDB returns bit
+// fields as big-endian bytes, which Scan reads into Uint64; Valid is false for NULL.
+type Bits struct {
+	Uint64 uint64
+	Valid  bool
+}
+
+// Value implements the driver.Valuer interface. The go-sql-driver/mysql
+// driver accepts uint64 parameters, so all 64 bits can be written.
+func (b Bits) Value() (driver.Value, error) {
+	if !b.Valid {
+		return nil, nil
+	}
+	return b.Uint64, nil
+}
+
+// Scan implements the sql.Scanner interface
+func (b *Bits) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		*b = Bits{}
+		return nil
+	case []byte:
+		if len(v) > 8 {
+			return fmt.Errorf("bit field of %d bytes does not fit into 64 bits", len(v))
+		}
+		var n uint64
+		for _, c := range v {
+			n = n<<8 | uint64(c)
+		}
+		b.Uint64 = n
+	case int64:
+		b.Uint64 = uint64(v)
+	case uint64:
+		b.Uint64 = v
+	default:
+		return fmt.Errorf("unsupported type for Bits: %T", value)
+	}
+
+	b.Valid = true
+	return nil
+}
+
+// Bit reports whether bit i, counted from the least significant bit, is set
+func (b Bits) Bit(i uint) bool {
+	return i < 64 && b.Uint64&(1<<i) != 0
+}
@@ -0,0 +1,49 @@
+package types
+
+import "testing"
+
+func TestBits_Scan(t *testing.T) {
+	tests := []struct {
+		value    any
+		expected uint64
+	}{
+		{[]byte{0x01}, 1},
+		{[]byte{0xa5}, 0xa5},
+		{[]byte{0x01, 0x00}, 256},
+		{[]byte{0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, 1<<64 - 1},
+		{int64(42), 42},
+	}
+
+	for _, test := range tests {
+		var b Bits
+		if err := b.Scan(test.value); err != nil {
+			t.Fatalf("Scan(%v) error: %v", test.value, err)
+		}
+		if !b.Valid || b.Uint64 != test.expected {
+			t.Errorf("Scan(%v) = %+v, expected %d", test.value, b, test.expected)
+		}
+	}
+
+	var b Bits
+	if err := b.Scan(make([]byte, 9)); err == nil {
+		t.Error("Scan() of 9 bytes expected error, got nil")
+	}
+	if err := b.Scan(nil); err != nil || b.Valid {
+		t.Errorf("Scan(nil) = %+v, %v, expected an invalid Bits", b, err)
+	}
+	if value, _ := b.Value(); value != nil {
+		t.Errorf("Value() of NULL Bits = %v, expected nil", value)
+	}
+}
+
+func TestBits_Bit(t *testing.T) {
+	b := Bits{Uint64: 0b101, Valid: true}
+	for i, expected := range []bool{true, false, true, false} {
+		if b.Bit(uint(i)) != expected {
+			t.Errorf("Bit(%d) = %t, expected %t", i, !expected, expected)
+		}
+	}
+	if b.Bit(64) {
+		t.Error("Bit(64) = true, expected false")
+	}
+}
@@ -0,0 +1,56 @@
+package types
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"fmt"
+	"io"
+)
+
+// Blob holds the content of a BLOB column and hands it out as an io.Reader,
+// so code consuming it can stream regardless of how it was loaded. Valid is
+// false for NULL.
+type Blob struct {
+	Bytes []byte
+	Valid bool
+}
+
+// Value implements the driver.Valuer interface
+func (b Blob) Value() (driver.Value, error) {
+	if !b.Valid {
+		return nil, nil
+	}
+	return b.Bytes, nil
+}
+
+// Scan implements the sql.Scanner interface. The bytes are copied because
+// the driver may reuse its buffer for the next row.
+func (b *Blob) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		*b = Blob{}
+		return nil
+	case []byte:
+		b.Bytes = bytes.Clone(v)
+	case string:
+		b.Bytes = []byte(v)
+	default:
+		return fmt.Errorf("unsupported type for Blob: %T", value)
+	}
+
+	if b.Bytes == nil {
+		b.Bytes = []byte{}
+	}
+	b.Valid = true
+	return nil
+}
+
+// Reader returns a reader over the blob's content. A NULL blob reads as empty.
+func (b Blob) Reader() io.Reader {
+	return bytes.NewReader(b.Bytes)
+}
+
+// Len returns the size of the blob in bytes
+func (b Blob) Len() int {
+	return len(b.Bytes)
+}
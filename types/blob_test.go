@@ -0,0 +1,51 @@
+package types
+
+import (
+	"io"
+	"testing"
+)
+
+func TestBlob_ScanAndReader(t *testing.T) {
+	raw := []byte("binary\x00content")
+
+	var b Blob
+	if err := b.Scan(raw); err != nil {
+		t.Fatalf("Scan() error: %v", err)
+	}
+	raw[0] = 'X' // the driver may reuse its buffer
+
+	if !b.Valid || b.Len() != 14 {
+		t.Fatalf("Scan() = %+v, expected 14 valid bytes", b)
+	}
+
+	content, err := io.ReadAll(b.Reader())
+	if err != nil {
+		t.Fatalf("ReadAll(Reader()) error: %v", err)
+	}
+	if string(content) != "binary\x00content" {
+		t.Errorf("Reader() content = %q, expected %q", content, "binary\x00content")
+	}
+
+	if err := b.Scan([]byte{}); err != nil || !b.Valid || b.Bytes == nil {
+		t.Errorf("Scan(empty) = %+v, %v, expected a valid empty blob", b, err)
+	}
+}
+
+func TestBlob_ScanNull(t *testing.T) {
+	b := Blob{Bytes: []byte("previous row"), Valid: true}
+	if err := b.Scan(nil); err != nil {
+		t.Fatalf("Scan(nil) error: %v", err)
+	}
+	if b.Valid || b.Bytes != nil {
+		t.Errorf("Scan(nil) = %+v, expected an invalid empty blob", b)
+	}
+	if value, _ := b.Value(); value != nil {
+		t.Errorf("Value() of NULL Blob = %v, expected nil", value)
+	}
+	if content, _ := io.ReadAll(b.Reader()); len(content) != 0 {
+		t.Errorf("Reader() of NULL Blob read %q, expected nothing", content)
+	}
+	if err := b.Scan(42); err == nil {
+		t.Error("Scan(int) expected error, got nil")
+	}
+}
@@ -0,0 +1,76 @@
+package types
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// dateTimeLayouts are the text forms MariaDB returns for DATE, DATETIME and
+// TIMESTAMP columns; the fraction is optional
+var dateTimeLayouts = []string{
+	"2006-01-02 15:04:05.999999",
+	"2006-01-02",
+}
+
+// DateTime holds a DATE, DATETIME or TIMESTAMP value. Unlike time.Time it
+// scans both the time.Time the driver returns with parseTime=true and the
+// YYYY-MM-DD HH:MM:SS text returned without it, which is parsed as UTC.
+// Valid is false for NULL.
+type DateTime struct {
+	Time  time.Time
+	Valid bool
+}
+
+// Value implements the driver.Valuer interface
+func (d DateTime) Value() (driver.Value, error) {
+	if !d.Valid {
+		return nil, nil
+	}
+	return d.Time, nil
+}
+
+// Scan implements the sql.Scanner interface. Zero dates such as
+// 0000-00-00 00:00:00 scan as the zero time.Time.
+func (d *DateTime) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		*d = DateTime{}
+		return nil
+	case time.Time:
+		d.Time = v
+	case []byte:
+		return d.scanText(string(v))
+	case string:
+		return d.scanText(v)
+	default:
+		return fmt.Errorf("unsupported type for DateTime: %T", value)
+	}
+
+	d.Valid = true
+	return nil
+}
+
+func (d *DateTime) scanText(text string) error {
+	if strings.HasPrefix(text, "0000-00-00") {
+		*d = DateTime{Valid: true}
+		return nil
+	}
+
+	for _, layout := range dateTimeLayouts {
+		if t, err := time.ParseInLocation(layout, text, time.UTC); err == nil {
+			*d = DateTime{Time: t, Valid: true}
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid DateTime: %q", text)
+}
+
+// String returns the time in RFC 3339 format, or "NULL" if the value is not valid
+func (d DateTime) String() string {
+	if !d.Valid {
+		return "NULL"
+	}
+	return d.Time.Format(time.RFC3339Nano)
+}
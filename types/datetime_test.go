@@ -0,0 +1,43 @@
+package types
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDateTime_Scan(t *testing.T) {
+	expected := time.Date(2024, 6, 1, 12, 30, 5, 0, time.UTC)
+
+	tests := []struct {
+		value    any
+		expected time.Time
+	}{
+		{expected, expected},
+		{[]byte("2024-06-01 12:30:05"), expected},
+		{"2024-06-01 12:30:05", expected},
+		{[]byte("2024-06-01 12:30:05.250000"), expected.Add(250 * time.Millisecond)},
+		{[]byte("2024-06-01"), time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)},
+		{[]byte("0000-00-00 00:00:00"), time.Time{}},
+	}
+
+	for _, test := range tests {
+		var d DateTime
+		if err := d.Scan(test.value); err != nil {
+			t.Fatalf("Scan(%v) error: %v", test.value, err)
+		}
+		if !d.Valid || !d.Time.Equal(test.expected) {
+			t.Errorf("Scan(%v) = %v, expected %v", test.value, d, test.expected)
+		}
+	}
+
+	var d DateTime
+	if err := d.Scan([]byte("yesterday")); err == nil {
+		t.Error("Scan(yesterday) expected error, got nil")
+	}
+	if err := d.Scan(nil); err != nil || d.Valid {
+		t.Errorf("Scan(nil) = %v, %v, expected an invalid DateTime", d, err)
+	}
+	if value, _ := d.Value(); value != nil {
+		t.Errorf("Value() of NULL DateTime = %v, expected nil", value)
+	}
+}
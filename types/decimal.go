@@ -0,0 +1,81 @@
+package types
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"math/big"
+	"strconv"
+)
+
+// Decimal holds the exact textual value of a DECIMAL or NUMERIC column as
+// returned by MariaDB, so values round-trip without float rounding
+type Decimal struct {
+	Text  string
+	Valid bool
+}
+
+// NewDecimal creates a valid Decimal from its textual representation
+func NewDecimal(text string) (Decimal, error) {
+	if _, ok := new(big.Rat).SetString(text); !ok {
+		return Decimal{}, fmt.Errorf("invalid decimal: %q", text)
+	}
+	return Decimal{Text: text, Valid: true}, nil
+}
+
+// Value implements the driver.Valuer interface
+func (d Decimal) Value() (driver.Value, error) {
+	if !d.Valid {
+		return nil, nil
+	}
+	return d.Text, nil
+}
+
+// Scan implements the sql.Scanner interface
+func (d *Decimal) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		d.Text, d.Valid = "", false
+		return nil
+	case []byte:
+		d.Text = string(v)
+	case string:
+		d.Text = v
+	case int64:
+		d.Text = strconv.FormatInt(v, 10)
+	case float64:
+		d.Text = strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Errorf("unsupported type for Decimal: %T", value)
+	}
+
+	d.Valid = true
+	return nil
+}
+
+// String returns the exact textual value, or "NULL" if the decimal is not valid
+func (d Decimal) String() string {
+	if !d.Valid {
+		return "NULL"
+	}
+	return d.Text
+}
+
+// Float64 converts the decimal to the nearest float64
+func (d Decimal) Float64() (float64, error) {
+	if !d.Valid {
+		return 0, fmt.Errorf("decimal is NULL")
+	}
+	return strconv.ParseFloat(d.Text, 64)
+}
+
+// Rat returns the decimal as an exact rational number for arithmetic
+func (d Decimal) Rat() (*big.Rat, error) {
+	if !d.Valid {
+		return nil, fmt.Errorf("decimal is NULL")
+	}
+	r, ok := new(big.Rat).SetString(d.Text)
+	if !ok {
+		return nil, fmt.Errorf("invalid decimal: %q", d.Text)
+	}
+	return r, nil
+}
@@ -0,0 +1,65 @@
+package types
+
+import (
+	"math/big"
+	"testing"
+)
+
+func TestDecimal_RoundTrip(t *testing.T) {
+	for _, text := range []string{"0.10", "-12345678901234567890.000001", "100"} {
+		var d Decimal
+		if err := d.Scan([]byte(text)); err != nil {
+			t.Fatalf("Scan(%q) error: %v", text, err)
+		}
+
+		value, err := d.Value()
+		if err != nil {
+			t.Fatalf("Value() error: %v", err)
+		}
+
+		if value != text {
+			t.Errorf("Decimal round trip of %q returned %v", text, value)
+		}
+	}
+}
+
+func TestDecimal_Accessors(t *testing.T) {
+	d, err := NewDecimal("0.10")
+	if err != nil {
+		t.Fatalf("NewDecimal error: %v", err)
+	}
+
+	if d.String() != "0.10" {
+		t.Errorf("String() = %q, expected %q", d.String(), "0.10")
+	}
+
+	f, err := d.Float64()
+	if err != nil || f != 0.1 {
+		t.Errorf("Float64() = %v, %v, expected 0.1", f, err)
+	}
+
+	r, err := d.Rat()
+	if err != nil || r.Cmp(big.NewRat(1, 10)) != 0 {
+		t.Errorf("Rat() = %v, %v, expected 1/10", r, err)
+	}
+
+	if _, err := NewDecimal("ten"); err == nil {
+		t.Error("NewDecimal(\"ten\") should fail")
+	}
+}
+
+func TestDecimal_Null(t *testing.T) {
+	d := Decimal{Text: "1.5", Valid: true}
+	if err := d.Scan(nil); err != nil {
+		t.Fatalf("Scan(nil) error: %v", err)
+	}
+
+	if d.Valid {
+		t.Error("Decimal should not be valid after scanning NULL")
+	}
+
+	value, err := d.Value()
+	if err != nil || value != nil {
+		t.Errorf("Value() of NULL decimal = %v, %v, expected nil", value, err)
+	}
+}
@@ -17,6 +17,37 @@ const (
 	//WKBTypeGeometryCollection = 7
 )
 
+// WKB type code flags of geometries with Z or M coordinates. ISO WKB adds
+// 1000 (Z), 2000 (M) or 3000 (ZM) to the type, EWKB sets the high bits.
+const (
+	wkbFlagZ = 0x80000000
+	wkbFlagM = 0x40000000
+)
+
+// checkGeometryType returns an error unless geometryType is the plain 2D
+// expected type. Z and M coordinates are reported as unsupported instead of
+// being silently dropped.
+func checkGeometryType(geometryType, expected uint32, name string) error {
+	var extra string
+	switch {
+	case geometryType&(wkbFlagZ|wkbFlagM) == wkbFlagZ|wkbFlagM, geometryType/1000 == 3:
+		extra = "Z and M"
+	case geometryType&wkbFlagZ != 0, geometryType/1000 == 1:
+		extra = "Z"
+	case geometryType&wkbFlagM != 0, geometryType/1000 == 2:
+		extra = "M"
+	}
+
+	base := (geometryType &^ (wkbFlagZ | wkbFlagM)) % 1000
+	if extra != "" && base == expected {
+		return fmt.Errorf("unsupported 3D geometry: WKB type %d has %s coordinates, %s only holds X and Y", geometryType, extra, name)
+	}
+	if geometryType != expected {
+		return fmt.Errorf("expected geometry type %d (%s), got %d", expected, name, geometryType)
+	}
+	return nil
+}
+
 func decodePoint(byteOrder binary.ByteOrder, data []byte) Point {
 	var p Point
 	p.X = math.Float64frombits(byteOrder.Uint64(data[0:8]))
@@ -106,8 +137,8 @@ func (p *Point) Scan(value interface{}) error {
 
 	// Check geometry type (should be 1 for Point)
 	geometryType := byteOrder.Uint32(data[5:9])
-	if geometryType != WKBTypePoint {
-		return fmt.Errorf("expected geometry type 1 (Point), got %d", geometryType)
+	if err := checkGeometryType(geometryType, WKBTypePoint, "Point"); err != nil {
+		return err
 	}
 
 	// Extract X and Y coordinates (double precision floating point, 8 bytes each)
@@ -153,10 +184,10 @@ func (p *LineString) Scan(value interface{}) error {
 		return fmt.Errorf("invalid byte order indicator: %d", data[0])
 	}
 
-	// Check geometry type (should be 1 for Point)
+	// Check geometry type (should be 2 for LineString)
 	geometryType := byteOrder.Uint32(data[5:9])
-	if geometryType != WKBTypeLineString {
-		return fmt.Errorf("expected geometry type 2 (LineString), got %d", geometryType)
+	if err := checkGeometryType(geometryType, WKBTypeLineString, "LineString"); err != nil {
+		return err
 	}
 
 	numPoints := byteOrder.Uint32(data[9:13])
@@ -0,0 +1,68 @@
+package types
+
+import (
+	"encoding/binary"
+	"math"
+	"strings"
+	"testing"
+)
+
+// wkbPoint builds a little-endian MariaDB geometry value: SRID, byte order,
+// type code and the coordinates
+func wkbPoint(geometryType uint32, coords ...float64) []byte {
+	data := make([]byte, 9+8*len(coords))
+	data[4] = 1
+	binary.LittleEndian.PutUint32(data[5:9], geometryType)
+	for i, c := range coords {
+		binary.LittleEndian.PutUint64(data[9+8*i:], math.Float64bits(c))
+	}
+	return data
+}
+
+func TestPoint_Scan(t *testing.T) {
+	var p Point
+	if err := p.Scan(wkbPoint(WKBTypePoint, 13.4, 52.5)); err != nil {
+		t.Fatalf("Scan() error: %v", err)
+	}
+	if p.X != 13.4 || p.Y != 52.5 {
+		t.Errorf("Scan() = %+v, expected {X:13.4 Y:52.5}", p)
+	}
+
+	value, err := p.Value()
+	if err != nil {
+		t.Fatalf("Value() error: %v", err)
+	}
+	var roundTrip Point
+	if err := roundTrip.Scan(value); err != nil || roundTrip != p {
+		t.Errorf("Scan(Value()) = %+v, %v, expected %+v", roundTrip, err, p)
+	}
+}
+
+func TestPoint_ScanPointZ(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       []byte
+		dimensions string
+	}{
+		{"ISO PointZ", wkbPoint(1001, 13.4, 52.5, 34), "Z coordinates"},
+		{"ISO PointM", wkbPoint(2001, 13.4, 52.5, 7), "M coordinates"},
+		{"ISO PointZM", wkbPoint(3001, 13.4, 52.5, 34, 7), "Z and M coordinates"},
+		{"EWKB PointZ", wkbPoint(wkbFlagZ|WKBTypePoint, 13.4, 52.5, 34), "Z coordinates"},
+	}
+
+	for _, test := range tests {
+		var p Point
+		err := p.Scan(test.data)
+		if err == nil || !strings.Contains(err.Error(), "unsupported 3D geometry") || !strings.Contains(err.Error(), test.dimensions) {
+			t.Errorf("Scan(%s) error = %v, expected an unsupported 3D geometry error with %s", test.name, err, test.dimensions)
+		}
+		if p != (Point{}) {
+			t.Errorf("Scan(%s) = %+v, expected the point to stay unset", test.name, p)
+		}
+	}
+
+	var p Point
+	if err := p.Scan(wkbPoint(1002, 1, 2, 3)); err == nil || strings.Contains(err.Error(), "3D") {
+		t.Errorf("Scan(LineStringZ) error = %v, expected a geometry type mismatch", err)
+	}
+}
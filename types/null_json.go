@@ -0,0 +1,59 @@
+package types
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// NullJSON is a JSON column that tells SQL NULL apart from the JSON literal
+// null, which JSON[T] both leave as Data's zero value. Valid is false for SQL
+// NULL; a column holding null is Valid with Null set and a zero Data.
+type NullJSON[T any] struct {
+	Data  T
+	Null  bool // the column holds the JSON literal null
+	Valid bool // the column is not SQL NULL
+}
+
+// Value implements the driver.Valuer interface. It writes SQL NULL when the
+// value is not Valid and the JSON literal null when Null is set.
+func (n NullJSON[T]) Value() (driver.Value, error) {
+	if !n.Valid {
+		return nil, nil
+	}
+	if n.Null {
+		return []byte("null"), nil
+	}
+	return json.Marshal(n.Data)
+}
+
+// Scan implements the sql.Scanner interface
+func (n *NullJSON[T]) Scan(value any) error {
+	var data []byte
+
+	switch v := value.(type) {
+	case nil:
+		*n = NullJSON[T]{}
+		return nil
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	default:
+		return fmt.Errorf("unsupported type for NullJSON: %T", value)
+	}
+
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		*n = NullJSON[T]{Null: true, Valid: true}
+		return nil
+	}
+
+	var decoded T
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	*n = NullJSON[T]{Data: decoded, Valid: true}
+	return nil
+}
@@ -0,0 +1,61 @@
+package types
+
+import "testing"
+
+func TestNullJSON_ScanNullLiteral(t *testing.T) {
+	n := NullJSON[map[string]int]{Data: map[string]int{"a": 1}, Valid: true}
+	if err := n.Scan([]byte("null")); err != nil {
+		t.Fatalf("Scan(null) error: %v", err)
+	}
+	if !n.Valid || !n.Null || n.Data != nil {
+		t.Errorf("Scan(null) = %+v, expected a valid JSON null with zero Data", n)
+	}
+
+	value, err := n.Value()
+	if err != nil {
+		t.Fatalf("Value() error: %v", err)
+	}
+	if b, ok := value.([]byte); !ok || string(b) != "null" {
+		t.Errorf("Value() = %#v, expected the JSON literal null", value)
+	}
+}
+
+func TestNullJSON_ScanSQLNull(t *testing.T) {
+	n := NullJSON[map[string]int]{Data: map[string]int{"a": 1}, Null: true, Valid: true}
+	if err := n.Scan(nil); err != nil {
+		t.Fatalf("Scan(nil) error: %v", err)
+	}
+	if n.Valid || n.Null || n.Data != nil {
+		t.Errorf("Scan(nil) = %+v, expected an invalid zero value", n)
+	}
+
+	value, err := n.Value()
+	if err != nil {
+		t.Fatalf("Value() error: %v", err)
+	}
+	if value != nil {
+		t.Errorf("Value() = %#v, expected SQL NULL", value)
+	}
+}
+
+func TestNullJSON_ScanObject(t *testing.T) {
+	var n NullJSON[map[string]int]
+	if err := n.Scan(`{"a": 1}`); err != nil {
+		t.Fatalf("Scan() error: %v", err)
+	}
+	if !n.Valid || n.Null || n.Data["a"] != 1 {
+		t.Errorf("Scan() = %+v, expected a valid object", n)
+	}
+
+	value, err := n.Value()
+	if err != nil {
+		t.Fatalf("Value() error: %v", err)
+	}
+	if b := value.([]byte); string(b) != `{"a":1}` {
+		t.Errorf("Value() = %s, expected {\"a\":1}", b)
+	}
+
+	if err := n.Scan(42); err == nil {
+		t.Error("Scan(int) expected error, got nil")
+	}
+}
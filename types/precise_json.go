@@ -0,0 +1,52 @@
+package types
+
+import (
+	"bytes"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+)
+
+// PreciseJSON is a JSON column like JSON[T], but numbers decoded into
+// interface values become json.Number instead of float64. Use it with
+// T = any or map[string]any to keep integers beyond 2^53 and long decimals
+// exact.
+type PreciseJSON[T any] struct {
+	Data  T
+	Valid bool
+}
+
+// Value implements the driver.Valuer interface
+func (p PreciseJSON[T]) Value() (driver.Value, error) {
+	if !p.Valid {
+		return nil, nil
+	}
+	return json.Marshal(p.Data)
+}
+
+// Scan implements the sql.Scanner interface
+func (p *PreciseJSON[T]) Scan(value any) error {
+	var data []byte
+
+	switch v := value.(type) {
+	case nil:
+		var zero T
+		p.Data, p.Valid = zero, false
+		return nil
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	default:
+		return fmt.Errorf("unsupported type for PreciseJSON: %T", value)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&p.Data); err != nil {
+		return err
+	}
+
+	p.Valid = true
+	return nil
+}
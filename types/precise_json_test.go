@@ -0,0 +1,58 @@
+package types
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestPreciseJSON_LargeInteger(t *testing.T) {
+	const raw = `{"id": 1234567890123456789, "price": 0.1000000000000000055}`
+
+	var p PreciseJSON[any]
+	if err := p.Scan([]byte(raw)); err != nil {
+		t.Fatalf("Scan() error: %v", err)
+	}
+
+	object, ok := p.Data.(map[string]any)
+	if !ok {
+		t.Fatalf("Scan() = %T, expected map[string]any", p.Data)
+	}
+	id, ok := object["id"].(json.Number)
+	if !ok || id.String() != "1234567890123456789" {
+		t.Errorf("id = %#v, expected json.Number 1234567890123456789", object["id"])
+	}
+	if n, err := id.Int64(); err != nil || n != 1234567890123456789 {
+		t.Errorf("id.Int64() = %d, %v, expected 1234567890123456789", n, err)
+	}
+
+	// The number is written back unchanged
+	value, err := p.Value()
+	if err != nil {
+		t.Fatalf("Value() error: %v", err)
+	}
+	if expected := `{"id":1234567890123456789,"price":0.1000000000000000055}`; string(value.([]byte)) != expected {
+		t.Errorf("Value() = %s, expected %s", value, expected)
+	}
+
+	// JSON[any] rounds the same integer through float64
+	var lossy JSON[any]
+	if err := lossy.Scan([]byte(raw)); err != nil {
+		t.Fatalf("JSON.Scan() error: %v", err)
+	}
+	if f := lossy.Data.(map[string]any)["id"].(float64); int64(f) == 1234567890123456789 {
+		t.Errorf("JSON[any] kept the integer exact, expected float64 rounding")
+	}
+}
+
+func TestPreciseJSON_ScanNull(t *testing.T) {
+	p := PreciseJSON[map[string]any]{Data: map[string]any{"a": 1}, Valid: true}
+	if err := p.Scan(nil); err != nil {
+		t.Fatalf("Scan(nil) error: %v", err)
+	}
+	if p.Valid || p.Data != nil {
+		t.Errorf("Scan(nil) = %+v, expected an invalid zero value", p)
+	}
+	if err := p.Scan(42); err == nil {
+		t.Error("Scan(int) expected error, got nil")
+	}
+}
@@ -0,0 +1,35 @@
+package types
+
+import "fmt"
+
+// Rows is the subset of *sql.Rows used by Collect
+type Rows interface {
+	Next() bool
+	Err() error
+	Close() error
+}
+
+// Collect iterates rows, scanning each row into a new T with scan, and closes
+// rows when done. scan typically wraps rows.Scan, for example
+//
+//	users, err := types.Collect(rows, func(u *models.Users) error {
+//		return rows.Scan(&u.Id, &u.Name)
+//	})
+func Collect[T any](rows Rows, scan func(*T) error) ([]T, error) {
+	defer rows.Close()
+
+	var result []T
+	for rows.Next() {
+		var item T
+		if err := scan(&item); err != nil {
+			return nil, fmt.Errorf("failed to scan row %d: %w", len(result)+1, err)
+		}
+		result = append(result, item)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return result, rows.Close()
+}
@@ -0,0 +1,79 @@
+package types
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// fakeRows is an in-memory Rows source yielding one string per row
+type fakeRows struct {
+	values  []string
+	current int
+	err     error
+	closed  bool
+}
+
+func (r *fakeRows) Next() bool {
+	if r.closed || r.current >= len(r.values) {
+		return false
+	}
+	r.current++
+	return true
+}
+
+func (r *fakeRows) Err() error {
+	return r.err
+}
+
+func (r *fakeRows) Close() error {
+	r.closed = true
+	return nil
+}
+
+func (r *fakeRows) Scan(dest *string) error {
+	*dest = r.values[r.current-1]
+	if *dest == "bad" {
+		return errors.New("cannot scan bad")
+	}
+	return nil
+}
+
+type collectRow struct {
+	Name string
+}
+
+func TestCollect(t *testing.T) {
+	rows := &fakeRows{values: []string{"alice", "bob"}}
+
+	result, err := Collect(rows, func(row *collectRow) error {
+		return rows.Scan(&row.Name)
+	})
+	if err != nil {
+		t.Fatalf("Collect() error: %v", err)
+	}
+	if len(result) != 2 || result[0].Name != "alice" || result[1].Name != "bob" {
+		t.Errorf("Collect() = %v, expected [{alice} {bob}]", result)
+	}
+	if !rows.closed {
+		t.Error("Collect() did not close rows")
+	}
+}
+
+func TestCollect_Errors(t *testing.T) {
+	rows := &fakeRows{values: []string{"alice", "bad"}}
+	_, err := Collect(rows, func(row *collectRow) error {
+		return rows.Scan(&row.Name)
+	})
+	if err == nil || !strings.Contains(err.Error(), "failed to scan row 2: cannot scan bad") {
+		t.Errorf("Collect() error = %v, expected scan error for row 2", err)
+	}
+	if !rows.closed {
+		t.Error("Collect() did not close rows after a scan error")
+	}
+
+	rows = &fakeRows{err: errors.New("connection lost")}
+	if _, err := Collect(rows, func(row *collectRow) error { return nil }); err == nil || err.Error() != "connection lost" {
+		t.Errorf("Collect() error = %v, expected rows error", err)
+	}
+}
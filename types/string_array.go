@@ -22,6 +22,8 @@ func (p *StringArray) Scan(value any) error {
 
 	switch v := value.(type) {
 	case nil:
+		// Reset, so a reused value does not keep the previous row's array
+		*p = nil
 		return nil
 	case string:
 		data = []byte(v)
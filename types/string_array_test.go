@@ -0,0 +1,24 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStringArray_ScanNull(t *testing.T) {
+	var p StringArray
+	if err := p.Scan([]byte(`["a","b"]`)); err != nil {
+		t.Fatalf("Scan() error: %v", err)
+	}
+	if !reflect.DeepEqual(p, StringArray{"a", "b"}) {
+		t.Fatalf("Scan() = %v, expected [a b]", p)
+	}
+
+	// Scanning NULL into a reused value must not keep the previous array
+	if err := p.Scan(nil); err != nil {
+		t.Fatalf("Scan(nil) error: %v", err)
+	}
+	if p != nil {
+		t.Errorf("Scan(nil) = %v, expected nil", p)
+	}
+}
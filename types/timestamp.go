@@ -0,0 +1,51 @@
+package types
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"time"
+)
+
+// Timestamp holds a DATETIME or TIMESTAMP value normalized to UTC, so values
+// compare consistently regardless of the connection's time_zone and the
+// driver's loc parameter. Valid is false for NULL.
+type Timestamp struct {
+	Time  time.Time
+	Valid bool
+}
+
+// NewTimestamp creates a valid Timestamp holding t in UTC
+func NewTimestamp(t time.Time) Timestamp {
+	return Timestamp{Time: t.UTC(), Valid: true}
+}
+
+// Value implements the driver.Valuer interface and writes the time in UTC
+func (ts Timestamp) Value() (driver.Value, error) {
+	if !ts.Valid {
+		return nil, nil
+	}
+	return ts.Time.UTC(), nil
+}
+
+// Scan implements the sql.Scanner interface and normalizes the time to UTC.
+// The DSN needs parseTime=true so the driver returns time.Time values.
+func (ts *Timestamp) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		*ts = Timestamp{}
+		return nil
+	case time.Time:
+		*ts = NewTimestamp(v)
+		return nil
+	default:
+		return fmt.Errorf("unsupported type for Timestamp: %T", value)
+	}
+}
+
+// String returns the time in RFC 3339 format, or "NULL" if the timestamp is not valid
+func (ts Timestamp) String() string {
+	if !ts.Valid {
+		return "NULL"
+	}
+	return ts.Time.Format(time.RFC3339Nano)
+}
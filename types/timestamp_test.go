@@ -0,0 +1,49 @@
+package types
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimestamp_ScanNormalizesToUTC(t *testing.T) {
+	berlin := time.FixedZone("CEST", 2*60*60)
+	local := time.Date(2024, 6, 1, 14, 30, 0, 0, berlin)
+
+	var ts Timestamp
+	if err := ts.Scan(local); err != nil {
+		t.Fatalf("Scan() error: %v", err)
+	}
+	if !ts.Valid {
+		t.Fatal("Scan() Valid = false, expected true")
+	}
+	if ts.Time.Location() != time.UTC {
+		t.Errorf("Scan() location = %v, expected UTC", ts.Time.Location())
+	}
+	if expected := time.Date(2024, 6, 1, 12, 30, 0, 0, time.UTC); !ts.Time.Equal(expected) || ts.Time.Hour() != 12 {
+		t.Errorf("Scan() = %v, expected %v", ts.Time, expected)
+	}
+
+	value, err := Timestamp{Time: local, Valid: true}.Value()
+	if err != nil {
+		t.Fatalf("Value() error: %v", err)
+	}
+	if written, ok := value.(time.Time); !ok || written.Location() != time.UTC || written.Hour() != 12 {
+		t.Errorf("Value() = %v, expected 12:30 UTC", value)
+	}
+}
+
+func TestTimestamp_ScanNull(t *testing.T) {
+	ts := NewTimestamp(time.Now())
+	if err := ts.Scan(nil); err != nil {
+		t.Fatalf("Scan(nil) error: %v", err)
+	}
+	if ts.Valid {
+		t.Error("Scan(nil) Valid = true, expected false")
+	}
+	if value, _ := ts.Value(); value != nil {
+		t.Errorf("Value() of NULL Timestamp = %v, expected nil", value)
+	}
+	if err := ts.Scan("2024-06-01 12:30:00"); err == nil {
+		t.Error("Scan(string) expected error, got nil")
+	}
+}
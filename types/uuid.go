@@ -0,0 +1,86 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+)
+
+// UUID holds the value of a MariaDB UUID column. MariaDB returns UUIDs in
+// their canonical 36-character text form; Valid is false for NULL.
+type UUID struct {
+	Bytes [16]byte
+	Valid bool
+}
+
+// ParseUUID parses the canonical xxxxxxxx-xxxx-xxxx-xxxx-xxxxxxxxxxxx form
+// or 32 hex digits without dashes
+func ParseUUID(text string) (UUID, error) {
+	var u UUID
+
+	digits := text
+	switch len(text) {
+	case 36:
+		if text[8] != '-' || text[13] != '-' || text[18] != '-' || text[23] != '-' {
+			return UUID{}, fmt.Errorf("invalid UUID: %q", text)
+		}
+		digits = text[:8] + text[9:13] + text[14:18] + text[19:23] + text[24:]
+	case 32:
+	default:
+		return UUID{}, fmt.Errorf("invalid UUID: %q", text)
+	}
+
+	if _, err := hex.Decode(u.Bytes[:], []byte(digits)); err != nil {
+		return UUID{}, fmt.Errorf("invalid UUID: %q", text)
+	}
+
+	u.Valid = true
+	return u, nil
+}
+
+// Value implements the driver.Valuer interface
+func (u UUID) Value() (driver.Value, error) {
+	if !u.Valid {
+		return nil, nil
+	}
+	return u.String(), nil
+}
+
+// Scan implements the sql.Scanner interface. It accepts the text form and
+// the 16 raw bytes of a UUID stored in BINARY(16).
+func (u *UUID) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		*u = UUID{}
+		return nil
+	case []byte:
+		if len(v) == 16 {
+			copy(u.Bytes[:], v)
+			u.Valid = true
+			return nil
+		}
+		return u.scanText(string(v))
+	case string:
+		return u.scanText(v)
+	default:
+		return fmt.Errorf("unsupported type for UUID: %T", value)
+	}
+}
+
+func (u *UUID) scanText(text string) error {
+	parsed, err := ParseUUID(text)
+	if err != nil {
+		return err
+	}
+	*u = parsed
+	return nil
+}
+
+// String returns the canonical text form, or "NULL" if the UUID is not valid
+func (u UUID) String() string {
+	if !u.Valid {
+		return "NULL"
+	}
+	b := u.Bytes
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -0,0 +1,44 @@
+package types
+
+import "testing"
+
+func TestUUID_RoundTrip(t *testing.T) {
+	const text = "123e4567-e89b-12d3-a456-426614174000"
+
+	for _, value := range []any{text, []byte(text), []byte("123e4567e89b12d3a456426614174000")} {
+		var u UUID
+		if err := u.Scan(value); err != nil {
+			t.Fatalf("Scan(%v) error: %v", value, err)
+		}
+
+		result, err := u.Value()
+		if err != nil {
+			t.Fatalf("Value() error: %v", err)
+		}
+		if result != text {
+			t.Errorf("UUID round trip of %v returned %v", value, result)
+		}
+	}
+}
+
+func TestUUID_Scan(t *testing.T) {
+	raw := []byte{0x12, 0x3e, 0x45, 0x67, 0xe8, 0x9b, 0x12, 0xd3, 0xa4, 0x56, 0x42, 0x66, 0x14, 0x17, 0x40, 0x00}
+
+	var u UUID
+	if err := u.Scan(raw); err != nil {
+		t.Fatalf("Scan(raw bytes) error: %v", err)
+	}
+	if u.String() != "123e4567-e89b-12d3-a456-426614174000" {
+		t.Errorf("Scan(raw bytes) = %s", u)
+	}
+
+	if err := u.Scan(nil); err != nil || u.Valid || u.String() != "NULL" {
+		t.Errorf("Scan(nil) = %v, %v, expected invalid UUID", u, err)
+	}
+
+	for _, invalid := range []any{"not-a-uuid", "123e4567+e89b-12d3-a456-426614174000", "123e4567-e89b-12d3-a456-42661417400g", 42} {
+		if err := u.Scan(invalid); err == nil {
+			t.Errorf("Scan(%v) expected error, got nil", invalid)
+		}
+	}
+}
@@ -17,6 +17,15 @@ type Vector[T VectorElement] struct {
 	Valid     bool
 }
 
+// Element type codes of the [type:1][dimension:4][elements] format written
+// by Vector.Value and read back by Vector.Scan
+const (
+	VectorFloat32 byte = 1
+	VectorFloat64 byte = 2
+	VectorInt32   byte = 3
+	VectorInt64   byte = 4
+)
+
 // VectorElement defines the supported element types for vectors
 type VectorElement interface {
 	~float32 | ~float64 | ~int32 | ~int64
@@ -46,16 +55,16 @@ func (v Vector[T]) Value() (driver.Value, error) {
 	switch any(v.Data[0]).(type) {
 	case float32:
 		elementSize = 4
-		elementType = 1 // FLOAT
+		elementType = VectorFloat32
 	case float64:
 		elementSize = 8
-		elementType = 2 // DOUBLE
+		elementType = VectorFloat64
 	case int32:
 		elementSize = 4
-		elementType = 3 // INT
+		elementType = VectorInt32
 	case int64:
 		elementSize = 8
-		elementType = 4 // BIGINT
+		elementType = VectorInt64
 	default:
 		return nil, fmt.Errorf("unsupported vector element type")
 	}
@@ -73,13 +82,13 @@ func (v Vector[T]) Value() (driver.Value, error) {
 	offset := 5
 	for _, elem := range v.Data {
 		switch elementType {
-		case 1: // float32
+		case VectorFloat32:
 			binary.LittleEndian.PutUint32(data[offset:offset+4], math.Float32bits(float32(any(elem).(float32))))
-		case 2: // float64
+		case VectorFloat64:
 			binary.LittleEndian.PutUint64(data[offset:offset+8], math.Float64bits(float64(any(elem).(float64))))
-		case 3: // int32
+		case VectorInt32:
 			binary.LittleEndian.PutUint32(data[offset:offset+4], uint32(any(elem).(int32)))
-		case 4: // int64
+		case VectorInt64:
 			binary.LittleEndian.PutUint64(data[offset:offset+8], uint64(any(elem).(int64)))
 		}
 		offset += elementSize
@@ -88,6 +97,36 @@ func (v Vector[T]) Value() (driver.Value, error) {
 	return data, nil
 }
 
+// ValueText returns the vector in the bracketed text form VEC_FromText
+// expects, such as "[1,2.5,-0.125]", for inserting through SQL text instead of
+// the binary form of Value. Floats use the shortest representation that reads
+// back to the same value; NaN and infinities have no text form.
+func (v Vector[T]) ValueText() (driver.Value, error) {
+	if !v.Valid || len(v.Data) == 0 {
+		return nil, nil
+	}
+
+	parts := make([]string, len(v.Data))
+	for i, elem := range v.Data {
+		switch x := any(elem).(type) {
+		case float32:
+			if math.IsNaN(float64(x)) || math.IsInf(float64(x), 0) {
+				return nil, fmt.Errorf("vector element %d is %v, which has no text form", i, x)
+			}
+			parts[i] = strconv.FormatFloat(float64(x), 'g', -1, 32)
+		case float64:
+			if math.IsNaN(x) || math.IsInf(x, 0) {
+				return nil, fmt.Errorf("vector element %d is %v, which has no text form", i, x)
+			}
+			parts[i] = strconv.FormatFloat(x, 'g', -1, 64)
+		default:
+			parts[i] = fmt.Sprintf("%v", elem)
+		}
+	}
+
+	return "[" + strings.Join(parts, ",") + "]", nil
+}
+
 // Scan implements the sql.Scanner interface
 func (v *Vector[T]) Scan(value interface{}) error {
 	if value == nil {
@@ -106,6 +145,16 @@ func (v *Vector[T]) Scan(value interface{}) error {
 		return fmt.Errorf("unsupported type for Vector: %T", value)
 	}
 
+	// Data without our [type][dimension] header is a packed little-endian
+	// array as returned by MariaDB itself. A headered vector is always
+	// 5 + dimension*elementSize bytes long, which is never a multiple of the
+	// element size, so the two formats cannot be confused.
+	if !hasVectorHeader(data) {
+		if size := v.elementSize(); size > 0 && len(data)%size == 0 {
+			return v.scanPacked(data, size)
+		}
+	}
+
 	// Parse binary data
 	if len(data) < 5 {
 		return fmt.Errorf("vector data too short: %d bytes", len(data))
@@ -120,9 +169,9 @@ func (v *Vector[T]) Scan(value interface{}) error {
 	// Determine element size
 	var elementSize int
 	switch elementType {
-	case 1, 3: // float32, int32
+	case VectorFloat32, VectorInt32:
 		elementSize = 4
-	case 2, 4: // float64, int64
+	case VectorFloat64, VectorInt64:
 		elementSize = 8
 	default:
 		return fmt.Errorf("unknown vector element type: %d", elementType)
@@ -143,15 +192,15 @@ func (v *Vector[T]) Scan(value interface{}) error {
 		var elem interface{}
 		
 		switch elementType {
-		case 1: // float32
+		case VectorFloat32:
 			bits := binary.LittleEndian.Uint32(data[offset : offset+4])
 			elem = math.Float32frombits(bits)
-		case 2: // float64
+		case VectorFloat64:
 			bits := binary.LittleEndian.Uint64(data[offset : offset+8])
 			elem = math.Float64frombits(bits)
-		case 3: // int32
+		case VectorInt32:
 			elem = int32(binary.LittleEndian.Uint32(data[offset : offset+4]))
-		case 4: // int64
+		case VectorInt64:
 			elem = int64(binary.LittleEndian.Uint64(data[offset : offset+8]))
 		}
 		
@@ -166,9 +215,76 @@ func (v *Vector[T]) Scan(value interface{}) error {
 	return nil
 }
 
+// hasVectorHeader reports whether data is exactly a headered vector:
+// a known element type byte, a dimension and dimension elements
+func hasVectorHeader(data []byte) bool {
+	if len(data) < 5 {
+		return false
+	}
+
+	var elementSize int
+	switch data[0] {
+	case VectorFloat32, VectorInt32:
+		elementSize = 4
+	case VectorFloat64, VectorInt64:
+		elementSize = 8
+	default:
+		return false
+	}
+
+	dimension := int(binary.LittleEndian.Uint32(data[1:5]))
+	return len(data) == 5+dimension*elementSize
+}
+
+// elementSize returns the size in bytes of the vector's element type
+func (v *Vector[T]) elementSize() int {
+	var zero T
+	switch any(zero).(type) {
+	case float32, int32:
+		return 4
+	case float64, int64:
+		return 8
+	default:
+		return 0
+	}
+}
+
+// scanPacked parses a headerless little-endian array of T
+func (v *Vector[T]) scanPacked(data []byte, elementSize int) error {
+	dimension := len(data) / elementSize
+	elements := make([]T, dimension)
+
+	for i := 0; i < dimension; i++ {
+		chunk := data[i*elementSize : (i+1)*elementSize]
+
+		var elem interface{}
+		switch any(elements[0]).(type) {
+		case float32:
+			elem = math.Float32frombits(binary.LittleEndian.Uint32(chunk))
+		case float64:
+			elem = math.Float64frombits(binary.LittleEndian.Uint64(chunk))
+		case int32:
+			elem = int32(binary.LittleEndian.Uint32(chunk))
+		case int64:
+			elem = int64(binary.LittleEndian.Uint64(chunk))
+		}
+
+		elements[i] = elem.(T)
+	}
+
+	v.Data = elements
+	v.Dimension = dimension
+	v.Valid = true
+
+	return nil
+}
+
 // scanFromString parses vector from string representation like "[1.0, 2.0, 3.0]"
 func (v *Vector[T]) scanFromString(s string) error {
 	s = strings.TrimSpace(s)
+	if strings.HasPrefix(s, "{") {
+		return fmt.Errorf("expected flat numeric array, got object: %s", s)
+	}
 	if !strings.HasPrefix(s, "[") || !strings.HasSuffix(s, "]") {
 		return fmt.Errorf("invalid vector string format: %s", s)
 	}
@@ -176,6 +292,15 @@ func (v *Vector[T]) scanFromString(s string) error {
 	// Remove brackets
 	s = s[1 : len(s)-1]
 	s = strings.TrimSpace(s)
+
+	// Check the shape first, so nested arrays and objects fail once instead of per element
+	if i := strings.IndexAny(s, "[]{}"); i >= 0 {
+		shape := "nested array"
+		if s[i] == '{' || s[i] == '}' {
+			shape = "object"
+		}
+		return fmt.Errorf("expected flat numeric array, got %s: [%s]", shape, s)
+	}
 	
 	if s == "" {
 		v.Data = []T{}
@@ -0,0 +1,57 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// MeanVector returns the element-wise mean of a batch of vectors. Elements
+// are promoted to float64, so integer vectors average exactly. It fails on
+// an empty batch, a NULL vector or vectors of differing dimensions.
+func MeanVector[T VectorElement](vs []Vector[T]) (Vector[float64], error) {
+	if len(vs) == 0 {
+		return Vector[float64]{}, errors.New("mean of an empty vector batch")
+	}
+
+	dimension := len(vs[0].Data)
+	sum := make([]float64, dimension)
+	for i, v := range vs {
+		if !v.Valid {
+			return Vector[float64]{}, fmt.Errorf("vector %d is NULL", i)
+		}
+		if len(v.Data) != dimension {
+			return Vector[float64]{}, fmt.Errorf("vector %d has dimension %d, expected %d", i, len(v.Data), dimension)
+		}
+		for j, elem := range v.Data {
+			sum[j] += float64(elem)
+		}
+	}
+
+	for j := range sum {
+		sum[j] /= float64(len(vs))
+	}
+	return NewVector(sum), nil
+}
+
+// CentroidDistance returns the Euclidean distance between v and the mean of
+// vs, for example to spot an embedding that is far off its batch
+func CentroidDistance[T VectorElement](vs []Vector[T], v Vector[T]) (float64, error) {
+	centroid, err := MeanVector(vs)
+	if err != nil {
+		return 0, err
+	}
+	if !v.Valid {
+		return 0, errors.New("distance of a NULL vector")
+	}
+	if len(v.Data) != len(centroid.Data) {
+		return 0, fmt.Errorf("vector has dimension %d, expected %d", len(v.Data), len(centroid.Data))
+	}
+
+	var sum float64
+	for i, elem := range v.Data {
+		diff := float64(elem) - centroid.Data[i]
+		sum += diff * diff
+	}
+	return math.Sqrt(sum), nil
+}
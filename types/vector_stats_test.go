@@ -0,0 +1,62 @@
+package types
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMeanVector(t *testing.T) {
+	batch := []Vector[int32]{
+		NewVector([]int32{1, 2, 3}),
+		NewVector([]int32{2, 4, 6}),
+		NewVector([]int32{4, 0, 0}),
+		NewVector([]int32{1, 2, 4}),
+	}
+
+	mean, err := MeanVector(batch)
+	if err != nil {
+		t.Fatalf("MeanVector() error: %v", err)
+	}
+	if expected := []float64{2, 2, 3.25}; !mean.Valid || mean.Dimension != 3 || !reflect.DeepEqual(mean.Data, expected) {
+		t.Errorf("MeanVector() = %+v, expected %v", mean, expected)
+	}
+}
+
+func TestMeanVector_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		batch []Vector[float32]
+	}{
+		{"empty", nil},
+		{"dimension mismatch", []Vector[float32]{NewVector([]float32{1, 2}), NewVector([]float32{1, 2, 3})}},
+		{"null vector", []Vector[float32]{NewVector([]float32{1, 2}), {}}},
+	}
+
+	for _, test := range tests {
+		if _, err := MeanVector(test.batch); err == nil {
+			t.Errorf("MeanVector(%s) expected error, got nil", test.name)
+		}
+	}
+}
+
+func TestCentroidDistance(t *testing.T) {
+	batch := []Vector[float64]{
+		NewVector([]float64{0, 0}),
+		NewVector([]float64{2, 4}),
+	}
+
+	distance, err := CentroidDistance(batch, NewVector([]float64{4, 6}))
+	if err != nil {
+		t.Fatalf("CentroidDistance() error: %v", err)
+	}
+	if distance != 5 {
+		t.Errorf("CentroidDistance() = %v, expected 5", distance)
+	}
+
+	if _, err := CentroidDistance(batch, NewVector([]float64{1})); err == nil {
+		t.Error("CentroidDistance() with a dimension mismatch expected error, got nil")
+	}
+	if _, err := CentroidDistance(nil, NewVector([]float64{1})); err == nil {
+		t.Error("CentroidDistance() of an empty batch expected error, got nil")
+	}
+}
@@ -1,6 +1,10 @@
 package types
 
 import (
+	"database/sql/driver"
+	"encoding/binary"
+	"math"
+	"strings"
 	"testing"
 )
 
@@ -128,6 +132,29 @@ func TestVector_StringScan(t *testing.T) {
 	}
 }
 
+func TestVector_StringScanShape(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"[[1.0, 2.0], [3.0, 4.0]]", "expected flat numeric array, got nested array"},
+		{"[1.0, [2.0]]", "expected flat numeric array, got nested array"},
+		{`[{"x": 1}, {"x": 2}]`, "expected flat numeric array, got object"},
+		{`{"data": [1.0, 2.0]}`, "expected flat numeric array, got object"},
+	}
+
+	for _, test := range tests {
+		var v Vector[float32]
+		err := v.Scan(test.input)
+		if err == nil || !strings.HasPrefix(err.Error(), test.expected) {
+			t.Errorf("Scan(%q) error = %v, expected %q", test.input, err, test.expected)
+		}
+		if v.Valid {
+			t.Errorf("Scan(%q) left the vector valid", test.input)
+		}
+	}
+}
+
 func TestVector_EmptyVector(t *testing.T) {
 	// Test empty vector
 	var v Vector[float32]
@@ -189,3 +216,122 @@ func TestVector_Len(t *testing.T) {
 		t.Errorf("Expected length 5, got %d", v.Len())
 	}
 }
+
+func TestVector_HeaderlessFloat32Scan(t *testing.T) {
+	// MariaDB returns VECTOR columns as packed little-endian float32 values
+	expected := []float32{1.0, -2.5, 3.25}
+	data := make([]byte, 4*len(expected))
+	for i, f := range expected {
+		binary.LittleEndian.PutUint32(data[i*4:], math.Float32bits(f))
+	}
+
+	var v Vector[float32]
+	if err := v.Scan(data); err != nil {
+		t.Fatalf("Scan headerless data error: %v", err)
+	}
+
+	if !v.Valid {
+		t.Error("Vector should be valid after headerless scan")
+	}
+
+	if v.Dimension != len(expected) {
+		t.Fatalf("Expected dimension %d, got %d", len(expected), v.Dimension)
+	}
+
+	for i, exp := range expected {
+		if v.Data[i] != exp {
+			t.Errorf("Data mismatch at index %d: expected %f, got %f", i, exp, v.Data[i])
+		}
+	}
+
+	// A headerless blob whose first byte looks like an element type code
+	// must still be read as packed data
+	data = make([]byte, 8)
+	binary.LittleEndian.PutUint32(data[0:4], 1)
+	binary.LittleEndian.PutUint32(data[4:8], math.Float32bits(2))
+
+	var v2 Vector[float32]
+	if err := v2.Scan(data); err != nil {
+		t.Fatalf("Scan headerless data error: %v", err)
+	}
+	if v2.Dimension != 2 || v2.Data[1] != 2 {
+		t.Errorf("Expected 2 packed elements ending in 2, got %v", v2.Data)
+	}
+
+	// Lengths that fit neither format are rejected
+	var v3 Vector[float32]
+	if err := v3.Scan([]byte{9, 9, 9}); err == nil {
+		t.Error("Scan of 3 bytes should fail")
+	}
+}
+
+func TestVectorElementTypeCodes(t *testing.T) {
+	// The codes are part of the wire format and must never change
+	if VectorFloat32 != 1 || VectorFloat64 != 2 || VectorInt32 != 3 || VectorInt64 != 4 {
+		t.Fatalf("element type codes = %d, %d, %d, %d, expected 1, 2, 3, 4", VectorFloat32, VectorFloat64, VectorInt32, VectorInt64)
+	}
+
+	values := []struct {
+		name     string
+		valuer   driver.Valuer
+		expected byte
+	}{
+		{"float32", NewVector([]float32{1}), VectorFloat32},
+		{"float64", NewVector([]float64{1}), VectorFloat64},
+		{"int32", NewVector([]int32{1}), VectorInt32},
+		{"int64", NewVector([]int64{1}), VectorInt64},
+	}
+
+	for _, test := range values {
+		value, err := test.valuer.Value()
+		if err != nil {
+			t.Fatalf("Value() of %s vector error: %v", test.name, err)
+		}
+		if code := value.([]byte)[0]; code != test.expected {
+			t.Errorf("Value() of %s vector has element type %d, expected %d", test.name, code, test.expected)
+		}
+	}
+}
+
+func TestVector_ValueText(t *testing.T) {
+	tests := []struct {
+		name      string
+		valueText func() (driver.Value, error)
+		expected  driver.Value
+	}{
+		{"float32", NewVector([]float32{1, 2.5, -0.125, 0.1}).ValueText, "[1,2.5,-0.125,0.1]"},
+		{"float64", NewVector([]float64{0.1, 1e-7, 123456789}).ValueText, "[0.1,1e-07,1.23456789e+08]"},
+		{"int32", NewVector([]int32{1, -2, 3}).ValueText, "[1,-2,3]"},
+		{"int64", NewVector([]int64{9007199254740993}).ValueText, "[9007199254740993]"},
+		{"null", Vector[float32]{}.ValueText, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			text, err := test.valueText()
+			if err != nil {
+				t.Fatalf("ValueText() error: %v", err)
+			}
+			if text != test.expected {
+				t.Errorf("ValueText() = %v, expected %v", text, test.expected)
+			}
+		})
+	}
+
+	// The text form reads back to the same elements
+	original := NewVector([]float32{0.1, 3.14159, -2e-5})
+	text, _ := original.ValueText()
+	var scanned Vector[float32]
+	if err := scanned.Scan(text); err != nil {
+		t.Fatalf("Scan(%v) error: %v", text, err)
+	}
+	for i := range original.Data {
+		if scanned.Data[i] != original.Data[i] {
+			t.Errorf("element %d = %v after a round trip, expected %v", i, scanned.Data[i], original.Data[i])
+		}
+	}
+
+	if _, err := NewVector([]float32{float32(math.Inf(1))}).ValueText(); err == nil {
+		t.Error("ValueText() of an infinite element should fail")
+	}
+}